@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/gerladeno/chat-service/internal/config"
+	afcdlqreplayer "github.com/gerladeno/chat-service/internal/services/afc-dlq-replayer"
+	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
+)
+
+var (
+	configPath    = flag.String("config", "configs/config.toml", "Path to config file")
+	consumerGroup = flag.String("group", "afc-dlq-replay", "Consumer group to read the DLQ with, offsets are not committed")
+	errorContains = flag.String("error", "", "Replay only the messages with the substring in the last error")
+	from          = flag.String("from", "", "Replay only the messages sent to the DLQ after the time, RFC3339")
+	to            = flag.String("to", "", "Replay only the messages sent to the DLQ before the time, RFC3339")
+	maxCount      = flag.Int("max", 0, "Max number of messages to replay, 0 is unlimited")
+	idleTimeout   = flag.Duration("idle", 5*time.Second, "Stop if there are no new messages in the DLQ for the duration")
+	dryRun        = flag.Bool("dry-run", false, "Only print the messages to replay")
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run app: %v", err)
+	}
+}
+
+func run() (errReturned error) {
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.ParseAndValidate(*configPath)
+	if err != nil {
+		return fmt.Errorf("parse and validate config %q: %v", *configPath, err)
+	}
+	afcCfg := cfg.Services.AFCVerdictProcessor
+
+	fromTime, err := parseTime(*from)
+	if err != nil {
+		return fmt.Errorf("parse from: %v", err)
+	}
+	toTime, err := parseTime(*to)
+	if err != nil {
+		return fmt.Errorf("parse to: %v", err)
+	}
+
+	if k := cfg.Broker.Kind; k != "" && k != "kafka" {
+		return fmt.Errorf("only kafka dlq can be replayed, not %q", k)
+	}
+
+	reader, err := afcverdictsprocessor.NewKafkaSubscriberFactory(afcCfg.Brokers)(*consumerGroup, afcCfg.VerdictTopicDLQ)
+	if err != nil {
+		return fmt.Errorf("init dlq reader: %v", err)
+	}
+	defer func() {
+		errReturned = multierr.Append(errReturned, reader.Close())
+	}()
+	writer := afcverdictsprocessor.NewKafkaDLQPublisher(afcCfg.Brokers, afcCfg.VerdictTopic)
+	defer func() {
+		errReturned = multierr.Append(errReturned, writer.Close())
+	}()
+
+	replayer, err := afcdlqreplayer.New(afcdlqreplayer.NewOptions(
+		reader,
+		writer,
+		os.Stdout,
+		afcdlqreplayer.WithErrorContains(*errorContains),
+		afcdlqreplayer.WithFrom(fromTime),
+		afcdlqreplayer.WithTo(toTime),
+		afcdlqreplayer.WithMaxCount(*maxCount),
+		afcdlqreplayer.WithIdleTimeout(*idleTimeout),
+		afcdlqreplayer.WithDryRun(*dryRun),
+	))
+	if err != nil {
+		return fmt.Errorf("init dlq replayer: %v", err)
+	}
+
+	n, err := replayer.Run(ctx)
+	log.Printf("%d messages selected from %s (dry run: %t)", n, afcCfg.VerdictTopicDLQ, *dryRun)
+	if err != nil {
+		return fmt.Errorf("replay dlq: %v", err)
+	}
+	return nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
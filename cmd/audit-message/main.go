@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/multierr"
+
+	"github.com/gerladeno/chat-service/internal/auditseal"
+	"github.com/gerladeno/chat-service/internal/config"
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	"github.com/gerladeno/chat-service/internal/store"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+var (
+	configPath = flag.String("config", "configs/config.toml", "Path to config file")
+	keyPath    = flag.String("key", "", "Path to the auditor RSA private key (PEM)")
+	messageID  = flag.String("id", "", "ID of the redacted message")
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run app: %v", err)
+	}
+}
+
+func run() (errReturned error) {
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.ParseAndValidate(*configPath)
+	if err != nil {
+		return fmt.Errorf("parse and validate config %q: %v", *configPath, err)
+	}
+
+	msgID, err := types.Parse[types.MessageID](*messageID)
+	if err != nil {
+		return fmt.Errorf("parse message id: %v", err)
+	}
+
+	keyData, err := os.ReadFile(*keyPath)
+	if err != nil {
+		return fmt.Errorf("read key: %v", err)
+	}
+	key, err := auditseal.ParsePrivateKey(keyData)
+	if err != nil {
+		return fmt.Errorf("parse key: %v", err)
+	}
+
+	psqlClient, err := store.NewPSQLClient(store.NewPSQLOptions(
+		cfg.DB.Postgres.Addr,
+		cfg.DB.Postgres.User,
+		cfg.DB.Postgres.Password,
+		cfg.DB.Postgres.Database,
+	))
+	if err != nil {
+		return fmt.Errorf("init psql client: %v", err)
+	}
+	defer func() {
+		errReturned = multierr.Append(errReturned, psqlClient.Close())
+	}()
+
+	msgRepo, err := messagesrepo.New(messagesrepo.NewOptions(store.NewDatabase(psqlClient)))
+	if err != nil {
+		return fmt.Errorf("init messages repo: %v", err)
+	}
+
+	sealed, err := msgRepo.GetSealedBody(ctx, msgID)
+	if err != nil {
+		return fmt.Errorf("get sealed body: %v", err)
+	}
+	body, err := auditseal.Open(key, sealed)
+	if err != nil {
+		return fmt.Errorf("open sealed body: %v", err)
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(body))
+	return err
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+	"github.com/gerladeno/chat-service/internal/config"
+	"github.com/gerladeno/chat-service/internal/jwks"
+	"github.com/gerladeno/chat-service/internal/middlewares"
+)
+
+const (
+	authProviderKeycloak = "keycloak"
+	authProviderOIDC     = "oidc"
+
+	authModeActive  = "active"
+	authModePassive = "passive"
+)
+
+// authProvider is the identity provider the servers verify the tokens with.
+type authProvider struct {
+	introspector middlewares.Introspector
+	keys         *jwks.RemoteSet
+}
+
+// newAuthProviders returns the providers referenced by the servers by their names.
+func newAuthProviders(cfg config.ClientConfig, servers []config.ServerConfig, isProd bool) (map[string]authProvider, error) {
+	providers := make(map[string]authProvider, len(servers))
+	for _, srv := range servers {
+		name := authProviderName(srv)
+		if _, ok := providers[name]; ok {
+			continue
+		}
+
+		var (
+			provider authProvider
+			err      error
+		)
+		switch name {
+		case authProviderKeycloak:
+			provider, err = newKeycloakProvider(cfg.Keycloak, isProd)
+		case authProviderOIDC:
+			provider, err = newOIDCProvider(cfg.OIDC, isProd)
+		default:
+			err = fmt.Errorf("unknown auth provider %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		providers[name] = provider
+	}
+	return providers, nil
+}
+
+func newKeycloakProvider(cfg *config.Keycloak, isProd bool) (authProvider, error) {
+	if cfg == nil {
+		return authProvider{}, fmt.Errorf("auth provider %q is not configured in [clients.keycloak]", authProviderKeycloak)
+	}
+
+	kcClient, err := keycloakclient.New(keycloakclient.NewOptions(
+		cfg.BasePath,
+		cfg.Realm,
+		cfg.ClientID,
+		cfg.ClientSecret,
+		isProd,
+		keycloakclient.WithDebugMode(cfg.DebugMode),
+	))
+	if err != nil {
+		return authProvider{}, fmt.Errorf("init keycloak client: %v", err)
+	}
+	introspector, err := newIntrospector(authProviderKeycloak, kcClient, cfg.IntrospectionCache)
+	if err != nil {
+		return authProvider{}, err
+	}
+	return authProvider{
+		introspector: introspector,
+		keys:         jwks.NewRemoteSet(kcClient, cfg.JWKSMinRefreshInterval),
+	}, nil
+}
+
+func newOIDCProvider(cfg *config.OIDCConfig, isProd bool) (authProvider, error) {
+	if cfg == nil {
+		return authProvider{}, fmt.Errorf("auth provider %q is not configured in [clients.oidc]", authProviderOIDC)
+	}
+
+	oidcClient, err := oidcclient.New(oidcclient.NewOptions(
+		cfg.Issuer,
+		cfg.ClientID,
+		cfg.ClientSecret,
+		isProd,
+		oidcclient.WithIntrospectionEndpoint(cfg.IntrospectionEndpoint),
+		oidcclient.WithDebugMode(cfg.DebugMode),
+	))
+	if err != nil {
+		return authProvider{}, fmt.Errorf("init oidc client: %v", err)
+	}
+	introspector, err := newIntrospector(authProviderOIDC, oidcClient, cfg.IntrospectionCache)
+	if err != nil {
+		return authProvider{}, err
+	}
+	return authProvider{
+		introspector: introspector,
+		keys:         jwks.NewRemoteSet(oidcClient, cfg.JWKSMinRefreshInterval),
+	}, nil
+}
+
+// authProviderName returns the provider the server verifies the tokens with, Keycloak by default.
+func authProviderName(cfg config.ServerConfig) string {
+	if cfg.Auth.Provider == "" {
+		return authProviderKeycloak
+	}
+	return cfg.Auth.Provider
+}
+
+// newIntrospector wraps the introspector with the cache unless it is disabled.
+func newIntrospector(
+	provider string,
+	introspector middlewares.Introspector,
+	cfg config.IntrospectionCacheConfig,
+) (middlewares.Introspector, error) {
+	if cfg.MaxTTL == 0 {
+		return introspector, nil
+	}
+
+	cachingIntrospector, err := middlewares.NewCachingIntrospector(middlewares.NewCachingIntrospectorOptions(
+		introspector,
+		middlewares.WithMaxTTL(cfg.MaxTTL),
+		middlewares.WithMaxEntries(cfg.MaxEntries),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("init %s introspection cache: %v", provider, err)
+	}
+	registerer := prometheus.WrapRegistererWith(prometheus.Labels{"provider": provider}, prometheus.DefaultRegisterer)
+	if err := registerer.Register(cachingIntrospector); err != nil {
+		return nil, fmt.Errorf("register %s introspection cache metrics: %v", provider, err)
+	}
+	return cachingIntrospector, nil
+}
+
+// newTokenVerifier verifies the tokens with the configured provider, Keycloak by default.
+func newTokenVerifier(cfg config.ServerConfig, providers map[string]authProvider) (*middlewares.OIDCTokenVerifier, error) {
+	name := authProviderName(cfg)
+	provider, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("auth provider %q is not configured", name)
+	}
+
+	roleClaim := cfg.RequiredAccess.RoleClaim
+	if roleClaim == "" {
+		roleClaim = middlewares.KeycloakRoleClaim(cfg.RequiredAccess.Resource)
+	}
+
+	var opts []middlewares.OptOIDCTokenVerifierOptionsSetter
+	switch cfg.Auth.Mode {
+	case authModeActive:
+		opts = append(opts, middlewares.WithIntrospector(provider.introspector))
+
+	case authModePassive:
+		opts = append(opts,
+			middlewares.WithKeys(provider.keys),
+			middlewares.WithIssuer(cfg.Auth.Issuer),
+			middlewares.WithAudience(cfg.Auth.Audience),
+		)
+
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", cfg.Auth.Mode)
+	}
+	return middlewares.NewOIDCTokenVerifier(middlewares.NewOIDCTokenVerifierOptions(roleClaim, cfg.RequiredAccess.Role, opts...))
+}
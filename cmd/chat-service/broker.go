@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	inmembroker "github.com/gerladeno/chat-service/internal/broker/inmem"
+	natsbroker "github.com/gerladeno/chat-service/internal/broker/nats"
+	"github.com/gerladeno/chat-service/internal/config"
+	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
+	msgproducer "github.com/gerladeno/chat-service/internal/services/msg-producer"
+)
+
+const (
+	brokerKafka = "kafka"
+	brokerNATS  = "nats"
+	brokerInMem = "inmem"
+)
+
+// transport is the message broker the services talk to AFC through.
+type transport struct {
+	messages    broker.Publisher
+	verdicts    afcverdictsprocessor.SubscriberFactory
+	verdictsDLQ broker.Publisher
+	// verdictsBatchSize is the number of the verdicts committed at once, the configured one if zero.
+	verdictsBatchSize int
+	close             func()
+}
+
+func initTransport(cfg config.Config) (*transport, error) {
+	producerCfg := cfg.Services.MsgProducer
+	afcCfg := cfg.Services.AFCVerdictProcessor
+
+	switch cfg.Broker.Kind {
+	case "", brokerKafka:
+		return &transport{
+			messages:    msgproducer.NewKafkaPublisher(producerCfg.Brokers, producerCfg.Topic, producerCfg.BatchSize),
+			verdicts:    afcverdictsprocessor.NewKafkaSubscriberFactory(afcCfg.Brokers),
+			verdictsDLQ: afcverdictsprocessor.NewKafkaDLQPublisher(afcCfg.Brokers, afcCfg.VerdictTopicDLQ),
+			close:       func() {},
+		}, nil
+
+	case brokerNATS:
+		nc, err := nats.Connect(cfg.Broker.NATS.URL)
+		if err != nil {
+			return nil, fmt.Errorf("connect to nats: %v", err)
+		}
+		js, err := nc.JetStream()
+		if err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("init jetstream: %v", err)
+		}
+		stream := cfg.Broker.NATS.Stream
+		if err := natsbroker.EnsureStream(js, stream, producerCfg.Topic, afcCfg.VerdictTopic, afcCfg.VerdictTopicDLQ); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("ensure nats stream: %v", err)
+		}
+		return &transport{
+			messages: natsbroker.NewPublisher(js, producerCfg.Topic),
+			verdicts: func(groupID string, topic string) (broker.Subscriber, error) {
+				return natsbroker.NewSubscriber(js, stream, groupID, topic)
+			},
+			verdictsDLQ: natsbroker.NewPublisher(js, afcCfg.VerdictTopicDLQ),
+			// The durable consumer delivers the next verdict after the previous one is committed.
+			verdictsBatchSize: 1,
+			close:             nc.Close,
+		}, nil
+
+	case brokerInMem:
+		if cfg.Services.LocalVerdict.AFCSLA == 0 {
+			zap.L().Warn("in-memory broker has no AFC behind it, enable the local verdicts to get the messages checked")
+		}
+		b := inmembroker.New(inmembroker.DefaultPartitions)
+		return &transport{
+			messages: b.Publisher(producerCfg.Topic),
+			verdicts: func(groupID string, topic string) (broker.Subscriber, error) {
+				return b.Subscriber(groupID, topic), nil
+			},
+			verdictsDLQ: b.Publisher(afcCfg.VerdictTopicDLQ),
+			close:       func() {},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown broker kind %q", cfg.Broker.Kind)
+}
@@ -10,10 +10,12 @@ import (
 	"syscall"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
-	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
+	"github.com/gerladeno/chat-service/internal/auditseal"
 	"github.com/gerladeno/chat-service/internal/config"
 	"github.com/gerladeno/chat-service/internal/logger"
 	chatsrepo "github.com/gerladeno/chat-service/internal/repositories/chats"
@@ -23,16 +25,19 @@ import (
 	clientevents "github.com/gerladeno/chat-service/internal/server-client/events"
 	clientv1 "github.com/gerladeno/chat-service/internal/server-client/v1"
 	serverdebug "github.com/gerladeno/chat-service/internal/server-debug"
+	managerevents "github.com/gerladeno/chat-service/internal/server-manager/events"
 	managerv1 "github.com/gerladeno/chat-service/internal/server-manager/v1"
 	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
-	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
 	inmemeventstream "github.com/gerladeno/chat-service/internal/services/event-stream/in-mem"
 	managerload "github.com/gerladeno/chat-service/internal/services/manager-load"
 	inmemmanagerpool "github.com/gerladeno/chat-service/internal/services/manager-pool/in-mem"
 	msgproducer "github.com/gerladeno/chat-service/internal/services/msg-producer"
+	msgredactor "github.com/gerladeno/chat-service/internal/services/msg-redactor"
 	"github.com/gerladeno/chat-service/internal/services/outbox"
 	clientmessageblockedjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-blocked"
 	clientmessagesentjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-sent"
+	localverdictjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/local-verdict"
 	sendclientmessagejob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/send-client-message"
 	"github.com/gerladeno/chat-service/internal/store"
 	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
@@ -58,12 +63,31 @@ func run() (errReturned error) {
 		return fmt.Errorf("parse and validate config %q: %v", *configPath, err)
 	}
 
-	if err = logger.Init(logger.NewOptions(
-		cfg.Log.Level,
+	// The searcher is shared by the local verdicts and the redaction.
+	detectors, err := parseFacts(cfg.Services.LocalVerdict.Detectors)
+	if err != nil {
+		return fmt.Errorf("parse bloodhound detector: %v", err)
+	}
+	searcher := bloodhound.NewSearcher(detectors...)
+	if f := cfg.Services.LocalVerdict.RulesFile; f != "" {
+		if err := searcher.LoadRulesFile(f); err != nil {
+			return fmt.Errorf("load bloodhound rules: %v", err)
+		}
+	}
+
+	logOpts := []logger.OptOptionsSetter{
 		logger.WithProductionMode(cfg.Global.IsProd()),
 		logger.WithSentryDSN(cfg.Sentry.DSN),
 		logger.WithEnv(cfg.Global.Env),
-	)); err != nil {
+	}
+	if cfg.Services.Redaction.Logs {
+		logSearcher, err := newLogSearcher(cfg.Services.Redaction.LogDetectors)
+		if err != nil {
+			return err
+		}
+		logOpts = append(logOpts, logger.WithRedactor(logSearcher))
+	}
+	if err = logger.Init(logger.NewOptions(cfg.Log.Level, logOpts...)); err != nil {
 		return fmt.Errorf("init logger: %v", err)
 	}
 	defer logger.Sync()
@@ -82,17 +106,22 @@ func run() (errReturned error) {
 		return fmt.Errorf("get manager swagger: %v", err)
 	}
 
-	// Keycloak
-	kcClient, err := keycloakclient.New(keycloakclient.NewOptions(
-		cfg.Clients.Keycloak.BasePath,
-		cfg.Clients.Keycloak.Realm,
-		cfg.Clients.Keycloak.ClientID,
-		cfg.Clients.Keycloak.ClientSecret,
+	// Auth
+	authProviders, err := newAuthProviders(
+		cfg.Clients,
+		[]config.ServerConfig{cfg.Servers.Client, cfg.Servers.Manager},
 		cfg.Global.IsProd(),
-		keycloakclient.WithDebugMode(cfg.Clients.Keycloak.DebugMode),
-	))
+	)
+	if err != nil {
+		return fmt.Errorf("init auth providers: %v", err)
+	}
+	clientTokenVerifier, err := newTokenVerifier(cfg.Servers.Client, authProviders)
+	if err != nil {
+		return fmt.Errorf("init client token verifier: %v", err)
+	}
+	managerTokenVerifier, err := newTokenVerifier(cfg.Servers.Manager, authProviders)
 	if err != nil {
-		return fmt.Errorf("init keycloak client: %v", err)
+		return fmt.Errorf("init manager token verifier: %v", err)
 	}
 
 	// Storage
@@ -106,6 +135,10 @@ func run() (errReturned error) {
 	if err != nil {
 		return fmt.Errorf("init psql client: %v", err)
 	}
+	// The client is closed after all services are stopped, so that outbox is able to drain its jobs.
+	defer func() {
+		errReturned = multierr.Append(errReturned, psqlClient.Close())
+	}()
 	if err = psqlClient.Schema.Create(ctx); err != nil {
 		return fmt.Errorf("migrate schema: %v", err)
 	}
@@ -130,12 +163,17 @@ func run() (errReturned error) {
 	}
 
 	// Init services
+	brokerTransport, err := initTransport(cfg)
+	if err != nil {
+		return fmt.Errorf("init broker transport: %v", err)
+	}
+	defer brokerTransport.close()
+
 	msgProducer, err := msgproducer.New(msgproducer.NewOptions(
-		msgproducer.NewKafkaWriter(
-			cfg.Services.MsgProducer.Brokers,
-			cfg.Services.MsgProducer.Topic,
-			cfg.Services.MsgProducer.BatchSize,
-		), msgproducer.WithEncryptKey(cfg.Services.MsgProducer.EncryptKey),
+		brokerTransport.messages,
+		msgproducer.WithEncryptKey(cfg.Services.MsgProducer.EncryptKey),
+		msgproducer.WithEncryptKeys(cfg.Services.MsgProducer.EncryptKeys),
+		msgproducer.WithPrimaryKeyID(cfg.Services.MsgProducer.PrimaryKeyID),
 	))
 	if err != nil {
 		return fmt.Errorf("init msg producer: %v", err)
@@ -149,10 +187,16 @@ func run() (errReturned error) {
 		cfg.Services.Outbox.ReserveFor,
 		jobsRepo,
 		db,
+		outbox.WithDedupTTL(cfg.Services.Outbox.DedupTTL),
+		outbox.WithSchedulerTick(cfg.Services.Outbox.SchedulerTick),
+		outbox.WithDrainTimeout(cfg.Services.Outbox.DrainTimeout),
 	))
 	if err != nil {
 		return fmt.Errorf("init outbox service: %v", err)
 	}
+	if err = prometheus.Register(outboxService); err != nil {
+		return fmt.Errorf("register outbox metrics: %v", err)
+	}
 
 	managerPool := inmemmanagerpool.New()
 
@@ -164,31 +208,56 @@ func run() (errReturned error) {
 		return fmt.Errorf("init manager load service: %v", err)
 	}
 
-	dlqWriter := afcverdictsprocessor.NewKafkaDLQWriter(
-		cfg.Services.AFCVerdictProcessor.Brokers,
-		cfg.Services.AFCVerdictProcessor.VerdictTopicDLQ,
-	)
+	verdictsBatchSize := cfg.Services.AFCVerdictProcessor.ProcessBatchSize
+	if brokerTransport.verdictsBatchSize != 0 {
+		verdictsBatchSize = brokerTransport.verdictsBatchSize
+	}
+	afcOpts := []afcverdictsprocessor.OptOptionsSetter{
+		afcverdictsprocessor.WithBackoffFactor(cfg.Services.AFCVerdictProcessor.BackoffFactor),
+		afcverdictsprocessor.WithBackoffInitialInterval(cfg.Services.AFCVerdictProcessor.BackoffInitialInterval),
+		afcverdictsprocessor.WithBackoffMaxElapsedTime(cfg.Services.AFCVerdictProcessor.BackoffMaxElapsedTime),
+		afcverdictsprocessor.WithRetries(cfg.Services.AFCVerdictProcessor.Retries),
+		afcverdictsprocessor.WithDlqBackoffMaxInterval(cfg.Services.AFCVerdictProcessor.DLQBackoffMaxInterval),
+		afcverdictsprocessor.WithProcessBatchMaxTimeout(cfg.Services.AFCVerdictProcessor.ProcessBatchMaxTimeout),
+		afcverdictsprocessor.WithProcessBatchSize(verdictsBatchSize),
+		afcverdictsprocessor.WithVerdictsSignKey(cfg.Services.AFCVerdictProcessor.VerdictSignKey),
+		afcverdictsprocessor.WithVerdictsSignKeys(cfg.Services.AFCVerdictProcessor.VerdictSignKeys),
+		afcverdictsprocessor.WithVerdictsJWKSFile(cfg.Services.AFCVerdictProcessor.VerdictJWKSFile),
+		afcverdictsprocessor.WithJwksReloadInterval(cfg.Services.AFCVerdictProcessor.JWKSReloadInterval),
+		afcverdictsprocessor.WithConflictPolicy(
+			afcverdictsprocessor.ConflictPolicy(cfg.Services.AFCVerdictProcessor.VerdictConflictPolicy)),
+		afcverdictsprocessor.WithLocalVerdictPrecedence(
+			afcverdictsprocessor.LocalVerdictPrecedence(cfg.Services.AFCVerdictProcessor.LocalVerdictPrecedence)),
+	}
+	localVerdictOpts := []localverdictjob.OptOptionsSetter{
+		localverdictjob.WithBlockScore(cfg.Services.LocalVerdict.BlockScore),
+	}
+	if cfg.Services.Redaction.BlockedMessages {
+		auditKey, err := auditseal.ParsePublicKey([]byte(cfg.Services.Redaction.AuditPublicKey))
+		if err != nil {
+			return fmt.Errorf("parse audit public key: %v", err)
+		}
+		msgRedactor, err := msgredactor.New(msgredactor.NewOptions(msgRepo, searcher, auditKey))
+		if err != nil {
+			return fmt.Errorf("init msg redactor: %v", err)
+		}
+		afcOpts = append(afcOpts, afcverdictsprocessor.WithBodyRedactor(msgRedactor))
+		localVerdictOpts = append(localVerdictOpts, localverdictjob.WithBodyRedactor(msgRedactor))
+	}
 
 	afcVerdictProcessor, err := afcverdictsprocessor.New(afcverdictsprocessor.NewOptions(
-		cfg.Services.AFCVerdictProcessor.Brokers,
 		cfg.Services.AFCVerdictProcessor.Consumers,
 		cfg.Services.AFCVerdictProcessor.ConsumerGroup,
 		cfg.Services.AFCVerdictProcessor.VerdictTopic,
 
-		afcverdictsprocessor.NewKafkaReader,
-		dlqWriter,
+		brokerTransport.verdicts,
+		brokerTransport.verdictsDLQ,
 
 		db,
 		msgRepo,
 		outboxService,
 
-		afcverdictsprocessor.WithBackoffFactor(cfg.Services.AFCVerdictProcessor.BackoffFactor),
-		afcverdictsprocessor.WithBackoffInitialInterval(cfg.Services.AFCVerdictProcessor.BackoffInitialInterval),
-		afcverdictsprocessor.WithBackoffMaxElapsedTime(cfg.Services.AFCVerdictProcessor.BackoffMaxElapsedTime),
-		afcverdictsprocessor.WithRetries(cfg.Services.AFCVerdictProcessor.Retries),
-		afcverdictsprocessor.WithProcessBatchMaxTimeout(cfg.Services.AFCVerdictProcessor.ProcessBatchMaxTimeout),
-		afcverdictsprocessor.WithProcessBatchSize(cfg.Services.AFCVerdictProcessor.ProcessBatchSize),
-		afcverdictsprocessor.WithVerdictsSignKey(cfg.Services.AFCVerdictProcessor.VerdictSignKey),
+		afcOpts...,
 	))
 	if err != nil {
 		return fmt.Errorf("init afcVerdictProcessor: %v", err)
@@ -217,14 +286,47 @@ func run() (errReturned error) {
 	if err != nil {
 		return fmt.Errorf("init client message blocked job: %v", err)
 	}
+	localVerdictJob, err := localverdictjob.New(localverdictjob.NewOptions(
+		msgRepo,
+		outboxService,
+		db,
+		searcher,
+		localVerdictOpts...,
+	))
+	if err != nil {
+		return fmt.Errorf("init local verdict job: %v", err)
+	}
 
 	outboxService.MustRegisterJob(sendClientMessageJob)
 	outboxService.MustRegisterJob(clientMessageSentJob)
 	outboxService.MustRegisterJob(clientMessageBlockedJob)
+	outboxService.MustRegisterJob(localVerdictJob)
+
+	for _, sch := range cfg.Services.Outbox.Schedules {
+		if err = outboxService.AddSchedule(sch.Job, outbox.Schedule{Spec: sch.Spec, Payload: sch.Payload}); err != nil {
+			return fmt.Errorf("schedule outbox job: %v", err)
+		}
+	}
 
 	// ws
 	clientWSShutdownCh := make(chan struct{})
-	clientWSUpgrader := websocketstream.NewUpgrader(cfg.Servers.Client.AllowOrigins, cfg.Servers.Client.SecWSProtocol)
+	clientWSRegistry, err := websocketstream.NewRegistry(websocketstream.NewRegistryOptions(
+		websocketstream.WithMaxConnsPerUser(cfg.Servers.Client.WS.MaxConnsPerUser),
+		websocketstream.WithMaxConns(cfg.Servers.Client.WS.MaxConns),
+	))
+	if err != nil {
+		return fmt.Errorf("init client ws registry: %v", err)
+	}
+	clientWSUpgrader, err := websocketstream.NewUpgrader(websocketstream.NewUpgraderOptions(
+		cfg.Servers.Client.AllowOrigins,
+		websocketstream.WithSecWsProtocols(websocketstream.Subprotocols(cfg.Servers.Client.SecWSProtocol)),
+		websocketstream.WithReadBufferSize(cfg.Servers.Client.WS.ReadBufferSize),
+		websocketstream.WithWriteBufferSize(cfg.Servers.Client.WS.WriteBufferSize),
+		websocketstream.WithCompression(cfg.Servers.Client.WS.Compression),
+	))
+	if err != nil {
+		return fmt.Errorf("init client ws upgrader: %v", err)
+	}
 	clientWSHandler, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
 		zap.L(),
 		eventStream,
@@ -232,24 +334,96 @@ func run() (errReturned error) {
 		websocketstream.JSONEventWriter{},
 		clientWSUpgrader,
 		clientWSShutdownCh,
+		websocketstream.WithEventWriters(websocketstream.EventWriters(cfg.Servers.Client.SecWSProtocol)),
+		websocketstream.WithRegistry(clientWSRegistry),
+		websocketstream.WithPingPeriod(cfg.Servers.Client.WS.PingPeriod),
+		websocketstream.WithPongWait(cfg.Servers.Client.WS.PongWait),
+		websocketstream.WithWriteTimeout(cfg.Servers.Client.WS.WriteTimeout),
+		websocketstream.WithReadLimit(cfg.Servers.Client.WS.ReadLimit),
+		websocketstream.WithCompressionThreshold(cfg.Servers.Client.WS.CompressionThreshold),
+		websocketstream.WithTokenVerifier(clientTokenVerifier),
 	))
 	if err != nil {
 		return fmt.Errorf("init client ws handler: %v", err)
 	}
+	clientSSEHandler, err := websocketstream.NewSSEHandler(websocketstream.NewSSEOptions(
+		zap.L(),
+		eventStream,
+		clientevents.Adapter{},
+		clientWSShutdownCh,
+	))
+	if err != nil {
+		return fmt.Errorf("init client sse handler: %v", err)
+	}
+	clientPollHandler, err := websocketstream.NewPollHandler(websocketstream.NewPollOptions(
+		zap.L(),
+		eventStream,
+		clientevents.Adapter{},
+		clientWSShutdownCh,
+	))
+	if err != nil {
+		return fmt.Errorf("init client poll handler: %v", err)
+	}
 
 	managerWSShutdownCh := make(chan struct{})
-	managerWSUpgrader := websocketstream.NewUpgrader(cfg.Servers.Manager.AllowOrigins, cfg.Servers.Manager.SecWSProtocol)
+	var managerEventAdapter managerevents.Adapter
+	if cfg.Services.Redaction.ManagerMessages {
+		managerEventAdapter.Redactor = searcher
+	}
+	managerWSRegistry, err := websocketstream.NewRegistry(websocketstream.NewRegistryOptions(
+		websocketstream.WithMaxConnsPerUser(cfg.Servers.Manager.WS.MaxConnsPerUser),
+		websocketstream.WithMaxConns(cfg.Servers.Manager.WS.MaxConns),
+	))
+	if err != nil {
+		return fmt.Errorf("init manager ws registry: %v", err)
+	}
+	managerWSUpgrader, err := websocketstream.NewUpgrader(websocketstream.NewUpgraderOptions(
+		cfg.Servers.Manager.AllowOrigins,
+		websocketstream.WithSecWsProtocols(websocketstream.Subprotocols(cfg.Servers.Manager.SecWSProtocol)),
+		websocketstream.WithReadBufferSize(cfg.Servers.Manager.WS.ReadBufferSize),
+		websocketstream.WithWriteBufferSize(cfg.Servers.Manager.WS.WriteBufferSize),
+		websocketstream.WithCompression(cfg.Servers.Manager.WS.Compression),
+	))
+	if err != nil {
+		return fmt.Errorf("init manager ws upgrader: %v", err)
+	}
 	managerWSHandler, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
 		zap.L(),
 		eventStream,
-		dummyAdapter{},
+		managerEventAdapter,
 		websocketstream.JSONEventWriter{},
 		managerWSUpgrader,
 		managerWSShutdownCh,
+		websocketstream.WithEventWriters(websocketstream.EventWriters(cfg.Servers.Manager.SecWSProtocol)),
+		websocketstream.WithRegistry(managerWSRegistry),
+		websocketstream.WithPingPeriod(cfg.Servers.Manager.WS.PingPeriod),
+		websocketstream.WithPongWait(cfg.Servers.Manager.WS.PongWait),
+		websocketstream.WithWriteTimeout(cfg.Servers.Manager.WS.WriteTimeout),
+		websocketstream.WithReadLimit(cfg.Servers.Manager.WS.ReadLimit),
+		websocketstream.WithCompressionThreshold(cfg.Servers.Manager.WS.CompressionThreshold),
+		websocketstream.WithTokenVerifier(managerTokenVerifier),
 	))
 	if err != nil {
 		return fmt.Errorf("init manager ws handler: %v", err)
 	}
+	managerSSEHandler, err := websocketstream.NewSSEHandler(websocketstream.NewSSEOptions(
+		zap.L(),
+		eventStream,
+		managerEventAdapter,
+		managerWSShutdownCh,
+	))
+	if err != nil {
+		return fmt.Errorf("init manager sse handler: %v", err)
+	}
+	managerPollHandler, err := websocketstream.NewPollHandler(websocketstream.NewPollOptions(
+		zap.L(),
+		eventStream,
+		managerEventAdapter,
+		managerWSShutdownCh,
+	))
+	if err != nil {
+		return fmt.Errorf("init manager poll handler: %v", err)
+	}
 
 	// Init servers
 	srvDebug, err := serverdebug.New(serverdebug.NewOptions(
@@ -257,6 +431,11 @@ func run() (errReturned error) {
 		clientSwagger,
 		managerSwagger,
 		clientEventSwagger,
+		outboxService,
+		map[string]serverdebug.WSRegistry{
+			nameServerClient:  clientWSRegistry,
+			nameServerManager: managerWSRegistry,
+		},
 	))
 	if err != nil {
 		return fmt.Errorf("init debug server: %v", err)
@@ -268,14 +447,14 @@ func run() (errReturned error) {
 		cfg.Servers.Manager.AllowOrigins,
 		managerSwagger,
 
-		kcClient,
-		cfg.Servers.Manager.RequiredAccess.Resource,
-		cfg.Servers.Manager.RequiredAccess.Role,
+		managerTokenVerifier,
 		cfg.Servers.Manager.SecWSProtocol,
 
 		managerLoad,
 		managerPool,
 		managerWSHandler,
+		managerSSEHandler,
+		managerPollHandler,
 	)
 	if err != nil {
 		return fmt.Errorf("init manager chat server: %v", err)
@@ -287,9 +466,7 @@ func run() (errReturned error) {
 		cfg.Servers.Client.AllowOrigins,
 		clientSwagger,
 
-		kcClient,
-		cfg.Servers.Client.RequiredAccess.Resource,
-		cfg.Servers.Client.RequiredAccess.Role,
+		clientTokenVerifier,
 		cfg.Servers.Client.SecWSProtocol,
 
 		db,
@@ -297,7 +474,10 @@ func run() (errReturned error) {
 		chatRepo,
 		problemsRepo,
 		outboxService,
+		cfg.Services.LocalVerdict.AFCSLA,
 		clientWSHandler,
+		clientSSEHandler,
+		clientPollHandler,
 	)
 	if err != nil {
 		return fmt.Errorf("init client chat server: %v", err)
@@ -306,9 +486,9 @@ func run() (errReturned error) {
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
 		<-ctx.Done()
-		go func() { managerWSShutdownCh <- struct{}{} }()
-		go func() { clientWSShutdownCh <- struct{}{} }()
-		return psqlClient.Close()
+		close(managerWSShutdownCh)
+		close(clientWSShutdownCh)
+		return nil
 	})
 	// Run servers and services.
 	eg.Go(func() error { return srvDebug.Run(ctx) })
@@ -320,6 +500,13 @@ func run() (errReturned error) {
 	eg.Go(func() error { return outboxService.Run(ctx) })
 
 	eg.Go(func() error { return afcVerdictProcessor.Run(ctx) })
+
+	if f := cfg.Services.LocalVerdict.RulesFile; f != "" {
+		eg.Go(func() error {
+			searcher.WatchRulesFile(ctx, f, cfg.Services.LocalVerdict.RulesReloadInterval)
+			return nil
+		})
+	}
 	// Ждут своего часа.
 	// ...
 
@@ -329,10 +516,3 @@ func run() (errReturned error) {
 
 	return nil
 }
-
-// tmp.
-type dummyAdapter struct{}
-
-func (dummyAdapter) Adapt(event eventstream.Event) (any, error) {
-	return event, nil
-}
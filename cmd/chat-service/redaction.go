@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+)
+
+// newLogSearcher returns the searcher masking the logs with the given detectors only, bloodhound.LogFacts by default.
+// The loose detectors like card_cvc would mask every IP, port and status in the logs.
+func newLogSearcher(names []string) (*bloodhound.Searcher, error) {
+	if len(names) == 0 {
+		return bloodhound.NewSearcher(bloodhound.LogFacts()...), nil
+	}
+	facts, err := parseFacts(names)
+	if err != nil {
+		return nil, fmt.Errorf("parse log redaction detectors: %v", err)
+	}
+	return bloodhound.NewSearcher(facts...), nil
+}
+
+func parseFacts(names []string) ([]bloodhound.Fact, error) {
+	facts := make([]bloodhound.Fact, 0, len(names))
+	for _, name := range names {
+		f, err := bloodhound.ParseFact(name)
+		if err != nil {
+			return nil, err
+		}
+		facts = append(facts, f)
+	}
+	return facts, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	oapimdlwr "github.com/deepmap/oapi-codegen/pkg/middleware"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -9,7 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 
-	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
+	"github.com/gerladeno/chat-service/internal/middlewares"
 	chatsrepo "github.com/gerladeno/chat-service/internal/repositories/chats"
 	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
 	problemsrepo "github.com/gerladeno/chat-service/internal/repositories/problems"
@@ -31,9 +32,7 @@ func initServerClient(
 	allowOrigins []string,
 	v1Swagger *openapi3.T,
 
-	client *keycloakclient.Client,
-	resource string,
-	role string,
+	verifier *middlewares.OIDCTokenVerifier,
 	wsSecProtocol string,
 
 	db *store.Database,
@@ -42,7 +41,10 @@ func initServerClient(
 	problemRepo *problemsrepo.Repo,
 
 	outboxService *outbox.Service,
+	afcFallbackSLA time.Duration,
 	wsHandler *websocketstream.HTTPHandler,
+	sseHandler *websocketstream.SSEHandler,
+	pollHandler *websocketstream.PollHandler,
 ) (*server.Server, error) {
 	lg := zap.L().Named(nameServerClient)
 
@@ -50,7 +52,10 @@ func initServerClient(
 	if err != nil {
 		return nil, fmt.Errorf("create getHistoryUseCase: %v", err)
 	}
-	sendMessageUseCase, err := sendmessage.New(sendmessage.NewOptions(chatRepo, msgRepo, outboxService, problemRepo, db))
+	sendMessageUseCase, err := sendmessage.New(sendmessage.NewOptions(
+		chatRepo, msgRepo, outboxService, problemRepo, db,
+		sendmessage.WithAfcFallbackSLA(afcFallbackSLA),
+	))
 	if err != nil {
 		return nil, fmt.Errorf("create sendMessageUseCase: %v", err)
 	}
@@ -71,6 +76,8 @@ func initServerClient(
 
 		func(e *echo.Echo) {
 			e.GET("/ws", wsHandler.Serve)
+			e.GET("/events/sse", sseHandler.Serve)
+			e.GET("/events/poll", pollHandler.Serve)
 			v1 := e.Group("v1", oapimdlwr.OapiRequestValidatorWithOptions(v1Swagger, &oapimdlwr.Options{
 				Options: openapi3filter.Options{
 					ExcludeRequestBody:  false,
@@ -80,9 +87,7 @@ func initServerClient(
 			}))
 			clientv1.RegisterHandlers(v1, v1Handlers)
 		},
-		client,
-		resource,
-		role,
+		verifier,
 		wsSecProtocol,
 		errHandler.Handle,
 	))
@@ -9,7 +9,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 
-	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
+	"github.com/gerladeno/chat-service/internal/middlewares"
 	"github.com/gerladeno/chat-service/internal/server"
 	managerv1 "github.com/gerladeno/chat-service/internal/server-manager/v1"
 	"github.com/gerladeno/chat-service/internal/server/errhandler"
@@ -28,14 +28,14 @@ func initServerManager(
 	allowOrigins []string,
 	v1Swagger *openapi3.T,
 
-	client *keycloakclient.Client,
-	resource string,
-	role string,
+	verifier *middlewares.OIDCTokenVerifier,
 	wsSecProtocol string,
 
 	managerLoad *managerload.Service,
 	managerPool managerpool.Pool,
 	wsHandler *websocketstream.HTTPHandler,
+	sseHandler *websocketstream.SSEHandler,
+	pollHandler *websocketstream.PollHandler,
 ) (*server.Server, error) {
 	lg := zap.L().Named(nameServerManager)
 
@@ -71,6 +71,8 @@ func initServerManager(
 
 		func(e *echo.Echo) {
 			e.GET("/ws", wsHandler.Serve)
+			e.GET("/events/sse", sseHandler.Serve)
+			e.GET("/events/poll", pollHandler.Serve)
 			v1 := e.Group("v1", oapimdlwr.OapiRequestValidatorWithOptions(v1Swagger, &oapimdlwr.Options{
 				Options: openapi3filter.Options{
 					ExcludeRequestBody:  false,
@@ -80,9 +82,7 @@ func initServerManager(
 			}))
 			managerv1.RegisterHandlers(v1, v1Handlers)
 		},
-		client,
-		resource,
-		role,
+		verifier,
 		wsSecProtocol,
 		errHandler.Handle,
 	))
@@ -0,0 +1,129 @@
+// Package auditseal encrypts data for auditors: the service seals it with the auditors public key
+// and only the holders of the private key are able to open it.
+package auditseal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const version = 1
+
+var ErrInvalidSealed = errors.New("invalid sealed data")
+
+// Seal encrypts the data with a random AES-256-GCM key, the key itself is encrypted with RSA-OAEP.
+// The result is: version (1 byte), encrypted key length (2 bytes), encrypted key, nonce, ciphertext.
+func Seal(pub *rsa.PublicKey, data []byte) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate key: %v", err)
+	}
+	encKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt key: %v", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %v", err)
+	}
+
+	sealed := make([]byte, 0, 3+len(encKey)+len(nonce)+len(data)+gcm.Overhead())
+	sealed = append(sealed, version)
+	sealed = binary.BigEndian.AppendUint16(sealed, uint16(len(encKey)))
+	sealed = append(sealed, encKey...)
+	sealed = append(sealed, nonce...)
+	return gcm.Seal(sealed, nonce, data, nil), nil
+}
+
+// Open decrypts the data sealed by Seal.
+func Open(priv *rsa.PrivateKey, sealed []byte) ([]byte, error) {
+	if len(sealed) < 3 || sealed[0] != version {
+		return nil, fmt.Errorf("%w: unknown version", ErrInvalidSealed)
+	}
+	keyLen := int(binary.BigEndian.Uint16(sealed[1:3]))
+	sealed = sealed[3:]
+	if len(sealed) < keyLen {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidSealed)
+	}
+
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, priv, sealed[:keyLen], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt key: %v", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed = sealed[keyLen:]
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidSealed)
+	}
+	data, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data: %v", err)
+	}
+	return data, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new gcm: %v", err)
+	}
+	return gcm, nil
+}
+
+// ParsePublicKey parses the PKIX RSA public key in PEM.
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid pem")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %v", err)
+	}
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an rsa public key: %T", key)
+	}
+	return pub, nil
+}
+
+// ParsePrivateKey parses the PKCS #1 or PKCS #8 RSA private key in PEM.
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid pem")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %v", err)
+	}
+	priv, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an rsa private key: %T", key)
+	}
+	return priv, nil
+}
@@ -0,0 +1,57 @@
+package auditseal_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/auditseal"
+)
+
+func TestSealOpen(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	pub, err := auditseal.ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	require.NoError(t, err)
+	priv, err = auditseal.ParsePrivateKey(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(priv),
+	}))
+	require.NoError(t, err)
+
+	const data = "Карта 4276 1234 5678 1235"
+	sealed, err := auditseal.Seal(pub, []byte(data))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "4276")
+
+	opened, err := auditseal.Open(priv, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(opened))
+
+	t.Run("another key", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		_, err = auditseal.Open(other, sealed)
+		require.Error(t, err)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := append([]byte(nil), sealed...)
+		tampered[len(tampered)-1] ^= 1
+		_, err := auditseal.Open(priv, tampered)
+		require.Error(t, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := auditseal.Open(priv, sealed[:10])
+		require.ErrorIs(t, err, auditseal.ErrInvalidSealed)
+	})
+}
@@ -0,0 +1,54 @@
+// Package broker describes the message broker transport independent of the broker implementation.
+// The adapters live in the subpackages: kafka (the default), nats (JetStream) and inmem.
+package broker
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/broker_mock.gen.go -package=brokermocks
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Message is the message published to or fetched from the topic.
+// Topic, Partition, Offset and Time are set by the Subscriber.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+	Time      time.Time
+}
+
+// Header returns the value of the first header with the key.
+func (m Message) Header(key string) (string, bool) {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// Publisher publishes the messages to the topic it is bound to.
+// The messages with the same key are kept in order.
+type Publisher interface {
+	io.Closer
+	Publish(ctx context.Context, msgs ...Message) error
+}
+
+// Subscriber fetches the messages of the topic as a member of a consumer group.
+type Subscriber interface {
+	io.Closer
+	// Fetch blocks until the next message is available or ctx is done.
+	Fetch(ctx context.Context) (Message, error)
+	// Commit marks the messages and all the preceding messages of their partitions as processed.
+	Commit(ctx context.Context, msgs ...Message) error
+}
@@ -0,0 +1,240 @@
+// Package inmembroker is the in-process broker for local development and tests.
+// It keeps the topics in memory and mimics Kafka: the topic is split into partitions by the message key
+// and the partitions are shared between the members of a consumer group.
+package inmembroker
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+)
+
+const DefaultPartitions = 4
+
+var ErrClosed = errors.New("subscriber is closed")
+
+var (
+	_ broker.Publisher  = (*Publisher)(nil)
+	_ broker.Subscriber = (*Subscriber)(nil)
+)
+
+type Broker struct {
+	partitions int
+
+	mu      sync.Mutex
+	topics  map[string]*topic
+	changed chan struct{} // Closed and replaced on every change.
+}
+
+type topic struct {
+	name   string
+	logs   [][]broker.Message // By partition.
+	groups map[string]*group
+	next   int // Partition for the next message without key.
+}
+
+type group struct {
+	members   []*Subscriber
+	committed []int64 // Next offset by partition.
+}
+
+// New creates the broker with the number of partitions per topic, DefaultPartitions if not positive.
+func New(partitions int) *Broker {
+	if partitions <= 0 {
+		partitions = DefaultPartitions
+	}
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string]*topic),
+		changed:    make(chan struct{}),
+	}
+}
+
+// Publisher returns the publisher to the topic, the topic is created on demand.
+func (b *Broker) Publisher(topicName string) *Publisher {
+	return &Publisher{b: b, topic: topicName}
+}
+
+// Subscriber joins the consumer group of the topic. The group partitions are rebalanced
+// between its members, the uncommitted messages of the moved partitions are fetched again.
+func (b *Broker) Subscriber(groupID, topicName string) *Subscriber {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topicLocked(topicName)
+	g, ok := t.groups[groupID]
+	if !ok {
+		g = &group{committed: make([]int64, b.partitions)}
+		t.groups[groupID] = g
+	}
+	s := &Subscriber{b: b, topic: t, group: g}
+	g.members = append(g.members, s)
+	b.rebalanceLocked(g)
+	return s
+}
+
+func (b *Broker) topicLocked(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{
+			name:   name,
+			logs:   make([][]broker.Message, b.partitions),
+			groups: make(map[string]*group),
+		}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// rebalanceLocked spreads the partitions between the group members. The member keeps
+// its position in the partition it still owns, the moved partitions start from the committed offset.
+func (b *Broker) rebalanceLocked(g *group) {
+	for i, m := range g.members {
+		positions := make(map[int]int64)
+		for p := i; p < b.partitions; p += len(g.members) {
+			if pos, ok := m.positions[p]; ok {
+				positions[p] = pos
+			} else {
+				positions[p] = g.committed[p]
+			}
+		}
+		m.positions = positions
+	}
+	b.notifyLocked()
+}
+
+func (b *Broker) notifyLocked() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+type Publisher struct {
+	b     *Broker
+	topic string
+}
+
+func (p *Publisher) Publish(_ context.Context, msgs ...broker.Message) error {
+	p.b.mu.Lock()
+	defer p.b.mu.Unlock()
+
+	t := p.b.topicLocked(p.topic)
+	for _, m := range msgs {
+		partition := t.next
+		if len(m.Key) > 0 {
+			h := fnv.New32a()
+			_, _ = h.Write(m.Key)
+			partition = int(h.Sum32() % uint32(p.b.partitions))
+		} else {
+			t.next = (t.next + 1) % p.b.partitions
+		}
+
+		m.Topic = t.name
+		m.Partition = partition
+		m.Offset = int64(len(t.logs[partition]))
+		m.Headers = cloneHeaders(m.Headers)
+		if m.Time.IsZero() {
+			m.Time = time.Now()
+		}
+		t.logs[partition] = append(t.logs[partition], m)
+	}
+	p.b.notifyLocked()
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return nil
+}
+
+type Subscriber struct {
+	b         *Broker
+	topic     *topic
+	group     *group
+	positions map[int]int64 // Next offset by the assigned partition.
+	next      int           // Partition to start the next fetch from.
+	closed    bool
+}
+
+func (s *Subscriber) Fetch(ctx context.Context) (broker.Message, error) {
+	for {
+		s.b.mu.Lock()
+		if s.closed {
+			s.b.mu.Unlock()
+			return broker.Message{}, ErrClosed
+		}
+		if m, ok := s.nextLocked(); ok {
+			s.b.mu.Unlock()
+			return m, nil
+		}
+		changed := s.b.changed
+		s.b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return broker.Message{}, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// nextLocked takes the next message of the assigned partitions going round them.
+func (s *Subscriber) nextLocked() (broker.Message, bool) {
+	for i := 0; i < s.b.partitions; i++ {
+		p := (s.next + i) % s.b.partitions
+		pos, ok := s.positions[p]
+		if !ok || pos >= int64(len(s.topic.logs[p])) {
+			continue
+		}
+		s.positions[p] = pos + 1
+		s.next = p + 1
+
+		m := s.topic.logs[p][pos]
+		m.Headers = cloneHeaders(m.Headers)
+		return m, true
+	}
+	return broker.Message{}, false
+}
+
+func (s *Subscriber) Commit(_ context.Context, msgs ...broker.Message) error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	for _, m := range msgs {
+		if m.Offset+1 > s.group.committed[m.Partition] {
+			s.group.committed[m.Partition] = m.Offset + 1
+		}
+	}
+	return nil
+}
+
+// Close leaves the consumer group.
+func (s *Subscriber) Close() error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	for i, m := range s.group.members {
+		if m == s {
+			s.group.members = append(s.group.members[:i], s.group.members[i+1:]...)
+			break
+		}
+	}
+	s.b.rebalanceLocked(s.group)
+	return nil
+}
+
+func cloneHeaders(headers []broker.Header) []broker.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	return append(make([]broker.Header, 0, len(headers)), headers...)
+}
@@ -0,0 +1,139 @@
+package inmembroker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	inmembroker "github.com/gerladeno/chat-service/internal/broker/inmem"
+)
+
+const topic = "chat.messages"
+
+func TestBroker_KeyOrder(t *testing.T) {
+	// Arrange.
+	ctx := context.Background()
+	b := inmembroker.New(4)
+	pub := b.Publisher(topic)
+	sub := b.Subscriber("group", topic)
+	defer sub.Close()
+
+	// Action.
+	for i := 0; i < 30; i++ {
+		require.NoError(t, pub.Publish(ctx, broker.Message{
+			Key:     []byte(fmt.Sprintf("chat-%d", i%3)),
+			Value:   []byte(fmt.Sprintf("%d", i)),
+			Headers: []broker.Header{{Key: "n", Value: []byte(fmt.Sprintf("%d", i))}},
+		}))
+	}
+
+	// Assert.
+	byKey := make(map[string][]broker.Message)
+	for i := 0; i < 30; i++ {
+		m := fetch(t, sub)
+		assert.Equal(t, topic, m.Topic)
+		assert.False(t, m.Time.IsZero())
+		n, _ := m.Header("n")
+		assert.Equal(t, string(m.Value), n)
+		byKey[string(m.Key)] = append(byKey[string(m.Key)], m)
+	}
+	require.Len(t, byKey, 3)
+	for key, msgs := range byKey {
+		for i, m := range msgs {
+			assert.Equal(t, msgs[0].Partition, m.Partition, "key %s", key)
+			assert.Equal(t, int64(i), m.Offset-msgs[0].Offset, "key %s", key)
+		}
+	}
+}
+
+func TestBroker_CommittedOffsets(t *testing.T) {
+	// Arrange.
+	ctx := context.Background()
+	b := inmembroker.New(1)
+	pub := b.Publisher(topic)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pub.Publish(ctx, broker.Message{Value: []byte(fmt.Sprintf("%d", i))}))
+	}
+
+	sub := b.Subscriber("group", topic)
+	m0 := fetch(t, sub)
+	_ = fetch(t, sub)
+
+	// Action.
+	require.NoError(t, sub.Commit(ctx, m0))
+	require.NoError(t, sub.Close())
+
+	// Assert.
+	_, err := sub.Fetch(ctx)
+	require.ErrorIs(t, err, inmembroker.ErrClosed)
+
+	sub = b.Subscriber("group", topic)
+	defer sub.Close()
+	assert.Equal(t, "1", string(fetch(t, sub).Value), "uncommitted message is fetched again")
+	assert.Equal(t, "2", string(fetch(t, sub).Value))
+
+	other := b.Subscriber("other-group", topic)
+	defer other.Close()
+	assert.Equal(t, "0", string(fetch(t, other).Value), "other group reads from the beginning")
+}
+
+func TestBroker_Rebalance(t *testing.T) {
+	// Arrange.
+	ctx := context.Background()
+	b := inmembroker.New(2)
+	pub := b.Publisher(topic)
+	sub1 := b.Subscriber("group", topic)
+	sub2 := b.Subscriber("group", topic)
+	defer sub2.Close()
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, pub.Publish(ctx, broker.Message{Value: []byte(fmt.Sprintf("%d", i))}))
+	}
+
+	// Action & assert.
+	m1, m2 := fetch(t, sub1), fetch(t, sub2)
+	assert.NotEqual(t, m1.Partition, m2.Partition, "partitions are shared between members")
+	require.NoError(t, sub1.Commit(ctx, m1))
+	require.NoError(t, sub2.Commit(ctx, m2))
+
+	require.NoError(t, sub1.Close())
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		got[string(fetch(t, sub2).Value)] = true
+	}
+	assert.Equal(t, map[string]bool{"2": true, "3": true}, got, "left member partitions are taken over")
+}
+
+func TestBroker_FetchWaits(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	b := inmembroker.New(1)
+	sub := b.Subscriber("group", topic)
+	defer sub.Close()
+
+	_, err := sub.Fetch(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = b.Publisher(topic).Publish(context.Background(), broker.Message{Value: []byte("late")})
+	}()
+	assert.Equal(t, "late", string(fetch(t, sub).Value))
+}
+
+func fetch(t *testing.T, sub broker.Subscriber) broker.Message {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	m, err := sub.Fetch(ctx)
+	require.NoError(t, err)
+	return m
+}
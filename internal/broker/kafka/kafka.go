@@ -0,0 +1,104 @@
+// Package kafkabroker adapts segmentio/kafka-go readers and writers to the broker interfaces.
+package kafkabroker
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+)
+
+var (
+	_ broker.Publisher  = (*Publisher)(nil)
+	_ broker.Subscriber = (*Subscriber)(nil)
+)
+
+type Publisher struct {
+	w *kafka.Writer
+}
+
+// NewPublisher wraps the writer, it has to be bound to the topic.
+func NewPublisher(w *kafka.Writer) *Publisher {
+	return &Publisher{w: w}
+}
+
+func (p *Publisher) Publish(ctx context.Context, msgs ...broker.Message) error {
+	kMsgs := make([]kafka.Message, 0, len(msgs))
+	for _, m := range msgs {
+		kMsgs = append(kMsgs, kafka.Message{
+			Key:     m.Key,
+			Value:   m.Value,
+			Headers: toKafkaHeaders(m.Headers),
+			Time:    m.Time,
+		})
+	}
+	return p.w.WriteMessages(ctx, kMsgs...)
+}
+
+func (p *Publisher) Close() error {
+	return p.w.Close()
+}
+
+type Subscriber struct {
+	r *kafka.Reader
+}
+
+// NewSubscriber wraps the reader, it has to be a member of a consumer group.
+func NewSubscriber(r *kafka.Reader) *Subscriber {
+	return &Subscriber{r: r}
+}
+
+func (s *Subscriber) Fetch(ctx context.Context) (broker.Message, error) {
+	m, err := s.r.FetchMessage(ctx)
+	if err != nil {
+		return broker.Message{}, err
+	}
+	return broker.Message{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       m.Key,
+		Value:     m.Value,
+		Headers:   fromKafkaHeaders(m.Headers),
+		Time:      m.Time,
+	}, nil
+}
+
+func (s *Subscriber) Commit(ctx context.Context, msgs ...broker.Message) error {
+	kMsgs := make([]kafka.Message, 0, len(msgs))
+	for _, m := range msgs {
+		kMsgs = append(kMsgs, kafka.Message{
+			Topic:     m.Topic,
+			Partition: m.Partition,
+			Offset:    m.Offset,
+		})
+	}
+	return s.r.CommitMessages(ctx, kMsgs...)
+}
+
+func (s *Subscriber) Close() error {
+	return s.r.Close()
+}
+
+func toKafkaHeaders(headers []broker.Header) []kafka.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	res := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		res = append(res, kafka.Header{Key: h.Key, Value: h.Value})
+	}
+	return res
+}
+
+func fromKafkaHeaders(headers []kafka.Header) []broker.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	res := make([]broker.Header, 0, len(headers))
+	for _, h := range headers {
+		res = append(res, broker.Header{Key: h.Key, Value: h.Value})
+	}
+	return res
+}
@@ -0,0 +1,254 @@
+// Package natsbroker adapts NATS JetStream to the broker interfaces.
+// The topics are the stream subjects and the consumer groups are the durable pull consumers.
+// JetStream has no partitions, so all the messages of a subject are fetched as partition 0
+// with the stream sequence as the offset. To keep them in order the durable consumer delivers
+// the next message only after the previous one is acked, no matter how many subscribers
+// of how many replicas share it. So a subscriber is expected to commit each message it handled,
+// its next Fetch waits until then, and the shared durable processes the messages one at a time.
+package natsbroker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+)
+
+// HeaderKey carries the message key, JetStream messages have none.
+const HeaderKey = "Chat-Msg-Key"
+
+const fetchTimeout = 5 * time.Second
+
+var (
+	_ broker.Publisher  = (*Publisher)(nil)
+	_ broker.Subscriber = (*Subscriber)(nil)
+)
+
+// EnsureStream creates the stream or adds the missing subjects to the existing one.
+func EnsureStream(js nats.JetStreamContext, name string, subjects ...string) error {
+	info, err := js.StreamInfo(name)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		if _, err := js.AddStream(&nats.StreamConfig{Name: name, Subjects: subjects}); err != nil {
+			return fmt.Errorf("add stream: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get stream info: %v", err)
+	}
+
+	cfg := info.Config
+	known := make(map[string]struct{}, len(cfg.Subjects))
+	for _, s := range cfg.Subjects {
+		known[s] = struct{}{}
+	}
+	var changed bool
+	for _, s := range subjects {
+		if _, ok := known[s]; !ok {
+			cfg.Subjects = append(cfg.Subjects, s)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if _, err := js.UpdateStream(&cfg); err != nil {
+		return fmt.Errorf("update stream: %v", err)
+	}
+	return nil
+}
+
+type Publisher struct {
+	js      nats.JetStreamContext
+	subject string
+}
+
+func NewPublisher(js nats.JetStreamContext, subject string) *Publisher {
+	return &Publisher{js: js, subject: subject}
+}
+
+func (p *Publisher) Publish(ctx context.Context, msgs ...broker.Message) error {
+	for _, m := range msgs {
+		nm := nats.NewMsg(p.subject)
+		nm.Data = m.Value
+		for _, h := range m.Headers {
+			nm.Header.Add(h.Key, string(h.Value))
+		}
+		if len(m.Key) > 0 {
+			nm.Header.Set(HeaderKey, string(m.Key))
+		}
+		if _, err := p.js.PublishMsg(nm, nats.Context(ctx)); err != nil {
+			return fmt.Errorf("publish msg: %v", err)
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return nil
+}
+
+type Subscriber struct {
+	sub *nats.Subscription
+
+	mu      sync.Mutex
+	pending map[int64]*nats.Msg // Fetched messages by stream sequence.
+}
+
+// NewSubscriber binds to the durable consumer of the stream subject, the consumer is created if missing.
+// The messages are acked one by one, committing a message acks it and the preceding fetched ones.
+// The durable has one message in flight, the subscribers of the other replicas get the next one
+// after it is committed or, if the subscriber is gone, after the ack wait.
+func NewSubscriber(js nats.JetStreamContext, stream, durable, subject string) (*Subscriber, error) {
+	if err := ensureConsumer(js, stream, &nats.ConsumerConfig{
+		Durable:       durable,
+		FilterSubject: subject,
+		AckPolicy:     nats.AckExplicitPolicy,
+		DeliverPolicy: nats.DeliverAllPolicy,
+		MaxAckPending: 1,
+	}); err != nil {
+		return nil, err
+	}
+	sub, err := js.PullSubscribe(subject, durable, nats.Bind(stream, durable))
+	if err != nil {
+		return nil, fmt.Errorf("pull subscribe: %v", err)
+	}
+	return &Subscriber{sub: sub, pending: make(map[int64]*nats.Msg)}, nil
+}
+
+// ensureConsumer creates the durable consumer or limits the existing one to one message in flight.
+func ensureConsumer(js nats.JetStreamContext, stream string, cfg *nats.ConsumerConfig) error {
+	info, err := js.ConsumerInfo(stream, cfg.Durable)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		if _, err := js.AddConsumer(stream, cfg); err != nil {
+			return fmt.Errorf("add consumer: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get consumer info: %v", err)
+	}
+
+	if info.Config.MaxAckPending == cfg.MaxAckPending {
+		return nil
+	}
+	updated := info.Config
+	updated.MaxAckPending = cfg.MaxAckPending
+	if _, err := js.UpdateConsumer(stream, &updated); err != nil {
+		return fmt.Errorf("update consumer: %v", err)
+	}
+	return nil
+}
+
+func (s *Subscriber) Fetch(ctx context.Context) (broker.Message, error) {
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		msgs, err := s.sub.Fetch(1, nats.Context(fetchCtx))
+		cancel()
+		if ctx.Err() != nil {
+			return broker.Message{}, ctx.Err()
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) || len(msgs) == 0 {
+			continue
+		}
+		if err != nil {
+			return broker.Message{}, fmt.Errorf("fetch msg: %v", err)
+		}
+
+		nm := msgs[0]
+		meta, err := nm.Metadata()
+		if err != nil {
+			return broker.Message{}, fmt.Errorf("get msg metadata: %v", err)
+		}
+		if s.redelivered(nm, int64(meta.Sequence.Stream)) {
+			continue
+		}
+		m := broker.Message{
+			Topic:  nm.Subject,
+			Offset: int64(meta.Sequence.Stream),
+			Key:    []byte(nm.Header.Get(HeaderKey)),
+			Value:  nm.Data,
+			Time:   meta.Timestamp,
+		}
+		if len(m.Key) == 0 {
+			m.Key = nil
+		}
+		for k, values := range nm.Header {
+			if k == HeaderKey {
+				continue
+			}
+			for _, v := range values {
+				m.Headers = append(m.Headers, broker.Header{Key: k, Value: []byte(v)})
+			}
+		}
+
+		s.mu.Lock()
+		s.pending[m.Offset] = nm
+		s.mu.Unlock()
+		return m, nil
+	}
+}
+
+// redelivered reports whether the message is the redelivery of the fetched one that is not committed yet
+// because its processing outlasted the ack wait. The redelivery replaces the pending message to be acked.
+func (s *Subscriber) redelivered(nm *nats.Msg, seq int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pending[seq]; !ok {
+		return false
+	}
+	s.pending[seq] = nm
+	return true
+}
+
+func (s *Subscriber) Commit(ctx context.Context, msgs ...broker.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range msgs {
+		for _, seq := range s.pendingSeqs(m.Offset) {
+			if err := s.pending[seq].AckSync(nats.Context(ctx)); err != nil {
+				return fmt.Errorf("ack msg: %v", err)
+			}
+			delete(s.pending, seq)
+		}
+	}
+	return nil
+}
+
+// pendingSeqs returns the sequences of the pending messages up to the given one in order.
+// Must be called with s.mu held.
+func (s *Subscriber) pendingSeqs(upTo int64) []int64 {
+	seqs := make([]int64, 0, len(s.pending))
+	for seq := range s.pending {
+		if seq <= upTo {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs
+}
+
+// Close unsubscribes keeping the durable consumer.
+// The fetched messages that are not committed are nacked, so another subscriber gets them first.
+func (s *Subscriber) Close() error {
+	s.mu.Lock()
+	for _, seq := range s.pendingSeqs(math.MaxInt64) {
+		if err := s.pending[seq].Nak(); err != nil {
+			zap.L().Warn("nack msg", zap.Int64("seq", seq), zap.Error(err))
+		}
+		delete(s.pending, seq)
+	}
+	s.mu.Unlock()
+
+	return s.sub.Unsubscribe()
+}
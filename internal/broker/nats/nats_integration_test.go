@@ -0,0 +1,136 @@
+//go:build integration
+
+package natsbroker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	natsbroker "github.com/gerladeno/chat-service/internal/broker/nats"
+	"github.com/gerladeno/chat-service/internal/testingh"
+)
+
+type NATSSuite struct {
+	testingh.ContextSuite
+
+	nc      *nats.Conn
+	js      nats.JetStreamContext
+	stream  string
+	subject string
+}
+
+func TestNATSSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(NATSSuite))
+}
+
+func (s *NATSSuite) SetupTest() {
+	s.ContextSuite.SetupTest()
+
+	var err error
+	s.nc, err = nats.Connect(testingh.Config.NATSURL)
+	s.Require().NoError(err)
+	s.js, err = s.nc.JetStream()
+	s.Require().NoError(err)
+
+	now := time.Now().UnixMilli()
+	s.stream = fmt.Sprintf("natsbroker-test-%d", now)
+	s.subject = fmt.Sprintf("chat.messages.%d", now)
+	s.Require().NoError(natsbroker.EnsureStream(s.js, s.stream, s.subject))
+}
+
+func (s *NATSSuite) TearDownTest() {
+	s.NoError(s.js.DeleteStream(s.stream))
+	s.nc.Close()
+	s.ContextSuite.TearDownTest()
+}
+
+func (s *NATSSuite) TestPublishFetchCommit() {
+	// Arrange.
+	pub := natsbroker.NewPublisher(s.js, s.subject)
+	for i := 0; i < 3; i++ {
+		s.Require().NoError(pub.Publish(s.Ctx, broker.Message{
+			Key:     []byte("chat-1"),
+			Value:   []byte(fmt.Sprintf("msg %d", i)),
+			Headers: []broker.Header{{Key: "trace", Value: []byte("abc")}},
+		}))
+	}
+
+	sub, err := natsbroker.NewSubscriber(s.js, s.stream, "group", s.subject)
+	s.Require().NoError(err)
+
+	// Action.
+	m0 := s.fetch(sub)
+	s.Require().NoError(sub.Commit(s.Ctx, m0))
+	m1 := s.fetch(sub)
+	s.Require().NoError(sub.Close())
+
+	// Assert.
+	s.Equal("msg 0", string(m0.Value))
+	s.Equal(s.subject, m0.Topic)
+	s.Equal("chat-1", string(m0.Key))
+	trace, _ := m0.Header("trace")
+	s.Equal("abc", trace)
+	s.Less(m0.Offset, m1.Offset)
+
+	sub, err = natsbroker.NewSubscriber(s.js, s.stream, "group", s.subject)
+	s.Require().NoError(err)
+	defer func() { s.NoError(sub.Close()) }()
+	s.Equal("msg 1", string(s.fetch(sub).Value), "the not committed message is fetched again")
+}
+
+// TestTwoSubscribersOneDurable is the durable shared by the replicas.
+func (s *NATSSuite) TestTwoSubscribersOneDurable() {
+	// Arrange.
+	pub := natsbroker.NewPublisher(s.js, s.subject)
+	for i := 0; i < 3; i++ {
+		s.Require().NoError(pub.Publish(s.Ctx, broker.Message{Value: []byte(fmt.Sprintf("msg %d", i))}))
+	}
+
+	sub1, err := natsbroker.NewSubscriber(s.js, s.stream, "group", s.subject)
+	s.Require().NoError(err)
+	defer func() { s.NoError(sub1.Close()) }()
+	sub2, err := natsbroker.NewSubscriber(s.js, s.stream, "group", s.subject)
+	s.Require().NoError(err)
+
+	// Action.
+	m0 := s.fetch(sub1)
+
+	// Assert.
+	s.Equal("msg 0", string(m0.Value))
+	ctx, cancel := context.WithTimeout(s.Ctx, 2*time.Second)
+	_, err = sub2.Fetch(ctx)
+	cancel()
+	s.Require().ErrorIs(err, context.DeadlineExceeded, "the next message waits for the previous one to be committed")
+
+	// Action.
+	s.Require().NoError(sub1.Commit(s.Ctx, m0))
+	m1 := s.fetch(sub2)
+	s.Require().NoError(sub2.Close())
+
+	// Assert.
+	s.Equal("msg 1", string(m1.Value))
+	for _, m := range []broker.Message{m1, {Value: []byte("msg 2")}} {
+		got := s.fetch(sub1)
+		s.Equal(string(m.Value), string(got.Value), "the not committed message is fetched again in order")
+		s.Zero(got.Partition)
+		s.Require().NoError(sub1.Commit(s.Ctx, got))
+	}
+}
+
+func (s *NATSSuite) fetch(sub broker.Subscriber) broker.Message {
+	s.T().Helper()
+
+	ctx, cancel := context.WithTimeout(s.Ctx, 10*time.Second)
+	defer cancel()
+
+	m, err := sub.Fetch(ctx)
+	s.Require().NoError(err)
+	return m
+}
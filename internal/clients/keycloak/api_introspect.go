@@ -2,50 +2,16 @@ package keycloakclient
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/go-resty/resty/v2"
-)
 
-type IntrospectTokenResult struct {
-	Exp    int      `json:"exp"`
-	Iat    int      `json:"iat"`
-	Aud    []string `json:"aud"`
-	Active bool     `json:"active"`
-}
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+)
 
-func (t *IntrospectTokenResult) UnmarshalJSON(data []byte) error {
-	var tmp struct {
-		Exp    int  `json:"exp"`
-		Iat    int  `json:"iat"`
-		Aud    any  `json:"aud"`
-		Active bool `json:"active"`
-	}
-	if err := json.Unmarshal(data, &tmp); err != nil {
-		return fmt.Errorf("custom UnmarshalJSON error: %w", err)
-	}
-	t.Exp = tmp.Exp
-	t.Iat = tmp.Iat
-	t.Active = tmp.Active
-	if tmp.Aud == nil {
-		return nil
-	}
-	switch typ := tmp.Aud.(type) {
-	case []interface{}:
-		for _, elem := range typ {
-			audStr, _ := elem.(string)
-			t.Aud = append(t.Aud, audStr)
-		}
-	case string:
-		t.Aud = []string{typ}
-	default:
-		return errors.New("unexpected unmarshalled Aud type")
-	}
-	return nil
-}
+// IntrospectTokenResult is the RFC 7662 introspection response, Keycloak implements it.
+type IntrospectTokenResult = oidcclient.IntrospectTokenResult
 
 // IntrospectToken implements
 // https://www.keycloak.org/docs/latest/authorization_services/index.html#obtaining-information-about-an-rpt
@@ -74,6 +74,9 @@ func TestIntrospectTokenResult(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var r keycloakclient.IntrospectTokenResult
 			require.NoError(t, json.Unmarshal([]byte(tt.in), &r))
+			assert.JSONEq(t, tt.in, string(r.Claims))
+
+			r.Claims = nil
 			assert.Equal(t, tt.exp, r)
 		})
 	}
@@ -0,0 +1,20 @@
+package keycloakclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// FetchJWKS returns the realm JSON Web Key Set the access tokens are signed with.
+func (c *Client) FetchJWKS(ctx context.Context) ([]byte, error) {
+	url := fmt.Sprintf("realms/%s/protocol/openid-connect/certs", c.realm)
+	resp, err := c.cli.R().SetContext(ctx).Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("send request to keycloak: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("errored keycloak response: %v", resp.Status())
+	}
+	return resp.Body(), nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
+	"github.com/gerladeno/chat-service/internal/jwks"
 	"github.com/gerladeno/chat-service/internal/testingh"
 )
 
@@ -55,3 +56,12 @@ func (s *KeycloakSuite) TestIntrospectTokenWithInvalidSignature() {
 	s.Require().NoError(err)
 	s.False(result.Active)
 }
+
+func (s *KeycloakSuite) TestFetchJWKS() {
+	data, err := s.kc.FetchJWKS(s.Ctx)
+	s.Require().NoError(err)
+
+	keys, err := jwks.Parse(data)
+	s.Require().NoError(err)
+	s.NotEmpty(keys)
+}
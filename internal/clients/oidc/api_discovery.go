@@ -0,0 +1,43 @@
+package oidcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var ErrIssuerMismatch = errors.New("discovered issuer mismatch")
+
+// providerMetadata is the part of https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+// the client uses.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	JWKSURI               string `json:"jwks_uri"`
+	IntrospectionEndpoint string `json:"introspection_endpoint"`
+}
+
+// discover returns the provider metadata, it is fetched once and then reused.
+func (c *Client) discover(ctx context.Context) (*providerMetadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	var metadata providerMetadata
+	resp, err := c.cli.R().SetContext(ctx).SetResult(&metadata).Get(c.issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("send request to oidc provider: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("errored oidc provider response: %v", resp.Status())
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != c.issuer {
+		return nil, fmt.Errorf("%w: %q", ErrIssuerMismatch, metadata.Issuer)
+	}
+	c.metadata = &metadata
+	return c.metadata, nil
+}
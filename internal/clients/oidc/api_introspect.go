@@ -0,0 +1,86 @@
+package oidcclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrNoIntrospectionEndpoint = errors.New("no introspection endpoint")
+
+// IntrospectTokenResult is the part of https://www.rfc-editor.org/rfc/rfc7662#section-2.2 response.
+type IntrospectTokenResult struct {
+	Exp    int      `json:"exp"`
+	Iat    int      `json:"iat"`
+	Aud    []string `json:"aud"`
+	Active bool     `json:"active"`
+
+	// Claims is the whole response, the subject and roles of the opaque tokens are only known from it.
+	Claims json.RawMessage `json:"-"`
+}
+
+func (t *IntrospectTokenResult) UnmarshalJSON(data []byte) error {
+	var tmp struct {
+		Exp    int  `json:"exp"`
+		Iat    int  `json:"iat"`
+		Aud    any  `json:"aud"`
+		Active bool `json:"active"`
+	}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("custom UnmarshalJSON error: %w", err)
+	}
+	t.Exp = tmp.Exp
+	t.Iat = tmp.Iat
+	t.Active = tmp.Active
+	t.Claims = append(json.RawMessage(nil), data...)
+	if tmp.Aud == nil {
+		return nil
+	}
+	switch typ := tmp.Aud.(type) {
+	case []interface{}:
+		for _, elem := range typ {
+			audStr, _ := elem.(string)
+			t.Aud = append(t.Aud, audStr)
+		}
+	case string:
+		t.Aud = []string{typ}
+	default:
+		return errors.New("unexpected unmarshalled Aud type")
+	}
+	return nil
+}
+
+// IntrospectToken implements https://www.rfc-editor.org/rfc/rfc7662#section-2.1
+// authenticating with the client credentials.
+func (c *Client) IntrospectToken(ctx context.Context, token string) (*IntrospectTokenResult, error) {
+	endpoint := c.introspectionEndpoint
+	if endpoint == "" {
+		metadata, err := c.discover(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("discover: %w", err)
+		}
+		if metadata.IntrospectionEndpoint == "" {
+			return nil, ErrNoIntrospectionEndpoint
+		}
+		endpoint = metadata.IntrospectionEndpoint
+	}
+
+	var result IntrospectTokenResult
+	resp, err := c.cli.R().SetContext(ctx).
+		SetBasicAuth(c.clientID, c.clientSecret).
+		SetFormData(map[string]string{
+			"token_type_hint": "access_token",
+			"token":           token,
+		}).
+		SetResult(&result).
+		Post(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("send request to oidc provider: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("errored oidc provider response: %v", resp.Status())
+	}
+	return &result, nil
+}
@@ -0,0 +1,24 @@
+package oidcclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// FetchJWKS returns the provider JSON Web Key Set the access tokens are signed with.
+func (c *Client) FetchJWKS(ctx context.Context) ([]byte, error) {
+	metadata, err := c.discover(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("discover: %w", err)
+	}
+
+	resp, err := c.cli.R().SetContext(ctx).Get(metadata.JWKSURI)
+	if err != nil {
+		return nil, fmt.Errorf("send request to oidc provider: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("errored oidc provider response: %v", resp.Status())
+	}
+	return resp.Body(), nil
+}
@@ -0,0 +1,53 @@
+package oidcclient
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+)
+
+//go:generate options-gen -out-filename=client_options.gen.go -from-struct=Options
+type Options struct {
+	issuer       string `option:"mandatory" validate:"required,url"`
+	clientID     string `option:"mandatory"`
+	clientSecret string `option:"mandatory"`
+	isProd       bool   `option:"mandatory"`
+	// introspectionEndpoint overrides the discovered one, some providers don't advertise it.
+	introspectionEndpoint string `validate:"omitempty,url"`
+	debugMode             bool
+}
+
+// Client is a tiny client to the OpenID Connect provider. The endpoints are discovered from
+// <issuer>/.well-known/openid-configuration.
+type Client struct {
+	issuer                string
+	clientID              string
+	clientSecret          string
+	introspectionEndpoint string
+	cli                   *resty.Client
+
+	mu       sync.Mutex
+	metadata *providerMetadata // Nil until the first successful discovery.
+}
+
+func New(opts Options) (*Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	if opts.debugMode && opts.isProd {
+		zap.L().Warn("debug mode is on on PROD env")
+	}
+	cli := resty.New()
+	cli.SetDebug(opts.debugMode)
+
+	return &Client{
+		issuer:                strings.TrimSuffix(opts.issuer, "/"),
+		clientID:              opts.clientID,
+		clientSecret:          opts.clientSecret,
+		introspectionEndpoint: opts.introspectionEndpoint,
+		cli:                   cli,
+	}, nil
+}
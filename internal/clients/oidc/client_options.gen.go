@@ -0,0 +1,66 @@
+// Code generated by options-gen. DO NOT EDIT.
+package oidcclient
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	issuer string,
+	clientID string,
+	clientSecret string,
+	isProd bool,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+
+	o.issuer = issuer
+	o.clientID = clientID
+	o.clientSecret = clientSecret
+	o.isProd = isProd
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithIntrospectionEndpoint(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.introspectionEndpoint = opt
+	}
+}
+
+func WithDebugMode(opt bool) OptOptionsSetter {
+	return func(o *Options) {
+		o.debugMode = opt
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("issuer", _validate_Options_issuer(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("introspectionEndpoint", _validate_Options_introspectionEndpoint(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_issuer(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.issuer, "required,url"); err != nil {
+		return fmt461e464ebed9.Errorf("field `issuer` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_introspectionEndpoint(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.introspectionEndpoint, "omitempty,url"); err != nil {
+		return fmt461e464ebed9.Errorf("field `introspectionEndpoint` did not pass the test: %w", err)
+	}
+	return nil
+}
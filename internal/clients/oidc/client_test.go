@@ -0,0 +1,129 @@
+package oidcclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+)
+
+const (
+	clientID     = "chat-service"
+	clientSecret = "secret"
+	jwksBody     = `{"keys": []}`
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("endpoints are discovered once", func(t *testing.T) {
+		provider := newProviderStub(t, nil)
+		client := newClient(t, provider.URL)
+
+		for i := 0; i < 2; i++ {
+			data, err := client.FetchJWKS(ctx)
+			require.NoError(t, err)
+			assert.JSONEq(t, jwksBody, string(data))
+
+			result, err := client.IntrospectToken(ctx, "token")
+			require.NoError(t, err)
+			assert.True(t, result.Active)
+			assert.Equal(t, []string{clientID}, result.Aud)
+		}
+		assert.Equal(t, int32(1), provider.discoveries.Load())
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		provider := newProviderStub(t, map[string]string{"issuer": "https://id.bank.example"})
+		client := newClient(t, provider.URL)
+
+		_, err := client.FetchJWKS(ctx)
+		require.ErrorIs(t, err, oidcclient.ErrIssuerMismatch)
+	})
+
+	t.Run("no introspection endpoint", func(t *testing.T) {
+		provider := newProviderStub(t, map[string]string{"introspection_endpoint": ""})
+		client := newClient(t, provider.URL)
+
+		_, err := client.IntrospectToken(ctx, "token")
+		require.ErrorIs(t, err, oidcclient.ErrNoIntrospectionEndpoint)
+	})
+
+	t.Run("introspection endpoint is configured", func(t *testing.T) {
+		provider := newProviderStub(t, map[string]string{"introspection_endpoint": ""})
+		client := newClient(t, provider.URL, oidcclient.WithIntrospectionEndpoint(provider.URL+"/introspect"))
+
+		result, err := client.IntrospectToken(ctx, "token")
+		require.NoError(t, err)
+		assert.True(t, result.Active)
+		assert.Zero(t, provider.discoveries.Load())
+	})
+
+	t.Run("invalid client credentials", func(t *testing.T) {
+		provider := newProviderStub(t, nil)
+		client, err := oidcclient.New(oidcclient.NewOptions(provider.URL, clientID, "wrong", false))
+		require.NoError(t, err)
+
+		_, err = client.IntrospectToken(ctx, "token")
+		require.Error(t, err)
+	})
+}
+
+func newClient(t *testing.T, issuer string, opts ...oidcclient.OptOptionsSetter) *oidcclient.Client {
+	t.Helper()
+
+	client, err := oidcclient.New(oidcclient.NewOptions(issuer, clientID, clientSecret, false, opts...))
+	require.NoError(t, err)
+	return client
+}
+
+type providerStub struct {
+	*httptest.Server
+	discoveries atomic.Int32
+}
+
+// newProviderStub starts the provider, the metadata overrides the discovered fields.
+func newProviderStub(t *testing.T, metadata map[string]string) *providerStub {
+	t.Helper()
+
+	p := new(providerStub)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		p.discoveries.Add(1)
+		m := map[string]string{
+			"issuer":                 p.URL,
+			"jwks_uri":               p.URL + "/certs",
+			"introspection_endpoint": p.URL + "/introspect",
+		}
+		for k, v := range metadata {
+			m[k] = v
+		}
+		writeJSON(w, m)
+	})
+	mux.HandleFunc("/certs", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(jwksBody))
+	})
+	mux.HandleFunc("/introspect", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != clientID || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, map[string]any{"active": r.PostFormValue("token") != "", "aud": clientID})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -9,6 +9,7 @@ type Config struct {
 	Sentry   SentryConfig  `toml:"sentry"`
 	Clients  ClientConfig  `toml:"clients"`
 	DB       DBConfig      `toml:"db"`
+	Broker   BrokerConfig  `toml:"broker"`
 	Services ServiceConfig `toml:"services"`
 }
 
@@ -43,23 +44,65 @@ type ServerConfig struct {
 	AllowOrigins   []string       `toml:"allow_origins" validate:"required"`
 	SecWSProtocol  string         `toml:"sec_ws_protocol" validate:"required"`
 	RequiredAccess RequiredAccess `toml:"required_access" validate:"required"`
+	Auth           AuthConfig     `toml:"auth"`
+	WS             WSConfig       `toml:"ws"`
+}
+
+type AuthConfig struct {
+	Provider string `toml:"provider" validate:"omitempty,oneof=keycloak oidc"`
+	Mode     string `toml:"mode" validate:"required,oneof=active passive"`
+	Issuer   string `toml:"issuer" validate:"required_if=Mode passive,omitempty,url"`
+	Audience string `toml:"audience" validate:"required_if=Mode passive"`
+}
+
+type WSConfig struct {
+	MaxConnsPerUser      int           `toml:"max_conns_per_user" validate:"min=1,max=1000"`
+	MaxConns             int           `toml:"max_conns" validate:"min=1"`
+	PingPeriod           time.Duration `toml:"ping_period" validate:"min=10ms,max=30s"`
+	PongWait             time.Duration `toml:"pong_wait" validate:"gtfield=PingPeriod,max=5m"`
+	WriteTimeout         time.Duration `toml:"write_timeout" validate:"min=10ms,max=1m"`
+	ReadLimit            int64         `toml:"read_limit" validate:"min=512"`
+	ReadBufferSize       int           `toml:"read_buffer_size" validate:"min=0,max=1048576"`
+	WriteBufferSize      int           `toml:"write_buffer_size" validate:"min=0,max=1048576"`
+	Compression          bool          `toml:"compression"`
+	CompressionThreshold int           `toml:"compression_threshold" validate:"min=0"`
 }
 
 type RequiredAccess struct {
-	Resource string `toml:"resource" validate:"required"`
-	Role     string `toml:"role" validate:"required"`
+	Resource  string `toml:"resource" validate:"required_without=RoleClaim"`
+	RoleClaim string `toml:"role_claim" validate:"omitempty,startswith=$"`
+	Role      string `toml:"role" validate:"required"`
 }
 
+// ClientConfig configures the identity providers, at least one of them is required.
 type ClientConfig struct {
-	Keycloak Keycloak `toml:"keycloak" validate:"required"`
+	Keycloak *Keycloak   `toml:"keycloak" validate:"required_without=OIDC"`
+	OIDC     *OIDCConfig `toml:"oidc"`
 }
 
 type Keycloak struct {
-	BasePath     string `toml:"base_path" validate:"required"`
-	Realm        string `toml:"realm" validate:"required"`
-	ClientID     string `toml:"client_id" validate:"required"`
-	ClientSecret string `toml:"client_secret" validate:"required"`
-	DebugMode    bool   `toml:"debug_mode"`
+	BasePath               string                   `toml:"base_path" validate:"required"`
+	Realm                  string                   `toml:"realm" validate:"required"`
+	ClientID               string                   `toml:"client_id" validate:"required"`
+	ClientSecret           string                   `toml:"client_secret" validate:"required"`
+	DebugMode              bool                     `toml:"debug_mode"`
+	JWKSMinRefreshInterval time.Duration            `toml:"jwks_min_refresh_interval" validate:"min=1s,max=1h"`
+	IntrospectionCache     IntrospectionCacheConfig `toml:"introspection_cache"`
+}
+
+type OIDCConfig struct {
+	Issuer                 string                   `toml:"issuer" validate:"required,url"`
+	ClientID               string                   `toml:"client_id"`
+	ClientSecret           string                   `toml:"client_secret"`
+	IntrospectionEndpoint  string                   `toml:"introspection_endpoint" validate:"omitempty,url"`
+	DebugMode              bool                     `toml:"debug_mode"`
+	JWKSMinRefreshInterval time.Duration            `toml:"jwks_min_refresh_interval" validate:"min=1s,max=1h"`
+	IntrospectionCache     IntrospectionCacheConfig `toml:"introspection_cache"`
+}
+
+type IntrospectionCacheConfig struct {
+	MaxTTL     time.Duration `toml:"max_ttl" validate:"max=1h"`
+	MaxEntries int           `toml:"max_entries" validate:"required_with=MaxTTL,omitempty,min=1"`
 }
 
 type DBConfig struct {
@@ -74,24 +117,48 @@ type PGConfig struct {
 	DebugMode bool   `toml:"debug_mode"`
 }
 
+type BrokerConfig struct {
+	Kind string     `toml:"kind" validate:"omitempty,oneof=kafka nats inmem"`
+	NATS NATSConfig `toml:"nats"`
+}
+
+type NATSConfig struct {
+	URL    string `toml:"url" validate:"omitempty,url"`
+	Stream string `toml:"stream" validate:"required_with=URL"`
+}
+
 type ServiceConfig struct {
 	MsgProducer         MsgProducerConfig         `toml:"msg_producer"`
 	Outbox              OutboxConfig              `toml:"outbox"`
 	ManagerLoad         ManagerLoadConfig         `toml:"manager_load"`
 	AFCVerdictProcessor AFCVerdictProcessorConfig `toml:"afc_verdicts_processor"`
+	LocalVerdict        LocalVerdictConfig        `toml:"local_verdict"`
+	Redaction           RedactionConfig           `toml:"redaction"`
 }
 
 type MsgProducerConfig struct {
-	Brokers    []string `toml:"brokers" validate:"required,dive,hostname_port"`
-	Topic      string   `toml:"topic" validate:"required"`
-	BatchSize  int      `toml:"batch_size"`
-	EncryptKey string   `toml:"encrypt_key"`
+	Brokers      []string          `toml:"brokers" validate:"required,dive,hostname_port"`
+	Topic        string            `toml:"topic" validate:"required"`
+	BatchSize    int               `toml:"batch_size"`
+	EncryptKey   string            `toml:"encrypt_key"`
+	EncryptKeys  map[string]string `toml:"encrypt_keys"`
+	PrimaryKeyID string            `toml:"primary_key_id"`
 }
 
 type OutboxConfig struct {
-	Workers    int           `toml:"workers" validate:"required"`
-	IdleTime   time.Duration `toml:"idle_time" validate:"required"`
-	ReserveFor time.Duration `toml:"reserve_for"`
+	Workers       int                    `toml:"workers" validate:"required"`
+	IdleTime      time.Duration          `toml:"idle_time" validate:"required"`
+	ReserveFor    time.Duration          `toml:"reserve_for"`
+	DedupTTL      time.Duration          `toml:"dedup_ttl" validate:"min=1s,max=168h"`
+	SchedulerTick time.Duration          `toml:"scheduler_tick" validate:"min=10ms,max=1m"`
+	DrainTimeout  time.Duration          `toml:"drain_timeout" validate:"max=5m"`
+	Schedules     []OutboxScheduleConfig `toml:"schedules" validate:"dive"`
+}
+
+type OutboxScheduleConfig struct {
+	Job     string `toml:"job" validate:"required"`
+	Spec    string `toml:"spec" validate:"required"`
+	Payload string `toml:"payload"`
 }
 
 type ManagerLoadConfig struct {
@@ -99,16 +166,40 @@ type ManagerLoadConfig struct {
 }
 
 type AFCVerdictProcessorConfig struct {
-	BackoffInitialInterval time.Duration `toml:"backoff_initial_interval" validate:"min=50ms,max=1s"`
-	BackoffMaxElapsedTime  time.Duration `toml:"backoff_max_elapsed_time" validate:"min=500ms,max=1m"`
-	BackoffFactor          float64       `toml:"backoff_factor" validate:"min=1.01,max=10"`
-	Brokers                []string      `toml:"brokers" validate:"required,dive,hostname_port"`
-	Consumers              int           `toml:"consumers" validate:"required,min=1"`
-	ConsumerGroup          string        `toml:"consumer_group" validate:"required"`
-	VerdictTopic           string        `toml:"verdict_topic" validate:"required"`
-	VerdictSignKey         string        `toml:"verdicts_signing_public_key"`
-	VerdictTopicDLQ        string        `toml:"verdict_topic_dlq" validate:"required"`
-	ProcessBatchSize       int           `toml:"process_batch_size" validate:"min=1,max=1000"`
-	ProcessBatchMaxTimeout time.Duration `toml:"process_batch_max_timeout" validate:"min=50ms,max=10s"`
-	Retries                int           `toml:"retries" validate:"min=1,max=10"`
+	BackoffInitialInterval time.Duration     `toml:"backoff_initial_interval" validate:"min=50ms,max=1s"`
+	BackoffMaxElapsedTime  time.Duration     `toml:"backoff_max_elapsed_time" validate:"min=500ms,max=1m"`
+	BackoffFactor          float64           `toml:"backoff_factor" validate:"min=1.01,max=10"`
+	DLQBackoffMaxInterval  time.Duration     `toml:"dlq_backoff_max_interval" validate:"min=100ms,max=10m"`
+	Brokers                []string          `toml:"brokers" validate:"required,dive,hostname_port"`
+	Consumers              int               `toml:"consumers" validate:"required,min=1"`
+	ConsumerGroup          string            `toml:"consumer_group" validate:"required"`
+	VerdictTopic           string            `toml:"verdict_topic" validate:"required"`
+	VerdictSignKey         string            `toml:"verdicts_signing_public_key"`
+	VerdictSignKeys        map[string]string `toml:"verdicts_signing_public_keys"`
+	VerdictJWKSFile        string            `toml:"verdicts_jwks_file"`
+	JWKSReloadInterval     time.Duration     `toml:"jwks_reload_interval" validate:"min=10ms,max=1h"`
+	VerdictTopicDLQ        string            `toml:"verdict_topic_dlq" validate:"required"`
+	ProcessBatchSize       int               `toml:"process_batch_size" validate:"min=1,max=1000"`
+	ProcessBatchMaxTimeout time.Duration     `toml:"process_batch_max_timeout" validate:"min=50ms,max=10s"`
+	Retries                int               `toml:"retries" validate:"min=1,max=10"`
+	VerdictConflictPolicy  string            `toml:"verdict_conflict_policy" validate:"omitempty,oneof=suspicious_wins latest_wins"`
+	LocalVerdictPrecedence string            `toml:"local_verdict_precedence" validate:"omitempty,oneof=local afc"`
+}
+
+type LocalVerdictConfig struct {
+	AFCSLA              time.Duration `toml:"afc_sla" validate:"min=0,max=24h"`
+	Detectors           []string      `toml:"detectors"`
+	RulesFile           string        `toml:"rules_file"`
+	RulesReloadInterval time.Duration `toml:"rules_reload_interval" validate:"min=10ms,max=1h"`
+	BlockScore          int           `toml:"block_score" validate:"min=1"`
+}
+
+type RedactionConfig struct {
+	Logs bool `toml:"logs"`
+	// LogDetectors are the facts masked in the logs, bloodhound.LogFacts if empty.
+	LogDetectors    []string `toml:"log_detectors"`
+	BlockedMessages bool     `toml:"blocked_messages"`
+	AuditPublicKey  string   `toml:"audit_public_key" validate:"required_if=BlockedMessages true"`
+	// ManagerMessages masks the messages bodies sent to the managers, the messages below the block threshold included.
+	ManagerMessages bool `toml:"manager_messages"`
 }
@@ -4,11 +4,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/gerladeno/chat-service/internal/config"
+	"github.com/gerladeno/chat-service/internal/validator"
 )
 
 var configExamplePath string
@@ -23,3 +25,22 @@ func TestParseAndValidate(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, cfg.Log.Level)
 }
+
+func TestClientConfig_Validate(t *testing.T) {
+	oidc := &config.OIDCConfig{Issuer: "https://id.bank.example", JWKSMinRefreshInterval: time.Minute}
+
+	t.Run("oidc only", func(t *testing.T) {
+		err := validator.Validator.Struct(config.ClientConfig{OIDC: oidc})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no providers", func(t *testing.T) {
+		err := validator.Validator.Struct(config.ClientConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid keycloak", func(t *testing.T) {
+		err := validator.Validator.Struct(config.ClientConfig{Keycloak: &config.Keycloak{}, OIDC: oidc})
+		assert.Error(t, err)
+	})
+}
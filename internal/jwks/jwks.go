@@ -0,0 +1,238 @@
+package jwks
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrUnknownKeyID     = errors.New("unknown key id")
+	ErrAlgMismatch      = errors.New("token alg doesn't match the key")
+	ErrUnsupportedKey   = errors.New("unsupported key type")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Key is a public key with the only signing method it is allowed to verify.
+type Key struct {
+	Method    jwt.SigningMethod
+	PublicKey any
+}
+
+// NewKey chooses the signing method by the key type: RS256 for RSA, ES256 for P-256 ECDSA and EdDSA for Ed25519.
+func NewKey(publicKey any) (Key, error) {
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		return Key{Method: jwt.SigningMethodRS256, PublicKey: k}, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return Key{}, fmt.Errorf("%w: ecdsa curve %s", ErrUnsupportedKey, k.Curve.Params().Name)
+		}
+		return Key{Method: jwt.SigningMethodES256, PublicKey: k}, nil
+	case ed25519.PublicKey:
+		return Key{Method: jwt.SigningMethodEdDSA, PublicKey: k}, nil
+	default:
+		return Key{}, fmt.Errorf("%w: %T", ErrUnsupportedKey, publicKey)
+	}
+}
+
+// ParsePEM parses the PKIX public key in PEM.
+func ParsePEM(data string) (Key, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return Key{}, errors.New("invalid pem")
+	}
+	pubKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return Key{}, fmt.Errorf("parse public key: %v", err)
+	}
+	return NewKey(pubKey)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Parse parses the JSON Web Key Set, the keys not intended for signatures and the unsupported ones are skipped.
+// ErrUnsupportedKey is returned if none of the skipped unsupported keys is left to verify the tokens.
+func Parse(data []byte) (map[string]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("unmarshal jwks: %v", err)
+	}
+
+	keys := make(map[string]Key, len(set.Keys))
+	var unsupported []string
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.parse()
+		if errors.Is(err, ErrUnsupportedKey) || (err == nil && k.Alg != "" && k.Alg != key.Method.Alg()) {
+			unsupported = append(unsupported, k.Kid)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 && len(unsupported) != 0 {
+		return nil, fmt.Errorf("%w: no supported keys, skipped %q", ErrUnsupportedKey, unsupported)
+	}
+	return keys, nil
+}
+
+func (k jwk) parse() (Key, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return Key{}, fmt.Errorf("decode n: %v", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return Key{}, fmt.Errorf("decode e: %v", err)
+		}
+		return NewKey(&rsa.PublicKey{N: n, E: int(e.Int64())})
+
+	case "EC":
+		if k.Crv != "P-256" {
+			return Key{}, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return Key{}, fmt.Errorf("decode x: %v", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return Key{}, fmt.Errorf("decode y: %v", err)
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return Key{}, errors.New("point is not on curve")
+		}
+		return NewKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return Key{}, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return Key{}, fmt.Errorf("decode x: %v", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return Key{}, errors.New("invalid ed25519 key size")
+		}
+		return NewKey(ed25519.PublicKey(x))
+
+	default:
+		return Key{}, fmt.Errorf("%w: kty %s", ErrUnsupportedKey, k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// Set is a concurrent safe set of the keys by their ids.
+type Set struct {
+	mu   sync.RWMutex
+	keys map[string]Key
+}
+
+func NewSet(keys map[string]Key) *Set {
+	s := new(Set)
+	s.Replace(keys)
+	return s
+}
+
+// Replace replaces all the keys of the set.
+func (s *Set) Replace(keys map[string]Key) {
+	cp := make(map[string]Key, len(keys))
+	for kid, k := range keys {
+		cp[kid] = k
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = cp
+}
+
+func (s *Set) Get(kid string) (Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+func (s *Set) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.keys)
+}
+
+// Verify checks the signature of the compact JWS with the key chosen by the kid header.
+// The key without id is used for the tokens without kid. It returns the decoded payload.
+func (s *Set) Verify(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: %d parts", ErrInvalidToken, len(parts))
+	}
+
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode header: %v", ErrInvalidToken, err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal header: %v", ErrInvalidToken, err)
+	}
+
+	key, ok := s.Get(header.Kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, header.Kid)
+	}
+	if header.Alg != key.Method.Alg() {
+		return nil, fmt.Errorf("%w: %s, expected %s", ErrAlgMismatch, header.Alg, key.Method.Alg())
+	}
+	if err := key.Method.Verify(strings.Join(parts[:2], "."), parts[2], key.PublicKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode payload: %v", ErrInvalidToken, err)
+	}
+	return payload, nil
+}
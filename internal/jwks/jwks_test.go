@@ -0,0 +1,206 @@
+package jwks_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/jwks"
+)
+
+type claims struct {
+	Status string `json:"status"`
+}
+
+func (claims) Valid() error { return nil }
+
+func TestSet_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := jwks.Parse([]byte(fmt.Sprintf(`{"keys": [%s, %s, %s]}`,
+		rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey), edJWK("ed", edPub))))
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	set := jwks.NewSet(keys)
+
+	for _, tt := range []struct {
+		kid    string
+		method jwt.SigningMethod
+		key    any
+	}{
+		{kid: "rsa", method: jwt.SigningMethodRS256, key: rsaKey},
+		{kid: "ec", method: jwt.SigningMethodES256, key: ecKey},
+		{kid: "ed", method: jwt.SigningMethodEdDSA, key: edKey},
+	} {
+		t.Run(tt.method.Alg(), func(t *testing.T) {
+			payload, err := set.Verify(sign(t, tt.method, tt.kid, tt.key))
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"status":"ok"}`, string(payload))
+		})
+	}
+
+	t.Run("unknown kid", func(t *testing.T) {
+		_, err := set.Verify(sign(t, jwt.SigningMethodRS256, "unknown", rsaKey))
+		require.ErrorIs(t, err, jwks.ErrUnknownKeyID)
+	})
+
+	t.Run("alg doesn't match the key", func(t *testing.T) {
+		_, err := set.Verify(sign(t, jwt.SigningMethodRS512, "rsa", rsaKey))
+		require.ErrorIs(t, err, jwks.ErrAlgMismatch)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		_, err = set.Verify(sign(t, jwt.SigningMethodES256, "ec", otherKey))
+		require.ErrorIs(t, err, jwks.ErrInvalidSignature)
+	})
+
+	t.Run("not a jws", func(t *testing.T) {
+		_, err := set.Verify(`{"status":"ok"}`)
+		require.ErrorIs(t, err, jwks.ErrInvalidToken)
+	})
+}
+
+func TestSet_Replace(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	set := jwks.NewSet(map[string]jwks.Key{"old": mustNewKey(t, &oldKey.PublicKey)})
+	oldToken := sign(t, jwt.SigningMethodRS256, "old", oldKey)
+	newToken := sign(t, jwt.SigningMethodRS256, "new", newKey)
+
+	_, err = set.Verify(oldToken)
+	require.NoError(t, err)
+	_, err = set.Verify(newToken)
+	require.ErrorIs(t, err, jwks.ErrUnknownKeyID)
+
+	set.Replace(map[string]jwks.Key{"new": mustNewKey(t, &newKey.PublicKey)})
+
+	_, err = set.Verify(oldToken)
+	require.ErrorIs(t, err, jwks.ErrUnknownKeyID)
+	_, err = set.Verify(newToken)
+	require.NoError(t, err)
+}
+
+func TestParsePEM(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	key, err := jwks.ParsePEM(toPEM(t, &ecKey.PublicKey))
+	require.NoError(t, err)
+	assert.Equal(t, "ES256", key.Method.Alg())
+
+	key, err = jwks.ParsePEM(toPEM(t, edPub))
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", key.Method.Alg())
+
+	_, err = jwks.ParsePEM(toPEM(t, &p384Key.PublicKey))
+	require.ErrorIs(t, err, jwks.ErrUnsupportedKey)
+
+	_, err = jwks.ParsePEM("not a pem")
+	require.Error(t, err)
+}
+
+func TestParse_SkipsEncryptionKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys, err := jwks.Parse([]byte(fmt.Sprintf(`{"keys": [%s, {"kty": "RSA", "kid": "enc", "use": "enc"}]}`,
+		rsaJWK("sig", &rsaKey.PublicKey))))
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Contains(t, keys, "sig")
+}
+
+func TestParse_SkipsUnsupportedKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	unsupported := []string{
+		`{"kty": "RSA", "kid": "rs512", "use": "sig", "alg": "RS512", "n": "AQAB", "e": "AQAB"}`,
+		`{"kty": "RSA", "kid": "ps256", "alg": "PS256", "n": "AQAB", "e": "AQAB"}`,
+		fmt.Sprintf(`{"kty": "EC", "kid": "es384", "crv": "P-384", "x": %q, "y": %q}`,
+			b64(p384Key.X.Bytes()), b64(p384Key.Y.Bytes())),
+		`{"kty": "oct", "kid": "hs256", "k": "c2VjcmV0"}`,
+	}
+
+	keys, err := jwks.Parse([]byte(fmt.Sprintf(`{"keys": [%s, %s, %s, %s, %s]}`,
+		unsupported[0], unsupported[1], rsaJWK("rs256", &rsaKey.PublicKey), unsupported[2], unsupported[3])))
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Contains(t, keys, "rs256")
+
+	_, err = jwks.Parse([]byte(fmt.Sprintf(`{"keys": [%s, %s, %s, %s]}`,
+		unsupported[0], unsupported[1], unsupported[2], unsupported[3])))
+	require.ErrorIs(t, err, jwks.ErrUnsupportedKey)
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims{Status: "ok"})
+	token.Header["kid"] = kid
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func mustNewKey(t *testing.T, publicKey any) jwks.Key {
+	t.Helper()
+
+	k, err := jwks.NewKey(publicKey)
+	require.NoError(t, err)
+	return k
+}
+
+func toPEM(t *testing.T, publicKey any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, k *rsa.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "RSA", "kid": %q, "use": "sig", "alg": "RS256", "n": %q, "e": %q}`,
+		kid, b64(k.N.Bytes()), b64(big.NewInt(int64(k.E)).Bytes()))
+}
+
+func ecJWK(kid string, k *ecdsa.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "EC", "kid": %q, "crv": "P-256", "x": %q, "y": %q}`,
+		kid, b64(k.X.FillBytes(make([]byte, 32))), b64(k.Y.FillBytes(make([]byte, 32))))
+}
+
+func edJWK(kid string, k ed25519.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "OKP", "kid": %q, "crv": "Ed25519", "x": %q}`, kid, b64(k))
+}
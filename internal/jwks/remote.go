@@ -0,0 +1,69 @@
+package jwks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Fetcher interface {
+	FetchJWKS(ctx context.Context) ([]byte, error)
+}
+
+// RemoteSet is the Set of the remote JWKS. The keys are fetched on the first use
+// and refetched when the token is signed with an unknown key, e.g. after the key rotation.
+type RemoteSet struct {
+	fetcher            Fetcher
+	minRefreshInterval time.Duration
+	set                *Set
+
+	mu          sync.Mutex
+	refreshedAt time.Time // The last attempt, the failed one included.
+}
+
+// NewRemoteSet creates the set refetched at most once per minRefreshInterval,
+// so the tokens with made up key ids don't flood the remote.
+func NewRemoteSet(fetcher Fetcher, minRefreshInterval time.Duration) *RemoteSet {
+	return &RemoteSet{
+		fetcher:            fetcher,
+		minRefreshInterval: minRefreshInterval,
+		set:                NewSet(nil),
+	}
+}
+
+// Verify is Set.Verify refreshing the keys on unknown key id.
+func (s *RemoteSet) Verify(ctx context.Context, token string) ([]byte, error) {
+	payload, err := s.set.Verify(token)
+	if !errors.Is(err, ErrUnknownKeyID) {
+		return payload, err
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("refresh jwks: %w", err)
+	}
+	return s.set.Verify(token)
+}
+
+func (s *RemoteSet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The concurrent caller could have refreshed the keys while this one was waiting.
+	if !s.refreshedAt.IsZero() && time.Since(s.refreshedAt) < s.minRefreshInterval {
+		return nil
+	}
+
+	s.refreshedAt = time.Now()
+	data, err := s.fetcher.FetchJWKS(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch: %v", err)
+	}
+	keys, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	s.set.Replace(keys)
+	return nil
+}
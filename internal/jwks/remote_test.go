@@ -0,0 +1,83 @@
+package jwks_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/jwks"
+)
+
+func TestRemoteSet_Verify(t *testing.T) {
+	const minRefreshInterval = 100 * time.Millisecond
+
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fetcher := &fetcherMock{}
+	fetcher.data.Store(fmt.Sprintf(`{"keys": [%s]}`, rsaJWK("old", &oldKey.PublicKey)))
+	set := jwks.NewRemoteSet(fetcher, minRefreshInterval)
+	ctx := context.Background()
+
+	t.Run("fetched on first use", func(t *testing.T) {
+		_, err := set.Verify(ctx, sign(t, jwt.SigningMethodRS256, "old", oldKey))
+		require.NoError(t, err)
+		_, err = set.Verify(ctx, sign(t, jwt.SigningMethodRS256, "old", oldKey))
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), fetcher.calls.Load())
+	})
+
+	newToken := sign(t, jwt.SigningMethodRS256, "new", newKey)
+	fetcher.data.Store(fmt.Sprintf(`{"keys": [%s, %s]}`,
+		rsaJWK("old", &oldKey.PublicKey), rsaJWK("new", &newKey.PublicKey)))
+
+	t.Run("unknown kid is not refetched too often", func(t *testing.T) {
+		_, err := set.Verify(ctx, newToken)
+		require.ErrorIs(t, err, jwks.ErrUnknownKeyID)
+		assert.Equal(t, int32(1), fetcher.calls.Load())
+	})
+
+	t.Run("unknown kid is refetched", func(t *testing.T) {
+		time.Sleep(minRefreshInterval)
+
+		_, err := set.Verify(ctx, newToken)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), fetcher.calls.Load())
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		time.Sleep(minRefreshInterval)
+		fetcher.err = errors.New("unavailable")
+
+		_, err := set.Verify(ctx, sign(t, jwt.SigningMethodRS256, "unknown", newKey))
+		require.Error(t, err)
+
+		_, err = set.Verify(ctx, newToken)
+		require.NoError(t, err, "the previous keys are kept")
+	})
+}
+
+type fetcherMock struct {
+	data  atomic.Value
+	calls atomic.Int32
+	err   error
+}
+
+func (f *fetcherMock) FetchJWKS(context.Context) ([]byte, error) {
+	f.calls.Add(1)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte(f.data.Load().(string)), nil
+}
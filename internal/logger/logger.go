@@ -20,6 +20,8 @@ type Options struct {
 	productionMode bool
 	sentryDSN      string
 	env            string
+	// redactor masks the sensitive data in the logs if set.
+	redactor Redactor
 }
 
 func MustInit(opts Options) {
@@ -50,6 +52,9 @@ func Init(opts Options) error {
 		config.EncodeLevel = zapcore.CapitalLevelEncoder
 		encoder = zapcore.NewJSONEncoder(config)
 	}
+	if opts.redactor != nil {
+		encoder = NewRedactingEncoder(encoder, opts.redactor)
+	}
 
 	cores := []zapcore.Core{
 		zapcore.NewCore(encoder, os.Stdout, Atom),
@@ -44,6 +44,12 @@ func WithEnv(opt string) OptOptionsSetter {
 	}
 }
 
+func WithRedactor(opt Redactor) OptOptionsSetter {
+	return func(o *Options) {
+		o.redactor = opt
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("level", _validate_Options_level(o)))
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redactor masks the sensitive data in the text.
+type Redactor interface {
+	Redact(s string) string
+}
+
+// NewRedactingEncoder redacts the entry message and the string, stringer and error fields.
+func NewRedactingEncoder(enc zapcore.Encoder, r Redactor) zapcore.Encoder {
+	return redactingEncoder{Encoder: enc, r: r}
+}
+
+type redactingEncoder struct {
+	zapcore.Encoder
+	r Redactor
+}
+
+func (e redactingEncoder) Clone() zapcore.Encoder {
+	return redactingEncoder{Encoder: e.Encoder.Clone(), r: e.r}
+}
+
+func (e redactingEncoder) AddString(key, val string) {
+	e.Encoder.AddString(key, e.r.Redact(val))
+}
+
+func (e redactingEncoder) AddByteString(key string, val []byte) {
+	e.Encoder.AddByteString(key, []byte(e.r.Redact(string(val))))
+}
+
+func (e redactingEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	ent.Message = e.r.Redact(ent.Message)
+
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type { //nolint:exhaustive // Only the text fields are redacted.
+		case zapcore.StringType:
+			f.String = e.r.Redact(f.String)
+		case zapcore.ByteStringType:
+			f = zap.ByteString(f.Key, []byte(e.r.Redact(string(f.Interface.([]byte)))))
+		case zapcore.StringerType:
+			f = zap.String(f.Key, e.r.Redact(fmt.Sprint(f.Interface)))
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				f = zap.String(f.Key, e.r.Redact(err.Error()))
+			}
+		}
+		redacted[i] = f
+	}
+	return e.Encoder.EncodeEntry(ent, redacted)
+}
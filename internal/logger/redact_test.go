@@ -0,0 +1,83 @@
+package logger_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/gerladeno/chat-service/internal/logger"
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+)
+
+type secretRedactor struct{}
+
+func (secretRedactor) Redact(s string) string { return strings.ReplaceAll(s, "secret", "******") }
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestRedactingEncoder(t *testing.T) {
+	enc := logger.NewRedactingEncoder(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), secretRedactor{})
+	enc.AddString("with", "secret with")
+	enc = enc.Clone()
+
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "msg with secret"}, []zapcore.Field{
+		zap.String("string", "secret string"),
+		zap.ByteString("bytes", []byte("secret bytes")),
+		zap.Stringer("stringer", stringer("secret stringer")),
+		zap.Error(errors.New("secret error")),
+		zap.Int("int", 42),
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.NotContains(t, out, "secret")
+	for _, s := range []string{
+		`"msg":"msg with ******"`,
+		`"with":"****** with"`,
+		`"string":"****** string"`,
+		`"bytes":"****** bytes"`,
+		`"stringer":"****** stringer"`,
+		`"error":"****** error"`,
+		`"int":42`,
+	} {
+		assert.Contains(t, out, s)
+	}
+}
+
+func TestRedactingEncoder_RequestLog(t *testing.T) {
+	encode := func(enc zapcore.Encoder, userAgent string) string {
+		buf, err := enc.EncodeEntry(zapcore.Entry{Message: "request"}, []zapcore.Field{
+			zap.Duration("latency", 1250*time.Microsecond),
+			zap.String("remote_ip", "127.0.0.1"),
+			zap.String("host", "localhost:8080"),
+			zap.String("method", "POST"),
+			zap.String("path", "/v1/getHistory"),
+			zap.String("request_id", "0b8c6a8e-4f3b-4a51-9d4f-2b1e7c3d9a10"),
+			zap.String("user_agent", userAgent),
+			zap.Int("status", 200),
+			zap.String("userId", "5cb40dc0-a249-4783-a301-9e1f3cf3ea41"),
+			zap.Error(errors.New("code=404, message=Not Found, chat 123 since 12/05")),
+		})
+		require.NoError(t, err)
+		return buf.String()
+	}
+	newEncoder := func() zapcore.Encoder { return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()) }
+	redacting := logger.NewRedactingEncoder(newEncoder(), bloodhound.NewSearcher(bloodhound.LogFacts()...))
+
+	t.Run("request line is unchanged", func(t *testing.T) {
+		const userAgent = "Mozilla/5.0 (X11; Linux x86_64) Chrome/112.0.0.0 Safari/537.36"
+		assert.Equal(t, encode(newEncoder(), userAgent), encode(redacting, userAgent))
+	})
+
+	t.Run("card number is masked", func(t *testing.T) {
+		assert.Contains(t, encode(redacting, "card 4276 1234 5678 1235"), `"user_agent":"card 4276 **** **** 1235"`)
+	})
+}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidClaimPath = errors.New("invalid claim path")
+
+// claimPath is the JSONPath subset addressing the roles in the token claims,
+// e.g. "$.roles", "$.realm_access.roles", "$['https://bank.example/groups']"
+// or "$.resource_access['chat-ui-client'].roles".
+type claimPath []string
+
+func parseClaimPath(s string) (claimPath, error) {
+	rest, ok := strings.CutPrefix(s, "$")
+	if !ok {
+		return nil, fmt.Errorf("%w %q: must start with $", ErrInvalidClaimPath, s)
+	}
+
+	var path claimPath
+	for rest != "" {
+		var key string
+		switch {
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			key, rest = rest[:end], rest[end:]
+
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end == -1 {
+				return nil, fmt.Errorf("%w %q: unclosed bracket", ErrInvalidClaimPath, s)
+			}
+			key, rest = rest[2:end], rest[end+2:]
+
+		default:
+			return nil, fmt.Errorf("%w %q: unexpected %q", ErrInvalidClaimPath, s, rest)
+		}
+		if key == "" {
+			return nil, fmt.Errorf("%w %q: empty key", ErrInvalidClaimPath, s)
+		}
+		path = append(path, key)
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w %q: no keys", ErrInvalidClaimPath, s)
+	}
+	return path, nil
+}
+
+// roles returns the list of strings or the space-separated string the path points to.
+func (p claimPath) roles(claims map[string]any) []string {
+	var v any = claims
+	for _, key := range p {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+
+	switch vv := v.(type) {
+	case string:
+		return strings.Fields(vv)
+	case []any:
+		roles := make([]string, 0, len(vv))
+		for _, r := range vv {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	}
+	return nil
+}
+
+func (p claimPath) String() string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, key := range p {
+		if strings.ContainsAny(key, ".[]'") {
+			b.WriteString("['" + key + "']")
+		} else {
+			b.WriteString("." + key)
+		}
+	}
+	return b.String()
+}
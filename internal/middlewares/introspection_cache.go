@@ -0,0 +1,207 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+)
+
+const (
+	metricsNamespace = "chat_service"
+	metricsSubsystem = "introspection_cache"
+)
+
+//go:generate options-gen -out-filename=introspection_cache_options.gen.go -from-struct=CachingIntrospectorOptions
+type CachingIntrospectorOptions struct {
+	introspector Introspector `option:"mandatory" validate:"required"`
+	// maxTTL bounds the time the result is cached for, the active token is cached not longer than it lives.
+	maxTTL time.Duration `default:"30s" validate:"min=1s,max=1h"`
+	// maxEntries bounds the memory, the results over it are not cached until the expired ones are swept.
+	maxEntries int `default:"10000" validate:"min=1"`
+	// timeout bounds the introspection shared by the concurrent callers, it outlives the request that started it.
+	timeout time.Duration `default:"10s" validate:"min=100ms,max=1m"`
+}
+
+// CachingIntrospector caches the introspection results by the token hash, the inactive tokens included.
+// The concurrent introspections of the same token are collapsed into one.
+type CachingIntrospector struct {
+	CachingIntrospectorOptions
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]introspectionEntry
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type introspectionEntry struct {
+	result    oidcclient.IntrospectTokenResult
+	expiresAt time.Time
+}
+
+func NewCachingIntrospector(opts CachingIntrospectorOptions) (*CachingIntrospector, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating caching introspector options: %v", err)
+	}
+	return &CachingIntrospector{
+		CachingIntrospectorOptions: opts,
+		entries:                    make(map[[sha256.Size]byte]introspectionEntry),
+	}, nil
+}
+
+// IntrospectToken introspects the token once for the concurrent callers, each of them waits until its ctx is done.
+func (c *CachingIntrospector) IntrospectToken(ctx context.Context, token string) (*oidcclient.IntrospectTokenResult, error) {
+	key := sha256.Sum256([]byte(token))
+	if result, ok := c.get(key); ok {
+		c.hits.Add(1)
+		return result, nil
+	}
+
+	var called bool // Only the first of the concurrent callers calls Keycloak.
+	ch := c.group.DoChan(string(key[:]), func() (any, error) {
+		called = true
+		c.misses.Add(1)
+
+		ctx, cancel := context.WithTimeout(detachedContext{parent: ctx}, c.timeout)
+		defer cancel()
+
+		result, err := c.introspector.IntrospectToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		c.put(key, *result)
+		return result, nil
+	})
+
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+	if !called {
+		c.hits.Add(1)
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	result := *res.Val.(*oidcclient.IntrospectTokenResult) //nolint:forcetypeassert // Set above.
+	return &result, nil
+}
+
+func (c *CachingIntrospector) get(key [sha256.Size]byte) (*oidcclient.IntrospectTokenResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	result := e.result
+	return &result, true
+}
+
+func (c *CachingIntrospector) put(key [sha256.Size]byte, result oidcclient.IntrospectTokenResult) {
+	now := time.Now()
+	expiresAt := now.Add(c.maxTTL)
+	if result.Active && result.Exp != 0 {
+		if exp := time.Unix(int64(result.Exp), 0); exp.Before(expiresAt) {
+			expiresAt = exp
+		}
+	}
+	if !expiresAt.After(now) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+	c.entries[key] = introspectionEntry{result: result, expiresAt: expiresAt}
+}
+
+// detachedContext keeps the values of the parent context but not its deadline and cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
+
+type IntrospectionCacheStats struct {
+	// Hits are the introspections served without Keycloak, the collapsed concurrent ones included.
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+func (c *CachingIntrospector) Stats() IntrospectionCacheStats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	return IntrospectionCacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: entries,
+	}
+}
+
+var (
+	hitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "hits_total"),
+		"Number of token introspections served without Keycloak.",
+		nil, nil,
+	)
+	missesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "misses_total"),
+		"Number of token introspections sent to Keycloak.",
+		nil, nil,
+	)
+	entriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "entries"),
+		"Number of cached introspection results, the expired ones not swept yet included.",
+		nil, nil,
+	)
+)
+
+// Describe implements prometheus.Collector.
+func (c *CachingIntrospector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- hitsDesc
+	ch <- missesDesc
+	ch <- entriesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *CachingIntrospector) Collect(ch chan<- prometheus.Metric) {
+	st := c.Stats()
+	ch <- prometheus.MustNewConstMetric(hitsDesc, prometheus.CounterValue, float64(st.Hits))
+	ch <- prometheus.MustNewConstMetric(missesDesc, prometheus.CounterValue, float64(st.Misses))
+	ch <- prometheus.MustNewConstMetric(entriesDesc, prometheus.GaugeValue, float64(st.Entries))
+}
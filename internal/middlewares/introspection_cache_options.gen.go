@@ -0,0 +1,86 @@
+// Code generated by options-gen. DO NOT EDIT.
+package middlewares
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptCachingIntrospectorOptionsSetter func(o *CachingIntrospectorOptions)
+
+func NewCachingIntrospectorOptions(
+	introspector Introspector,
+	options ...OptCachingIntrospectorOptionsSetter,
+) CachingIntrospectorOptions {
+	o := CachingIntrospectorOptions{}
+
+	// Setting defaults from field tag (if present)
+	o.maxTTL, _ = time.ParseDuration("30s")
+	o.maxEntries = 10000
+	o.timeout, _ = time.ParseDuration("10s")
+
+	o.introspector = introspector
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithMaxTTL(opt time.Duration) OptCachingIntrospectorOptionsSetter {
+	return func(o *CachingIntrospectorOptions) {
+		o.maxTTL = opt
+	}
+}
+
+func WithMaxEntries(opt int) OptCachingIntrospectorOptionsSetter {
+	return func(o *CachingIntrospectorOptions) {
+		o.maxEntries = opt
+	}
+}
+
+func WithTimeout(opt time.Duration) OptCachingIntrospectorOptionsSetter {
+	return func(o *CachingIntrospectorOptions) {
+		o.timeout = opt
+	}
+}
+
+func (o *CachingIntrospectorOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("introspector", _validate_CachingIntrospectorOptions_introspector(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxTTL", _validate_CachingIntrospectorOptions_maxTTL(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxEntries", _validate_CachingIntrospectorOptions_maxEntries(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("timeout", _validate_CachingIntrospectorOptions_timeout(o)))
+	return errs.AsError()
+}
+
+func _validate_CachingIntrospectorOptions_introspector(o *CachingIntrospectorOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.introspector, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `introspector` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_CachingIntrospectorOptions_maxTTL(o *CachingIntrospectorOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxTTL, "min=1s,max=1h"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxTTL` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_CachingIntrospectorOptions_maxEntries(o *CachingIntrospectorOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxEntries, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxEntries` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_CachingIntrospectorOptions_timeout(o *CachingIntrospectorOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.timeout, "min=100ms,max=1m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `timeout` did not pass the test: %w", err)
+	}
+	return nil
+}
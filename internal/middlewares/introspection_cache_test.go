@@ -0,0 +1,166 @@
+package middlewares_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	middlewaresmocks "github.com/gerladeno/chat-service/internal/middlewares/mocks"
+)
+
+func TestCachingIntrospector(t *testing.T) {
+	ctx := context.Background()
+	activeFor := func(d time.Duration) *oidcclient.IntrospectTokenResult {
+		return &oidcclient.IntrospectTokenResult{Active: true, Exp: int(time.Now().Add(d).Unix())}
+	}
+
+	newCache := func(t *testing.T, opts ...middlewares.OptCachingIntrospectorOptionsSetter) (
+		*middlewares.CachingIntrospector, *middlewaresmocks.MockIntrospector,
+	) {
+		introspector := middlewaresmocks.NewMockIntrospector(gomock.NewController(t))
+		c, err := middlewares.NewCachingIntrospector(middlewares.NewCachingIntrospectorOptions(introspector, opts...))
+		require.NoError(t, err)
+		return c, introspector
+	}
+	introspectTwice := func(t *testing.T, c *middlewares.CachingIntrospector, token string) {
+		t.Helper()
+		for i := 0; i < 2; i++ {
+			_, _ = c.IntrospectToken(ctx, token)
+		}
+	}
+
+	t.Run("active token is cached", func(t *testing.T) {
+		c, introspector := newCache(t)
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").Return(activeFor(time.Hour), nil).Times(1)
+
+		for i := 0; i < 3; i++ {
+			result, err := c.IntrospectToken(ctx, "token")
+			require.NoError(t, err)
+			assert.True(t, result.Active)
+		}
+		assert.Equal(t, middlewares.IntrospectionCacheStats{Hits: 2, Misses: 1, Entries: 1}, c.Stats())
+	})
+
+	t.Run("inactive token is cached", func(t *testing.T) {
+		c, introspector := newCache(t)
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").
+			Return(&oidcclient.IntrospectTokenResult{Active: false}, nil).Times(1)
+
+		introspectTwice(t, c, "token")
+		result, err := c.IntrospectToken(ctx, "token")
+		require.NoError(t, err)
+		assert.False(t, result.Active)
+	})
+
+	t.Run("error is not cached", func(t *testing.T) {
+		c, introspector := newCache(t)
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").Return(nil, errors.New("unavailable")).Times(2)
+
+		introspectTwice(t, c, "token")
+		assert.Equal(t, uint64(2), c.Stats().Misses)
+	})
+
+	t.Run("ttl is bounded by token exp", func(t *testing.T) {
+		c, introspector := newCache(t)
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").Return(activeFor(-time.Second), nil).Times(2)
+
+		introspectTwice(t, c, "token")
+		assert.Zero(t, c.Stats().Entries)
+	})
+
+	t.Run("ttl is bounded by max ttl", func(t *testing.T) {
+		c, introspector := newCache(t, middlewares.WithMaxTTL(time.Second))
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").Return(activeFor(time.Hour), nil).Times(2)
+
+		introspectTwice(t, c, "token")
+		time.Sleep(1100 * time.Millisecond)
+		_, err := c.IntrospectToken(ctx, "token")
+		require.NoError(t, err)
+	})
+
+	t.Run("entries are limited", func(t *testing.T) {
+		c, introspector := newCache(t, middlewares.WithMaxEntries(1))
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token-1").Return(activeFor(time.Hour), nil).Times(1)
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token-2").Return(activeFor(time.Hour), nil).Times(2)
+
+		introspectTwice(t, c, "token-1")
+		introspectTwice(t, c, "token-2")
+		assert.Equal(t, 1, c.Stats().Entries)
+	})
+
+	t.Run("concurrent lookups are collapsed", func(t *testing.T) {
+		const callers = 10
+
+		c, introspector := newCache(t)
+		release := make(chan struct{})
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").
+			DoAndReturn(func(context.Context, string) (*oidcclient.IntrospectTokenResult, error) {
+				<-release
+				return activeFor(time.Hour), nil
+			}).Times(1)
+
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := c.IntrospectToken(ctx, "token")
+				assert.NoError(t, err)
+				assert.True(t, result.Active)
+			}()
+		}
+		time.Sleep(100 * time.Millisecond) // Let the callers wait for the first one.
+		close(release)
+		wg.Wait()
+
+		st := c.Stats()
+		assert.Equal(t, uint64(1), st.Misses)
+		assert.Equal(t, uint64(callers-1), st.Hits)
+	})
+
+	t.Run("canceled caller doesn't cancel the collapsed lookup", func(t *testing.T) {
+		c, introspector := newCache(t)
+		started, release := make(chan struct{}), make(chan struct{})
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "token").
+			DoAndReturn(func(ctx context.Context, _ string) (*oidcclient.IntrospectTokenResult, error) {
+				close(started)
+				<-release
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				return activeFor(time.Hour), nil
+			}).Times(1)
+
+		firstCtx, cancel := context.WithCancel(ctx)
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := c.IntrospectToken(firstCtx, "token")
+			firstErr <- err
+		}()
+		<-started
+
+		secondResult := make(chan *oidcclient.IntrospectTokenResult, 1)
+		go func() {
+			result, err := c.IntrospectToken(ctx, "token")
+			assert.NoError(t, err)
+			secondResult <- result
+		}()
+		time.Sleep(100 * time.Millisecond) // Let the second caller wait for the first one.
+
+		cancel()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+		close(release)
+		result := <-secondResult
+		require.NotNil(t, result)
+		assert.True(t, result.Active)
+		assert.Equal(t, 1, c.Stats().Entries)
+	})
+}
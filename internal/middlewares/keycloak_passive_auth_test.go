@@ -0,0 +1,217 @@
+package middlewares_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gerladeno/chat-service/internal/jwks"
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+const (
+	issuer   = "http://localhost:3010/realms/Bank"
+	audience = "account"
+)
+
+func TestNewKeycloakTokenAuth_Passive(t *testing.T) {
+	suite.Run(t, new(KeycloakPassiveTokenAuthSuite))
+}
+
+type KeycloakPassiveTokenAuthSuite struct {
+	suite.Suite
+	key       *rsa.PrivateKey
+	realmKeys jwksFetcherMock
+	authMdlwr echo.MiddlewareFunc
+	uid       types.UserID
+}
+
+func (s *KeycloakPassiveTokenAuthSuite) SetupTest() {
+	var err error
+	s.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.realmKeys = jwksFetcherMock{"kid-1": &s.key.PublicKey}
+	s.uid = types.NewUserID()
+
+	s.authMdlwr = middlewares.NewOIDCTokenAuth(
+		middlewares.NewKeycloakPassiveTokenVerifier(
+			jwks.NewRemoteSet(s.realmKeys, time.Nanosecond),
+			issuer,
+			audience,
+			requiredResource,
+			requiredRole,
+		),
+		protocol,
+	)
+}
+
+// Positive.
+
+func (s *KeycloakPassiveTokenAuthSuite) TestValidToken() {
+	for name, aud := range map[string]any{
+		"aud string": audience,
+		"aud list":   []string{requiredResource, audience},
+	} {
+		s.Run(name, func() {
+			claims := s.claims()
+			claims["aud"] = aud
+
+			uid, err := s.auth(s.sign("kid-1", s.key, claims))
+			s.Require().NoError(err)
+			s.Equal(s.uid, uid)
+		})
+	}
+}
+
+func (s *KeycloakPassiveTokenAuthSuite) TestValidToken_RotatedKey() {
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.realmKeys["kid-2"] = &newKey.PublicKey
+
+	uid, err := s.auth(s.sign("kid-2", newKey, s.claims()))
+	s.Require().NoError(err)
+	s.Equal(s.uid, uid)
+}
+
+// Negative.
+
+func (s *KeycloakPassiveTokenAuthSuite) TestInvalidToken() {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	cases := []struct {
+		name   string
+		kid    string
+		key    *rsa.PrivateKey
+		modify func(c jwt.MapClaims)
+		errIs  error
+	}{
+		{
+			name:  "signed by another key",
+			kid:   "kid-1",
+			key:   otherKey,
+			errIs: jwks.ErrInvalidSignature,
+		},
+		{
+			name:  "unknown key",
+			kid:   "kid-2",
+			key:   otherKey,
+			errIs: jwks.ErrUnknownKeyID,
+		},
+		{
+			name:   "another issuer",
+			modify: func(c jwt.MapClaims) { c["iss"] = "http://localhost:3010/realms/Other" },
+			errIs:  middlewares.ErrIssuerMismatch,
+		},
+		{
+			name:   "another audience",
+			modify: func(c jwt.MapClaims) { c["aud"] = []string{"other"} },
+			errIs:  middlewares.ErrAudienceMismatch,
+		},
+		{
+			name:   "expired",
+			modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name:   "no expiry",
+			modify: func(c jwt.MapClaims) { delete(c, "exp") },
+			errIs:  middlewares.ErrNoExpiresAt,
+		},
+		{
+			name: "no required role",
+			modify: func(c jwt.MapClaims) {
+				c["resource_access"] = map[string]any{"account": map[string]any{"roles": []string{"view"}}}
+			},
+			errIs: middlewares.ErrNoRequiredRole,
+		},
+	}
+
+	for _, tt := range cases {
+		s.Run(tt.name, func() {
+			kid, key := "kid-1", s.key
+			if tt.key != nil {
+				kid, key = tt.kid, tt.key
+			}
+			claims := s.claims()
+			if tt.modify != nil {
+				tt.modify(claims)
+			}
+
+			_, err := s.auth(s.sign(kid, key, claims))
+			s.Require().Error(err)
+			var httpErr *echo.HTTPError
+			s.Require().ErrorAs(err, &httpErr)
+			s.Equal(http.StatusUnauthorized, httpErr.Code)
+			if tt.errIs != nil {
+				s.ErrorIs(err, tt.errIs)
+			}
+		})
+	}
+}
+
+func (s *KeycloakPassiveTokenAuthSuite) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+		"iss": issuer,
+		"aud": audience,
+		"sub": s.uid.String(),
+		"resource_access": map[string]any{
+			requiredResource: map[string]any{"roles": []string{requiredRole}},
+		},
+	}
+}
+
+func (s *KeycloakPassiveTokenAuthSuite) sign(kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	signed, err := signRS256(kid, key, claims)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *KeycloakPassiveTokenAuthSuite) auth(token string) (types.UserID, error) {
+	req := httptest.NewRequest(http.MethodPost, "/getHistory", nil)
+	req.Header.Add(echo.HeaderAuthorization, "Bearer "+token)
+
+	var uid types.UserID
+	err := s.authMdlwr(func(c echo.Context) error {
+		uid = middlewares.MustUserID(c)
+		return nil
+	})(echo.New().NewContext(req, httptest.NewRecorder()))
+	return uid, err
+}
+
+func signRS256(kid string, key *rsa.PrivateKey, claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	return token.SignedString(key)
+}
+
+// jwksFetcherMock serves the realm JWKS of the keys by their ids.
+type jwksFetcherMock map[string]*rsa.PublicKey
+
+func (m jwksFetcherMock) FetchJWKS(context.Context) ([]byte, error) {
+	keys := ""
+	for kid, k := range m {
+		if keys != "" {
+			keys += ", "
+		}
+		keys += fmt.Sprintf(`{"kty": "RSA", "kid": %q, "use": "sig", "alg": "RS256", "n": %q, "e": %q}`,
+			kid,
+			base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		)
+	}
+	return []byte(`{"keys": [` + keys + `]}`), nil
+}
@@ -1,94 +1,32 @@
 package middlewares
 
-import (
-	"context"
-	"errors"
-	"fmt"
-	"strings"
-
-	"github.com/golang-jwt/jwt"
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
-
-	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
-	"github.com/gerladeno/chat-service/internal/types"
-)
-
-//go:generate mockgen -source=$GOFILE -destination=mocks/introspector_mock.gen.go -package=middlewaresmocks Introspector
-
-const tokenCtxKey = "user-token"
-
-var ErrNoRequiredResourceRole = errors.New("no required resource role")
-
-type Introspector interface {
-	IntrospectToken(ctx context.Context, token string) (*keycloakclient.IntrospectTokenResult, error)
-}
-
-// NewKeycloakTokenAuth returns a middleware that implements "active" authentication:
-// each request is verified by the Keycloak server.
-func NewKeycloakTokenAuth(introspector Introspector, resource, role, protocol string) echo.MiddlewareFunc {
-	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
-		KeyLookup:  "header:Authorization,header:X-Request-ID,header:Sec-WebSocket-Protocol",
-		AuthScheme: "Bearer",
-		Validator: func(tokenStr string, eCtx echo.Context) (bool, error) {
-			tokenStr = strings.TrimPrefix(tokenStr, fmt.Sprintf("%s, ", protocol))
-			token, err := introspector.IntrospectToken(eCtx.Request().Context(), tokenStr)
-			if err != nil {
-				return false, fmt.Errorf("token validation: %w", err)
-			}
-			if !token.Active {
-				return false, nil
-			}
-			parsedClaims := claims{}
-			parsedToken, _ := jwt.ParseWithClaims(tokenStr, &parsedClaims, nil)
-			if err = parsedClaims.Valid(); err != nil {
-				return false, fmt.Errorf("claims validation: %w", err)
-			}
-			roles, ok := parsedClaims.ResourceAccess[resource]
-			if !ok {
-				return false, ErrNoRequiredResourceRole
-			}
-
-			if !stringIsIn(role, roles.Roles) {
-				return false, ErrNoRequiredResourceRole
-			}
-			eCtx.Set(tokenCtxKey, parsedToken)
-			return true, nil
-		},
-	})
+// KeycloakRoleClaim returns the role claim of the Keycloak resource (client) roles.
+func KeycloakRoleClaim(resource string) string {
+	return keycloakRoleClaim(resource).String()
 }
 
-func stringIsIn(s string, slice []string) bool {
-	for i := range slice {
-		if s == slice[i] {
-			return true
-		}
-	}
-	return false
+func keycloakRoleClaim(resource string) claimPath {
+	return claimPath{"resource_access", resource, "roles"}
 }
 
-func MustUserID(eCtx echo.Context) types.UserID {
-	uid, ok := userID(eCtx)
-	if !ok {
-		panic("no user token in request context")
+// NewKeycloakTokenVerifier is the Keycloak preset of the verifier implementing "active" authentication:
+// each token is introspected and the resource roles are required.
+func NewKeycloakTokenVerifier(introspector Introspector, resource, role string) *OIDCTokenVerifier {
+	return &OIDCTokenVerifier{
+		introspector: introspector,
+		roleClaim:    keycloakRoleClaim(resource),
+		role:         role,
 	}
-	return uid
 }
 
-func userID(eCtx echo.Context) (types.UserID, bool) {
-	t := eCtx.Get(tokenCtxKey)
-	if t == nil {
-		return types.UserIDNil, false
-	}
-
-	tt, ok := t.(*jwt.Token)
-	if !ok {
-		return types.UserIDNil, false
-	}
-
-	userIDProvider, ok := tt.Claims.(interface{ UserID() types.UserID })
-	if !ok {
-		return types.UserIDNil, false
+// NewKeycloakPassiveTokenVerifier is the Keycloak preset of the verifier implementing "passive" authentication:
+// the token is verified locally with the realm keys and the resource roles are required.
+func NewKeycloakPassiveTokenVerifier(keys JWKS, issuer, audience, resource, role string) *OIDCTokenVerifier {
+	return &OIDCTokenVerifier{
+		keys:      keys,
+		issuer:    issuer,
+		audience:  audience,
+		roleClaim: keycloakRoleClaim(resource),
+		role:      role,
 	}
-	return userIDProvider.UserID(), true
 }
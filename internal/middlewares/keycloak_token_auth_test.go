@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
@@ -44,7 +45,10 @@ func (s *KeycloakTokenAuthSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 
 	s.introspector = middlewaresmocks.NewMockIntrospector(s.ctrl)
-	s.authMdlwr = middlewares.NewKeycloakTokenAuth(s.introspector, requiredResource, requiredRole, protocol)
+	s.authMdlwr = middlewares.NewOIDCTokenAuth(
+		middlewares.NewKeycloakTokenVerifier(s.introspector, requiredResource, requiredRole),
+		protocol,
+	)
 
 	s.req = httptest.NewRequest(http.MethodPost, "/getHistory",
 		bytes.NewBufferString(`{"pageSize": 100, "cursor": ""}`))
@@ -65,13 +69,35 @@ func (s *KeycloakTokenAuthSuite) TestValidToken_AudString() {
 	s.introspector.EXPECT().IntrospectToken(s.req.Context(), token).Return(&keycloakclient.IntrospectTokenResult{Active: true}, nil)
 
 	var uid types.UserID
+	var exp time.Time
 
 	err := s.authMdlwr(func(c echo.Context) error {
 		uid = middlewares.MustUserID(c)
+		exp, _ = middlewares.TokenExpiresAt(c)
 		return nil
 	})(s.ctx)
 	s.Require().NoError(err)
 	s.Equal("5cb40dc0-a249-4783-a301-9e1f3cf3ea41", uid.String())
+	s.Equal(int64(2667199580), exp.Unix())
+}
+
+func (s *KeycloakTokenAuthSuite) TestValidToken_WebsocketSubprotocol() {
+	const token = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJIR1lJcHN1UXlsZFNJZTB1T0JaeEpuQjBkZlFuTWI5LUlFcmx6NHk5ek9BIn0.eyJleHAiOjI2NjcxOTk1ODAsImlhdCI6MTY2NzE5OTI4MCwiYXV0aF90aW1lIjoxNjY3MTk4OTI4LCJqdGkiOiI5NGQ3ZDBkNS0zZTZmLTQ5NGItYTkzYy1hYjliMDkxMzQ3YmEiLCJpc3MiOiJodHRwOi8vbG9jYWxob3N0OjMwMTAvcmVhbG1zL0JhbmsiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNWNiNDBkYzAtYTI0OS00NzgzLWEzMDEtOWUxZjNjZjNlYTQxIiwidHlwIjoiQmVhcmVyIiwiYXpwIjoiY2hhdC11aS1jbGllbnQiLCJub25jZSI6ImJhMzdmZDVhLThjMzktNDgxNC1hZmNiLTk1MmExOGI3MjY3ZCIsInNlc3Npb25fc3RhdGUiOiJkODZkMTk4ZS1jMWM1LTRlZGQtODM1MC0zNjFlZTU4MTcxZjIiLCJhY3IiOiIwIiwiYWxsb3dlZC1vcmlnaW5zIjpbIiIsIioiXSwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwiZGVmYXVsdC1yb2xlcy1iYW5rIiwidW1hX2F1dGhvcml6YXRpb24iXX0sInJlc291cmNlX2FjY2VzcyI6eyJjaGF0LXVpLWNsaWVudCI6eyJyb2xlcyI6WyJzdXBwb3J0LWNoYXQtY2xpZW50Il19LCJhY2NvdW50Ijp7InJvbGVzIjpbIm1hbmFnZS1hY2NvdW50IiwibWFuYWdlLWFjY291bnQtbGlua3MiLCJ2aWV3LXByb2ZpbGUiXX19LCJzY29wZSI6Im9wZW5pZCBwcm9maWxlIGVtYWlsIiwic2lkIjoiZDg2ZDE5OGUtYzFjNS00ZWRkLTgzNTAtMzYxZWU1ODE3MWYyIiwiZW1haWxfdmVyaWZpZWQiOnRydWUsInByZWZlcnJlZF91c2VybmFtZSI6ImJvbmQwMDciLCJnaXZlbl9uYW1lIjoiIiwiZmFtaWx5X25hbWUiOiIiLCJlbWFpbCI6ImJvbmQwMDdAdWsuY29tIn0.we-dont-check-signature" //nolint:lll
+
+	for _, p := range []string{protocol, protocol + ".msgpack", protocol + ".msgpack, " + protocol} {
+		s.Run(p, func() {
+			req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			req.Header.Add("Sec-WebSocket-Protocol", p+", "+token)
+			s.introspector.EXPECT().IntrospectToken(req.Context(), token).
+				Return(&keycloakclient.IntrospectTokenResult{Active: true}, nil)
+
+			err := s.authMdlwr(func(c echo.Context) error {
+				s.Equal("5cb40dc0-a249-4783-a301-9e1f3cf3ea41", middlewares.MustUserID(c).String())
+				return nil
+			})(echo.New().NewContext(req, httptest.NewRecorder()))
+			s.Require().NoError(err)
+		})
+	}
 }
 
 func (s *KeycloakTokenAuthSuite) TestValidToken_AudList() {
@@ -93,6 +119,29 @@ func (s *KeycloakTokenAuthSuite) TestValidToken_AudList() {
 
 // Negative.
 
+func (s *KeycloakTokenAuthSuite) TestVerifyToken() {
+	const token = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJIR1lJcHN1UXlsZFNJZTB1T0JaeEpuQjBkZlFuTWI5LUlFcmx6NHk5ek9BIn0.eyJleHAiOjI2NjcxOTk1ODAsImlhdCI6MTY2NzE5OTI4MCwiYXV0aF90aW1lIjoxNjY3MTk4OTI4LCJqdGkiOiI5NGQ3ZDBkNS0zZTZmLTQ5NGItYTkzYy1hYjliMDkxMzQ3YmEiLCJpc3MiOiJodHRwOi8vbG9jYWxob3N0OjMwMTAvcmVhbG1zL0JhbmsiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNWNiNDBkYzAtYTI0OS00NzgzLWEzMDEtOWUxZjNjZjNlYTQxIiwidHlwIjoiQmVhcmVyIiwiYXpwIjoiY2hhdC11aS1jbGllbnQiLCJub25jZSI6ImJhMzdmZDVhLThjMzktNDgxNC1hZmNiLTk1MmExOGI3MjY3ZCIsInNlc3Npb25fc3RhdGUiOiJkODZkMTk4ZS1jMWM1LTRlZGQtODM1MC0zNjFlZTU4MTcxZjIiLCJhY3IiOiIwIiwiYWxsb3dlZC1vcmlnaW5zIjpbIiIsIioiXSwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwiZGVmYXVsdC1yb2xlcy1iYW5rIiwidW1hX2F1dGhvcml6YXRpb24iXX0sInJlc291cmNlX2FjY2VzcyI6eyJjaGF0LXVpLWNsaWVudCI6eyJyb2xlcyI6WyJzdXBwb3J0LWNoYXQtY2xpZW50Il19LCJhY2NvdW50Ijp7InJvbGVzIjpbIm1hbmFnZS1hY2NvdW50IiwibWFuYWdlLWFjY291bnQtbGlua3MiLCJ2aWV3LXByb2ZpbGUiXX19LCJzY29wZSI6Im9wZW5pZCBwcm9maWxlIGVtYWlsIiwic2lkIjoiZDg2ZDE5OGUtYzFjNS00ZWRkLTgzNTAtMzYxZWU1ODE3MWYyIiwiZW1haWxfdmVyaWZpZWQiOnRydWUsInByZWZlcnJlZF91c2VybmFtZSI6ImJvbmQwMDciLCJnaXZlbl9uYW1lIjoiIiwiZmFtaWx5X25hbWUiOiIiLCJlbWFpbCI6ImJvbmQwMDdAdWsuY29tIn0.we-dont-check-signature" //nolint:lll
+	verifier := middlewares.NewKeycloakTokenVerifier(s.introspector, requiredResource, requiredRole)
+
+	s.Run("valid", func() {
+		s.introspector.EXPECT().IntrospectToken(gomock.Any(), token).
+			Return(&keycloakclient.IntrospectTokenResult{Active: true}, nil)
+
+		uid, exp, err := verifier.VerifyToken(context.Background(), token)
+		s.Require().NoError(err)
+		s.Equal("5cb40dc0-a249-4783-a301-9e1f3cf3ea41", uid.String())
+		s.Equal(int64(2667199580), exp.Unix())
+	})
+
+	s.Run("inactive", func() {
+		s.introspector.EXPECT().IntrospectToken(gomock.Any(), token).
+			Return(&keycloakclient.IntrospectTokenResult{Active: false}, nil)
+
+		_, _, err := verifier.VerifyToken(context.Background(), token)
+		s.Require().Error(err)
+	})
+}
+
 func (s *KeycloakTokenAuthSuite) TestNoAuthorizationHeader() {
 	const token = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJIR1lJcHN1UXlsZFNJZTB1T0JaeEpuQjBkZlFuTWI5LUlFcmx6NHk5ek9BIn0.eyJleHAiOjI2NjcxOTk1ODAsImlhdCI6MTY2NzE5OTI4MCwiYXV0aF90aW1lIjoxNjY3MTk4OTI4LCJqdGkiOiI5NGQ3ZDBkNS0zZTZmLTQ5NGItYTkzYy1hYjliMDkxMzQ3YmEiLCJpc3MiOiJodHRwOi8vbG9jYWxob3N0OjMwMTAvcmVhbG1zL0JhbmsiLCJhdWQiOlsiY2hhdC11aS1jbGllbnQiLCJhY2NvdW50Il0sInN1YiI6IjVjYjQwZGMwLWEyNDktNDc4My1hMzAxLTllMWYzY2YzZWE0MSIsInR5cCI6IkJlYXJlciIsImF6cCI6ImNoYXQtdWktY2xpZW50Iiwibm9uY2UiOiJiYTM3ZmQ1YS04YzM5LTQ4MTQtYWZjYi05NTJhMThiNzI2N2QiLCJzZXNzaW9uX3N0YXRlIjoiZDg2ZDE5OGUtYzFjNS00ZWRkLTgzNTAtMzYxZWU1ODE3MWYyIiwiYWNyIjoiMCIsImFsbG93ZWQtb3JpZ2lucyI6WyIiLCIqIl0sInJlYWxtX2FjY2VzcyI6eyJyb2xlcyI6WyJvZmZsaW5lX2FjY2VzcyIsImRlZmF1bHQtcm9sZXMtYmFuayIsInVtYV9hdXRob3JpemF0aW9uIl19LCJyZXNvdXJjZV9hY2Nlc3MiOnsiY2hhdC11aS1jbGllbnQiOnsicm9sZXMiOlsic3VwcG9ydC1jaGF0LWNsaWVudCJdfSwiYWNjb3VudCI6eyJyb2xlcyI6WyJtYW5hZ2UtYWNjb3VudCIsIm1hbmFnZS1hY2NvdW50LWxpbmtzIiwidmlldy1wcm9maWxlIl19fSwic2NvcGUiOiJvcGVuaWQgcHJvZmlsZSBlbWFpbCIsInNpZCI6ImQ4NmQxOThlLWMxYzUtNGVkZC04MzUwLTM2MWVlNTgxNzFmMiIsImVtYWlsX3ZlcmlmaWVkIjp0cnVlLCJwcmVmZXJyZWRfdXNlcm5hbWUiOiJib25kMDA3IiwiZ2l2ZW5fbmFtZSI6IiIsImZhbWlseV9uYW1lIjoiIiwiZW1haWwiOiJib25kMDA3QHVrLmNvbSJ9.we-dont-check-signature" //nolint:lll
 	s.req.Header.Add("Authentication", "Bearer "+token)
@@ -221,7 +270,7 @@ func (s *KeycloakTokenAuthSuite) TestNoResourceAccess_NoKey() {
 		return nil
 	})(s.ctx)
 	s.assertHTTPCode(err, http.StatusUnauthorized)
-	s.Require().ErrorIs(err, middlewares.ErrNoAllowedResources)
+	s.Require().ErrorIs(err, middlewares.ErrNoRequiredRole)
 }
 
 func (s *KeycloakTokenAuthSuite) TestNoResourceAccess_EmptyMap() {
@@ -236,7 +285,7 @@ func (s *KeycloakTokenAuthSuite) TestNoResourceAccess_EmptyMap() {
 		return nil
 	})(s.ctx)
 	s.assertHTTPCode(err, http.StatusUnauthorized)
-	s.Require().ErrorIs(err, middlewares.ErrNoAllowedResources)
+	s.Require().ErrorIs(err, middlewares.ErrNoRequiredRole)
 }
 
 func (s *KeycloakTokenAuthSuite) TestNoResourceRole_NoNeededResource() {
@@ -251,7 +300,7 @@ func (s *KeycloakTokenAuthSuite) TestNoResourceRole_NoNeededResource() {
 		return nil
 	})(s.ctx)
 	s.assertHTTPCode(err, http.StatusUnauthorized)
-	s.Require().ErrorIs(err, middlewares.ErrNoRequiredResourceRole)
+	s.Require().ErrorIs(err, middlewares.ErrNoRequiredRole)
 }
 
 func (s *KeycloakTokenAuthSuite) TestNoResourceRole_NoNeededRole() {
@@ -266,7 +315,7 @@ func (s *KeycloakTokenAuthSuite) TestNoResourceRole_NoNeededRole() {
 		return nil
 	})(s.ctx)
 	s.assertHTTPCode(err, http.StatusUnauthorized)
-	s.Require().ErrorIs(err, middlewares.ErrNoRequiredResourceRole)
+	s.Require().ErrorIs(err, middlewares.ErrNoRequiredRole)
 }
 
 func (s *KeycloakTokenAuthSuite) assertHTTPCode(err error, code int) {
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: keycloak_token_auth.go
+// Source: oidc_token_auth.go
 
 // Package middlewaresmocks is a generated GoMock package.
 package middlewaresmocks
@@ -8,7 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	keycloakclient "github.com/gerladeno/chat-service/internal/clients/keycloak"
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,10 +36,10 @@ func (m *MockIntrospector) EXPECT() *MockIntrospectorMockRecorder {
 }
 
 // IntrospectToken mocks base method.
-func (m *MockIntrospector) IntrospectToken(ctx context.Context, token string) (*keycloakclient.IntrospectTokenResult, error) {
+func (m *MockIntrospector) IntrospectToken(ctx context.Context, token string) (*oidcclient.IntrospectTokenResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IntrospectToken", ctx, token)
-	ret0, _ := ret[0].(*keycloakclient.IntrospectTokenResult)
+	ret0, _ := ret[0].(*oidcclient.IntrospectTokenResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -49,3 +49,41 @@ func (mr *MockIntrospectorMockRecorder) IntrospectToken(ctx, token interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockIntrospector)(nil).IntrospectToken), ctx, token)
 }
+
+// MockJWKS is a mock of JWKS interface.
+type MockJWKS struct {
+	ctrl     *gomock.Controller
+	recorder *MockJWKSMockRecorder
+}
+
+// MockJWKSMockRecorder is the mock recorder for MockJWKS.
+type MockJWKSMockRecorder struct {
+	mock *MockJWKS
+}
+
+// NewMockJWKS creates a new mock instance.
+func NewMockJWKS(ctrl *gomock.Controller) *MockJWKS {
+	mock := &MockJWKS{ctrl: ctrl}
+	mock.recorder = &MockJWKSMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJWKS) EXPECT() *MockJWKSMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockJWKS) Verify(ctx context.Context, token string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockJWKSMockRecorder) Verify(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockJWKS)(nil).Verify), ctx, token)
+}
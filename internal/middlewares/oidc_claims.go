@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+var ErrSubjectNotDefined = errors.New(`"sub" is not defined`)
+
+type claims struct {
+	jwt.StandardClaims
+	Audience audience `json:"aud"`
+
+	raw map[string]any // All the claims, the roles are looked up in them.
+}
+
+func (c *claims) UnmarshalJSON(data []byte) error {
+	type plain claims
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.raw)
+}
+
+// Valid returns errors:
+// - from StandardClaims validation;
+// - ErrSubjectNotDefined, if claims doesn't contain `sub` field or subject is zero UUID.
+func (c claims) Valid() error {
+	if err := c.StandardClaims.Valid(); err != nil {
+		return err
+	}
+	if c.UserID().IsZero() {
+		return ErrSubjectNotDefined
+	}
+	return nil
+}
+
+func (c claims) UserID() types.UserID {
+	res, _ := types.Parse[types.UserID](c.Subject)
+	return res
+}
+
+// Expiry returns the zero time if the token never expires.
+func (c claims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// audience is either a string or a list of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("unmarshal aud: %v", err)
+	}
+	*a = list
+	return nil
+}
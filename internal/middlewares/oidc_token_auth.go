@@ -0,0 +1,237 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/introspector_mock.gen.go -package=middlewaresmocks Introspector
+
+const tokenCtxKey = "user-token"
+
+var (
+	ErrNoRequiredRole   = errors.New("no required role")
+	ErrIssuerMismatch   = errors.New("token issuer mismatch")
+	ErrAudienceMismatch = errors.New("token audience mismatch")
+	ErrNoExpiresAt      = errors.New(`"exp" is not defined`)
+	errTokenInactive    = errors.New("token is not active")
+)
+
+type Introspector interface {
+	IntrospectToken(ctx context.Context, token string) (*oidcclient.IntrospectTokenResult, error)
+}
+
+type JWKS interface {
+	// Verify checks the token signature and returns its payload.
+	Verify(ctx context.Context, token string) ([]byte, error)
+}
+
+// NewOIDCTokenAuth returns a middleware authenticating each request with the verifier,
+// see NewOIDCTokenVerifier and the Keycloak presets.
+func NewOIDCTokenAuth(verifier *OIDCTokenVerifier, protocol string) echo.MiddlewareFunc {
+	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		KeyLookup:  "header:Authorization,header:X-Request-ID,header:Sec-WebSocket-Protocol",
+		AuthScheme: "Bearer",
+		Validator: func(tokenStr string, eCtx echo.Context) (bool, error) {
+			token, _, err := verifier.verify(eCtx.Request().Context(), trimSubprotocol(tokenStr, protocol))
+			if errors.Is(err, errTokenInactive) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			eCtx.Set(tokenCtxKey, token)
+			return true, nil
+		},
+	})
+}
+
+//go:generate options-gen -out-filename=oidc_token_auth_options.gen.go -from-struct=OIDCTokenVerifierOptions
+type OIDCTokenVerifierOptions struct {
+	// roleClaim is the JSONPath of the token roles, e.g. "$.roles" or "$.realm_access.roles".
+	roleClaim string `option:"mandatory" validate:"required"`
+	role      string `option:"mandatory" validate:"required"`
+
+	// introspector enables "active" authentication: each token is verified by the provider.
+	introspector Introspector
+	// keys enable "passive" authentication: the token signature, issuer, audience and expiry are verified locally.
+	// The revoked token stays valid until it expires.
+	keys     JWKS
+	issuer   string
+	audience string
+}
+
+// OIDCTokenVerifier verifies the token the same way the middleware does,
+// e.g. when the websocket client re-authenticates.
+type OIDCTokenVerifier struct {
+	introspector Introspector // Nil in the "passive" mode.
+
+	keys     JWKS // Nil in the "active" mode.
+	issuer   string
+	audience string
+
+	roleClaim claimPath
+	role      string
+}
+
+func NewOIDCTokenVerifier(opts OIDCTokenVerifierOptions) (*OIDCTokenVerifier, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	if (opts.introspector == nil) == (opts.keys == nil) {
+		return nil, errors.New("exactly one of introspector and keys is expected")
+	}
+	if opts.keys != nil && (opts.issuer == "" || opts.audience == "") {
+		return nil, errors.New("issuer and audience are required to verify tokens locally")
+	}
+	roleClaim, err := parseClaimPath(opts.roleClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCTokenVerifier{
+		introspector: opts.introspector,
+		keys:         opts.keys,
+		issuer:       opts.issuer,
+		audience:     opts.audience,
+		roleClaim:    roleClaim,
+		role:         opts.role,
+	}, nil
+}
+
+// VerifyToken returns the token subject and expiration time, the latter is zero if the token never expires.
+func (v *OIDCTokenVerifier) VerifyToken(ctx context.Context, tokenStr string) (types.UserID, time.Time, error) {
+	_, c, err := v.verify(ctx, tokenStr)
+	if err != nil {
+		return types.UserIDNil, time.Time{}, err
+	}
+	return c.UserID(), c.Expiry(), nil
+}
+
+func (v *OIDCTokenVerifier) verify(ctx context.Context, tokenStr string) (*jwt.Token, *claims, error) {
+	var introspected *oidcclient.IntrospectTokenResult
+	if v.introspector != nil {
+		token, err := v.introspector.IntrospectToken(ctx, tokenStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("token validation: %w", err)
+		}
+		if !token.Active {
+			return nil, nil, errTokenInactive
+		}
+		introspected = token
+	} else if _, err := v.keys.Verify(ctx, tokenStr); err != nil {
+		return nil, nil, fmt.Errorf("token signature validation: %w", err)
+	}
+
+	parsedClaims := claims{}
+	parsedToken, err := jwt.ParseWithClaims(tokenStr, &parsedClaims, nil)
+	if introspected != nil && isMalformed(err) {
+		// The opaque token, its claims are only known from the introspection.
+		if err := json.Unmarshal(introspected.Claims, &parsedClaims); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal introspected claims: %v", err)
+		}
+		parsedToken = &jwt.Token{Raw: tokenStr, Claims: &parsedClaims, Valid: true}
+	}
+	if err := parsedClaims.Valid(); err != nil {
+		return nil, nil, fmt.Errorf("claims validation: %w", err)
+	}
+	if v.keys != nil {
+		if err := v.verifyLocally(parsedClaims); err != nil {
+			return nil, nil, fmt.Errorf("claims validation: %w", err)
+		}
+	}
+	if !stringIsIn(v.role, v.roleClaim.roles(parsedClaims.raw)) {
+		return nil, nil, fmt.Errorf("%w %q in %v", ErrNoRequiredRole, v.role, v.roleClaim)
+	}
+	return parsedToken, &parsedClaims, nil
+}
+
+// isMalformed reports whether the token is not a JWT at all.
+func isMalformed(err error) bool {
+	var vErr *jwt.ValidationError
+	return errors.As(err, &vErr) && vErr.Errors&jwt.ValidationErrorMalformed != 0
+}
+
+// verifyLocally checks the claims the provider checks on introspection.
+func (v *OIDCTokenVerifier) verifyLocally(c claims) error {
+	if c.ExpiresAt == 0 {
+		return ErrNoExpiresAt
+	}
+	if c.Issuer != v.issuer {
+		return ErrIssuerMismatch
+	}
+	if !stringIsIn(v.audience, c.Audience) {
+		return ErrAudienceMismatch
+	}
+	return nil
+}
+
+// trimSubprotocol cuts the websocket subprotocols the token is sent after, the format suffixes included.
+func trimSubprotocol(value, protocol string) string {
+	if !strings.HasPrefix(value, protocol) {
+		return value
+	}
+	if i := strings.LastIndex(value, ","); i != -1 {
+		return strings.TrimSpace(value[i+1:])
+	}
+	return value
+}
+
+func stringIsIn(s string, slice []string) bool {
+	for i := range slice {
+		if s == slice[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func MustUserID(eCtx echo.Context) types.UserID {
+	uid, ok := userID(eCtx)
+	if !ok {
+		panic("no user token in request context")
+	}
+	return uid
+}
+
+func userID(eCtx echo.Context) (types.UserID, bool) {
+	t := eCtx.Get(tokenCtxKey)
+	if t == nil {
+		return types.UserIDNil, false
+	}
+
+	tt, ok := t.(*jwt.Token)
+	if !ok {
+		return types.UserIDNil, false
+	}
+
+	userIDProvider, ok := tt.Claims.(interface{ UserID() types.UserID })
+	if !ok {
+		return types.UserIDNil, false
+	}
+	return userIDProvider.UserID(), true
+}
+
+// TokenExpiresAt returns the request token expiration time, it is zero if the token never expires.
+func TokenExpiresAt(eCtx echo.Context) (time.Time, bool) {
+	tt, ok := eCtx.Get(tokenCtxKey).(*jwt.Token)
+	if !ok {
+		return time.Time{}, false
+	}
+	expiryProvider, ok := tt.Claims.(interface{ Expiry() time.Time })
+	if !ok {
+		return time.Time{}, false
+	}
+	return expiryProvider.Expiry(), true
+}
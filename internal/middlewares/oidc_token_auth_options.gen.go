@@ -0,0 +1,74 @@
+// Code generated by options-gen. DO NOT EDIT.
+package middlewares
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOIDCTokenVerifierOptionsSetter func(o *OIDCTokenVerifierOptions)
+
+func NewOIDCTokenVerifierOptions(
+	roleClaim string,
+	role string,
+	options ...OptOIDCTokenVerifierOptionsSetter,
+) OIDCTokenVerifierOptions {
+	o := OIDCTokenVerifierOptions{}
+
+	// Setting defaults from field tag (if present)
+
+	o.roleClaim = roleClaim
+	o.role = role
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithIntrospector(opt Introspector) OptOIDCTokenVerifierOptionsSetter {
+	return func(o *OIDCTokenVerifierOptions) {
+		o.introspector = opt
+	}
+}
+
+func WithKeys(opt JWKS) OptOIDCTokenVerifierOptionsSetter {
+	return func(o *OIDCTokenVerifierOptions) {
+		o.keys = opt
+	}
+}
+
+func WithIssuer(opt string) OptOIDCTokenVerifierOptionsSetter {
+	return func(o *OIDCTokenVerifierOptions) {
+		o.issuer = opt
+	}
+}
+
+func WithAudience(opt string) OptOIDCTokenVerifierOptionsSetter {
+	return func(o *OIDCTokenVerifierOptions) {
+		o.audience = opt
+	}
+}
+
+func (o *OIDCTokenVerifierOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("roleClaim", _validate_OIDCTokenVerifierOptions_roleClaim(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("role", _validate_OIDCTokenVerifierOptions_role(o)))
+	return errs.AsError()
+}
+
+func _validate_OIDCTokenVerifierOptions_roleClaim(o *OIDCTokenVerifierOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.roleClaim, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `roleClaim` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_OIDCTokenVerifierOptions_role(o *OIDCTokenVerifierOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.role, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `role` did not pass the test: %w", err)
+	}
+	return nil
+}
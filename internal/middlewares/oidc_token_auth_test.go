@@ -0,0 +1,304 @@
+package middlewares_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	oidcclient "github.com/gerladeno/chat-service/internal/clients/oidc"
+	"github.com/gerladeno/chat-service/internal/jwks"
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	middlewaresmocks "github.com/gerladeno/chat-service/internal/middlewares/mocks"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+const (
+	oidcClientID     = "chat-service"
+	oidcClientSecret = "secret"
+	oidcRole         = "support-chat-client"
+)
+
+func TestNewOIDCTokenAuth(t *testing.T) {
+	suite.Run(t, new(OIDCTokenAuthSuite))
+}
+
+type OIDCTokenAuthSuite struct {
+	suite.Suite
+	key      *rsa.PrivateKey
+	provider *oidcProviderStub
+	client   *oidcclient.Client
+	uid      types.UserID
+}
+
+func (s *OIDCTokenAuthSuite) SetupTest() {
+	var err error
+	s.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.provider = newOIDCProviderStub(jwksFetcherMock{"kid-1": &s.key.PublicKey})
+	s.T().Cleanup(s.provider.Close)
+
+	s.client, err = oidcclient.New(oidcclient.NewOptions(s.provider.URL, oidcClientID, oidcClientSecret, false))
+	s.Require().NoError(err)
+	s.uid = types.NewUserID()
+}
+
+// Positive.
+
+func (s *OIDCTokenAuthSuite) TestPassive_RoleClaims() {
+	cases := []struct {
+		roleClaim string
+		claim     map[string]any
+	}{
+		{
+			roleClaim: "$.roles",
+			claim:     map[string]any{"roles": []string{"viewer", oidcRole}},
+		},
+		{
+			roleClaim: "$.groups",
+			claim:     map[string]any{"groups": oidcRole},
+		},
+		{
+			roleClaim: "$.scope",
+			claim:     map[string]any{"scope": "openid profile " + oidcRole},
+		},
+		{
+			roleClaim: "$.realm_access.roles",
+			claim:     map[string]any{"realm_access": map[string]any{"roles": []string{oidcRole}}},
+		},
+		{
+			roleClaim: "$['https://bank.example/roles']",
+			claim:     map[string]any{"https://bank.example/roles": []string{oidcRole}},
+		},
+		{
+			roleClaim: middlewares.KeycloakRoleClaim("chat-ui-client"),
+			claim: map[string]any{"resource_access": map[string]any{
+				"chat-ui-client": map[string]any{"roles": []string{oidcRole}},
+			}},
+		},
+	}
+
+	for _, tt := range cases {
+		s.Run(tt.roleClaim, func() {
+			auth := s.newAuth(tt.roleClaim, s.passive()...)
+
+			uid, err := auth(s.sign(s.claims(tt.claim)))
+			s.Require().NoError(err)
+			s.Equal(s.uid, uid)
+
+			_, err = auth(s.sign(s.claims(nil)))
+			s.assertUnauthorized(err)
+			s.ErrorIs(err, middlewares.ErrNoRequiredRole)
+		})
+	}
+}
+
+func (s *OIDCTokenAuthSuite) TestActive() {
+	auth := s.newAuth("$.roles", middlewares.WithIntrospector(s.client))
+	token := s.sign(s.claims(map[string]any{"roles": []string{oidcRole}}))
+
+	uid, err := auth(token)
+	s.Require().NoError(err)
+	s.Equal(s.uid, uid)
+
+	s.provider.revoke(token)
+	_, err = auth(token)
+	s.assertUnauthorized(err)
+}
+
+func (s *OIDCTokenAuthSuite) TestActive_OpaqueToken() {
+	auth := s.newAuth("$.roles", middlewares.WithIntrospector(s.client))
+	token := s.provider.issueOpaque(s.claims(map[string]any{"roles": []string{oidcRole}}))
+
+	uid, err := auth(token)
+	s.Require().NoError(err)
+	s.Equal(s.uid, uid)
+
+	_, err = auth(s.provider.issueOpaque(s.claims(nil)))
+	s.assertUnauthorized(err)
+	s.ErrorIs(err, middlewares.ErrNoRequiredRole)
+
+	s.provider.revoke(token)
+	_, err = auth(token)
+	s.assertUnauthorized(err)
+}
+
+// Negative.
+
+func (s *OIDCTokenAuthSuite) TestActive_InvalidClientCredentials() {
+	client, err := oidcclient.New(oidcclient.NewOptions(s.provider.URL, oidcClientID, "wrong", false))
+	s.Require().NoError(err)
+	auth := s.newAuth("$.roles", middlewares.WithIntrospector(client))
+
+	_, err = auth(s.sign(s.claims(map[string]any{"roles": []string{oidcRole}})))
+	s.assertUnauthorized(err)
+}
+
+func (s *OIDCTokenAuthSuite) TestPassive_AnotherIssuer() {
+	auth := s.newAuth("$.roles",
+		middlewares.WithKeys(jwks.NewRemoteSet(s.client, time.Nanosecond)),
+		middlewares.WithIssuer("https://id.bank.example"),
+		middlewares.WithAudience(oidcClientID),
+	)
+
+	_, err := auth(s.sign(s.claims(map[string]any{"roles": []string{oidcRole}})))
+	s.assertUnauthorized(err)
+	s.ErrorIs(err, middlewares.ErrIssuerMismatch)
+}
+
+func (s *OIDCTokenAuthSuite) passive() []middlewares.OptOIDCTokenVerifierOptionsSetter {
+	return []middlewares.OptOIDCTokenVerifierOptionsSetter{
+		middlewares.WithKeys(jwks.NewRemoteSet(s.client, time.Nanosecond)),
+		middlewares.WithIssuer(s.provider.URL),
+		middlewares.WithAudience(oidcClientID),
+	}
+}
+
+func (s *OIDCTokenAuthSuite) newAuth(
+	roleClaim string,
+	opts ...middlewares.OptOIDCTokenVerifierOptionsSetter,
+) func(token string) (types.UserID, error) {
+	verifier, err := middlewares.NewOIDCTokenVerifier(middlewares.NewOIDCTokenVerifierOptions(roleClaim, oidcRole, opts...))
+	s.Require().NoError(err)
+	authMdlwr := middlewares.NewOIDCTokenAuth(verifier, protocol)
+
+	return func(token string) (types.UserID, error) {
+		req := httptest.NewRequest(http.MethodPost, "/getHistory", nil)
+		req.Header.Add(echo.HeaderAuthorization, "Bearer "+token)
+
+		var uid types.UserID
+		err := authMdlwr(func(c echo.Context) error {
+			uid = middlewares.MustUserID(c)
+			return nil
+		})(echo.New().NewContext(req, httptest.NewRecorder()))
+		return uid, err
+	}
+}
+
+func (s *OIDCTokenAuthSuite) claims(extra map[string]any) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+		"iss": s.provider.URL,
+		"aud": oidcClientID,
+		"sub": s.uid.String(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return claims
+}
+
+func (s *OIDCTokenAuthSuite) sign(claims jwt.MapClaims) string {
+	signed, err := signRS256("kid-1", s.key, claims)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *OIDCTokenAuthSuite) assertUnauthorized(err error) {
+	s.Require().Error(err)
+	var httpErr *echo.HTTPError
+	s.Require().ErrorAs(err, &httpErr)
+	s.Equal(http.StatusUnauthorized, httpErr.Code)
+}
+
+func TestNewOIDCTokenVerifier_InvalidOptions(t *testing.T) {
+	introspector := middlewaresmocks.NewMockIntrospector(nil)
+	keys := middlewaresmocks.NewMockJWKS(nil)
+
+	for name, opts := range map[string]middlewares.OIDCTokenVerifierOptions{
+		"no role claim": middlewares.NewOIDCTokenVerifierOptions("", oidcRole, middlewares.WithIntrospector(introspector)),
+		"no role":       middlewares.NewOIDCTokenVerifierOptions("$.roles", "", middlewares.WithIntrospector(introspector)),
+		"no $":          middlewares.NewOIDCTokenVerifierOptions("roles", oidcRole, middlewares.WithIntrospector(introspector)),
+		"empty key":     middlewares.NewOIDCTokenVerifierOptions("$..roles", oidcRole, middlewares.WithIntrospector(introspector)),
+		"unclosed":      middlewares.NewOIDCTokenVerifierOptions("$['roles", oidcRole, middlewares.WithIntrospector(introspector)),
+		"no verifier":   middlewares.NewOIDCTokenVerifierOptions("$.roles", oidcRole),
+		"both verifiers": middlewares.NewOIDCTokenVerifierOptions("$.roles", oidcRole,
+			middlewares.WithIntrospector(introspector),
+			middlewares.WithKeys(keys),
+			middlewares.WithIssuer(issuer),
+			middlewares.WithAudience(audience),
+		),
+		"passive without issuer": middlewares.NewOIDCTokenVerifierOptions("$.roles", oidcRole,
+			middlewares.WithKeys(keys),
+			middlewares.WithAudience(audience),
+		),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := middlewares.NewOIDCTokenVerifier(opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// oidcProviderStub is the OpenID Connect provider serving the discovery, keys and introspection.
+type oidcProviderStub struct {
+	*httptest.Server
+	keys jwksFetcherMock
+
+	mu      sync.Mutex
+	revoked map[string]bool
+	opaque  map[string]jwt.MapClaims
+}
+
+func newOIDCProviderStub(keys jwksFetcherMock) *oidcProviderStub {
+	p := &oidcProviderStub{keys: keys, revoked: make(map[string]bool), opaque: make(map[string]jwt.MapClaims)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 p.URL,
+			"jwks_uri":               p.URL + "/certs",
+			"introspection_endpoint": p.URL + "/introspect",
+		})
+	})
+	mux.HandleFunc("/certs", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := p.keys.FetchJWKS(r.Context())
+		_, _ = w.Write(data)
+	})
+	mux.HandleFunc("/introspect", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != oidcClientID || secret != oidcClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		token := r.PostFormValue("token")
+		p.mu.Lock()
+		resp := map[string]any{"active": !p.revoked[token]}
+		for k, v := range p.opaque[token] {
+			resp[k] = v
+		}
+		p.mu.Unlock()
+		writeJSON(w, resp)
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *oidcProviderStub) revoke(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.revoked[token] = true
+}
+
+// issueOpaque returns the random token, its claims are only returned by the introspection.
+func (p *oidcProviderStub) issueOpaque(claims jwt.MapClaims) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	token := types.NewRequestID().String()
+	p.opaque[token] = claims
+	return token
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
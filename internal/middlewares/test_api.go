@@ -1,6 +1,8 @@
 package middlewares
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 
@@ -16,12 +18,23 @@ func AuthWith(uid types.UserID) echo.MiddlewareFunc {
 	}
 }
 
+// AuthWithExpiry is AuthWith for the token expiring at exp.
+func AuthWithExpiry(uid types.UserID, exp time.Time) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(tokenCtxKey, &jwt.Token{Claims: claimsMock{uid: uid, exp: exp}, Valid: true})
+			return next(c)
+		}
+	}
+}
+
 func SetToken(c echo.Context, uid types.UserID) {
 	c.Set(tokenCtxKey, &jwt.Token{Claims: claimsMock{uid: uid}, Valid: true})
 }
 
 type claimsMock struct {
 	uid types.UserID
+	exp time.Time
 }
 
 func (m claimsMock) Valid() error {
@@ -31,3 +44,7 @@ func (m claimsMock) Valid() error {
 func (m claimsMock) UserID() types.UserID {
 	return m.uid
 }
+
+func (m claimsMock) Expiry() time.Time {
+	return m.exp
+}
@@ -0,0 +1,69 @@
+package msgenvelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// KeyRing holds the AES-GCM keys by their IDs. The new messages are encrypted
+// with the primary key, the rest of the keys are kept to decrypt the older ones.
+type KeyRing struct {
+	primaryID string
+	keys      map[string]cipher.AEAD
+}
+
+// NewKeyRing creates the ring of hex encoded AES keys (16, 24 or 32 bytes).
+func NewKeyRing(primaryID string, hexKeys map[string]string) (*KeyRing, error) {
+	if len(hexKeys) == 0 {
+		return nil, errors.New("no keys")
+	}
+	if _, ok := hexKeys[primaryID]; !ok {
+		return nil, fmt.Errorf("primary key %q is not in the ring", primaryID)
+	}
+
+	keys := make(map[string]cipher.AEAD, len(hexKeys))
+	for id, hexKey := range hexKeys {
+		if id == "" {
+			return nil, errors.New("empty key id")
+		}
+		aead, err := newAEAD(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", id, err)
+		}
+		keys[id] = aead
+	}
+	return &KeyRing{primaryID: primaryID, keys: keys}, nil
+}
+
+// Primary returns the key to encrypt the new messages with.
+func (r *KeyRing) Primary() (string, cipher.AEAD) {
+	return r.primaryID, r.keys[r.primaryID]
+}
+
+// Key returns the key by its ID.
+func (r *KeyRing) Key(id string) (cipher.AEAD, bool) {
+	if r == nil {
+		return nil, false
+	}
+	k, ok := r.keys[id]
+	return k, ok
+}
+
+func newAEAD(hexKey string) (cipher.AEAD, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode hex string: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new gcm: %v", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,113 @@
+// Package msgenvelope describes how the chat messages are packed into broker messages:
+// the envelope headers tell the consumer the format version, whether the value is encrypted
+// and with which key of the ring.
+package msgenvelope
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+)
+
+// Version is the current envelope format version.
+const Version = "1"
+
+const (
+	HeaderVersion       = "envelope-version"
+	HeaderKeyID         = "key-id"
+	HeaderAlgorithm     = "alg"
+	HeaderSchemaVersion = "schema-version"
+)
+
+const (
+	// AlgNone means the value is not encrypted.
+	AlgNone = "none"
+	// AlgAESGCM means the value is nonce||ciphertext sealed with AES-GCM.
+	AlgAESGCM = "AES-GCM"
+)
+
+var (
+	ErrNoEnvelope         = errors.New("message has no envelope headers")
+	ErrUnsupportedVersion = errors.New("unsupported envelope version")
+	ErrUnsupportedAlg     = errors.New("unsupported algorithm")
+	ErrUnknownKey         = errors.New("unknown key id")
+	ErrMalformed          = errors.New("malformed message")
+)
+
+// Header is the envelope of the broker message.
+type Header struct {
+	Version       string
+	KeyID         string
+	Algorithm     string
+	SchemaVersion string
+}
+
+// MessageHeaders returns the header as the broker message headers.
+func (h Header) MessageHeaders() []broker.Header {
+	headers := []broker.Header{
+		{Key: HeaderVersion, Value: []byte(h.Version)},
+		{Key: HeaderAlgorithm, Value: []byte(h.Algorithm)},
+		{Key: HeaderSchemaVersion, Value: []byte(h.SchemaVersion)},
+	}
+	if h.KeyID != "" {
+		headers = append(headers, broker.Header{Key: HeaderKeyID, Value: []byte(h.KeyID)})
+	}
+	return headers
+}
+
+// ParseHeader reads the envelope from the broker message headers, the other headers are ignored.
+func ParseHeader(headers []broker.Header) (Header, error) {
+	var h Header
+	for _, kh := range headers {
+		switch kh.Key {
+		case HeaderVersion:
+			h.Version = string(kh.Value)
+		case HeaderKeyID:
+			h.KeyID = string(kh.Value)
+		case HeaderAlgorithm:
+			h.Algorithm = string(kh.Value)
+		case HeaderSchemaVersion:
+			h.SchemaVersion = string(kh.Value)
+		}
+	}
+
+	if h.Version == "" {
+		return Header{}, ErrNoEnvelope
+	}
+	if h.Version != Version {
+		return Header{}, fmt.Errorf("%w: %q", ErrUnsupportedVersion, h.Version)
+	}
+	return h, nil
+}
+
+// Decrypt returns the plain value of the broker message opening it with the key from the ring.
+// The ring may be nil if only plain messages are expected.
+func Decrypt(ring *KeyRing, msg broker.Message) ([]byte, Header, error) {
+	h, err := ParseHeader(msg.Headers)
+	if err != nil {
+		return nil, Header{}, err
+	}
+
+	switch h.Algorithm {
+	case AlgNone:
+		return msg.Value, h, nil
+	case AlgAESGCM:
+	default:
+		return nil, Header{}, fmt.Errorf("%w: %q", ErrUnsupportedAlg, h.Algorithm)
+	}
+
+	aead, ok := ring.Key(h.KeyID)
+	if !ok {
+		return nil, Header{}, fmt.Errorf("%w: %q", ErrUnknownKey, h.KeyID)
+	}
+	ns := aead.NonceSize()
+	if len(msg.Value) < ns {
+		return nil, Header{}, ErrMalformed
+	}
+	data, err := aead.Open(nil, msg.Value[:ns], msg.Value[ns:], nil)
+	if err != nil {
+		return nil, Header{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return data, h, nil
+}
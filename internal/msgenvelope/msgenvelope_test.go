@@ -0,0 +1,123 @@
+package msgenvelope_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
+)
+
+const testKey = "24432646294A404E635266546A576E5A"
+
+func TestDecrypt(t *testing.T) {
+	ring, err := msgenvelope.NewKeyRing("k1", map[string]string{"k1": testKey})
+	require.NoError(t, err)
+
+	keyID, aead := ring.Primary()
+	require.Equal(t, "k1", keyID)
+	nonce := make([]byte, aead.NonceSize())
+	sealed := aead.Seal(nonce, nonce, []byte("hello"), nil)
+
+	header := func(version, keyID, alg string) []broker.Header {
+		return msgenvelope.Header{Version: version, KeyID: keyID, Algorithm: alg, SchemaVersion: "1"}.MessageHeaders()
+	}
+
+	cases := []struct {
+		name    string
+		msg     broker.Message
+		want    string
+		wantErr error
+	}{
+		{
+			name: "plain",
+			msg:  broker.Message{Value: []byte("hello"), Headers: header("1", "", msgenvelope.AlgNone)},
+			want: "hello",
+		},
+		{
+			name: "encrypted",
+			msg:  broker.Message{Value: sealed, Headers: header("1", "k1", msgenvelope.AlgAESGCM)},
+			want: "hello",
+		},
+		{
+			name:    "no envelope",
+			msg:     broker.Message{Value: sealed},
+			wantErr: msgenvelope.ErrNoEnvelope,
+		},
+		{
+			name:    "unsupported version",
+			msg:     broker.Message{Value: sealed, Headers: header("2", "k1", msgenvelope.AlgAESGCM)},
+			wantErr: msgenvelope.ErrUnsupportedVersion,
+		},
+		{
+			name:    "unsupported algorithm",
+			msg:     broker.Message{Value: sealed, Headers: header("1", "k1", "ChaCha20")},
+			wantErr: msgenvelope.ErrUnsupportedAlg,
+		},
+		{
+			name:    "unknown key",
+			msg:     broker.Message{Value: sealed, Headers: header("1", "k2", msgenvelope.AlgAESGCM)},
+			wantErr: msgenvelope.ErrUnknownKey,
+		},
+		{
+			name:    "tampered value",
+			msg:     broker.Message{Value: append(sealed[:len(sealed):len(sealed)], 0), Headers: header("1", "k1", msgenvelope.AlgAESGCM)},
+			wantErr: msgenvelope.ErrMalformed,
+		},
+		{
+			name:    "too short value",
+			msg:     broker.Message{Value: []byte{1, 2}, Headers: header("1", "k1", msgenvelope.AlgAESGCM)},
+			wantErr: msgenvelope.ErrMalformed,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := msgenvelope.Decrypt(ring, tt.msg)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestDecrypt_NilRing(t *testing.T) {
+	msg := broker.Message{
+		Value: []byte("hello"),
+		Headers: msgenvelope.Header{
+			Version:   msgenvelope.Version,
+			KeyID:     "k1",
+			Algorithm: msgenvelope.AlgAESGCM,
+		}.MessageHeaders(),
+	}
+	_, _, err := msgenvelope.Decrypt(nil, msg)
+	require.ErrorIs(t, err, msgenvelope.ErrUnknownKey)
+}
+
+func TestNewKeyRing(t *testing.T) {
+	cases := []struct {
+		name    string
+		primary string
+		keys    map[string]string
+	}{
+		{name: "no keys", primary: "k1"},
+		{name: "primary is missing", primary: "k2", keys: map[string]string{"k1": testKey}},
+		{name: "empty key id", primary: "k1", keys: map[string]string{"k1": testKey, "": testKey}},
+		{name: "not hex", primary: "k1", keys: map[string]string{"k1": "not hex"}},
+		{name: "invalid key size", primary: "k1", keys: map[string]string{"k1": "2443"}},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := msgenvelope.NewKeyRing(tt.primary, tt.keys)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package msgenvelope
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+)
+
+// The headers correlating the broker message with the request and the trace it was produced in.
+const (
+	HeaderRequestID   = "request_id"
+	HeaderContentType = "content-type"
+	HeaderProducedAt  = "produced-at"
+
+	// HeaderTraceParent is the W3C trace context for the consumers outside of Sentry.
+	HeaderTraceParent = "traceparent"
+	HeaderSentryTrace = sentry.SentryTraceHeader
+	HeaderBaggage     = sentry.SentryBaggageHeader
+)
+
+const ContentTypeJSON = "application/json"
+
+// TraceHeaders returns the trace context of the span as the broker message headers.
+func TraceHeaders(span *sentry.Span) []broker.Header {
+	flags := "00"
+	if span.Sampled.Bool() {
+		flags = "01"
+	}
+	headers := []broker.Header{
+		{Key: HeaderTraceParent, Value: []byte("00-" + span.TraceID.String() + "-" + span.SpanID.String() + "-" + flags)},
+		{Key: HeaderSentryTrace, Value: []byte(span.ToSentryTrace())},
+	}
+	if baggage := span.ToBaggage(); baggage != "" {
+		headers = append(headers, broker.Header{Key: HeaderBaggage, Value: []byte(baggage)})
+	}
+	return headers
+}
+
+// ContinueTrace returns the span option continuing the trace the message was produced in.
+// The Sentry headers are preferred, the W3C traceparent is used if there are none.
+// The span starts a new trace if the message has no trace context.
+func ContinueTrace(msg broker.Message) sentry.SpanOption {
+	trace, ok := msg.Header(HeaderSentryTrace)
+	if !ok {
+		traceParent, _ := msg.Header(HeaderTraceParent)
+		trace = sentryTraceFromTraceParent(traceParent)
+	}
+	baggage, _ := msg.Header(HeaderBaggage)
+	return sentry.ContinueFromHeaders(trace, baggage)
+}
+
+// sentryTraceFromTraceParent converts "version-traceid-spanid-flags" to "traceid-spanid-sampled".
+func sentryTraceFromTraceParent(traceParent string) string {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return ""
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return ""
+	}
+	sampled := "0"
+	if flags&1 == 1 {
+		sampled = "1"
+	}
+	return parts[1] + "-" + parts[2] + "-" + sampled
+}
@@ -0,0 +1,58 @@
+package msgenvelope_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
+)
+
+func TestContinueTrace(t *testing.T) {
+	producer := sentry.StartSpan(context.Background(), "queue.publish")
+	defer producer.Finish()
+	headers := msgenvelope.TraceHeaders(producer)
+
+	withoutSentry := make([]broker.Header, 0, len(headers))
+	for _, h := range headers {
+		if h.Key == msgenvelope.HeaderTraceParent {
+			withoutSentry = append(withoutSentry, h)
+		}
+	}
+	require.Len(t, withoutSentry, 1)
+
+	cases := []struct {
+		name    string
+		headers []broker.Header
+	}{
+		{name: "sentry", headers: headers},
+		{name: "w3c", headers: withoutSentry},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			span := sentry.StartSpan(context.Background(), "afc.verdict",
+				msgenvelope.ContinueTrace(broker.Message{Headers: tt.headers}))
+			defer span.Finish()
+
+			assert.Equal(t, producer.TraceID, span.TraceID)
+			assert.Equal(t, producer.SpanID, span.ParentSpanID)
+		})
+	}
+
+	t.Run("no trace", func(t *testing.T) {
+		span := sentry.StartSpan(context.Background(), "afc.verdict",
+			msgenvelope.ContinueTrace(broker.Message{Headers: []broker.Header{
+				{Key: msgenvelope.HeaderTraceParent, Value: []byte("garbage")},
+			}}))
+		defer span.Finish()
+
+		assert.NotEqual(t, producer.TraceID, span.TraceID)
+		assert.Equal(t, sentry.SpanID{}, span.ParentSpanID)
+	})
+}
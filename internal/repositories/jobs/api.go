@@ -2,6 +2,7 @@ package jobsrepo
 
 import (
 	"context"
+	stdsql "database/sql"
 	"errors"
 	"fmt"
 	"time"
@@ -9,25 +10,67 @@ import (
 	"entgo.io/ent/dialect/sql"
 
 	"github.com/gerladeno/chat-service/internal/store"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
+	"github.com/gerladeno/chat-service/internal/store/failedjob"
 	"github.com/gerladeno/chat-service/internal/store/job"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
 	"github.com/gerladeno/chat-service/internal/types"
 )
 
-var ErrNoJobs = errors.New("no jobs found")
+var (
+	ErrNoJobs            = errors.New("no jobs found")
+	ErrJobIsDuplicate    = errors.New("job with the same dedup key is pending or recently completed")
+	ErrFailedJobNotFound = errors.New("failed job not found")
+)
 
 type Job struct {
 	ID       types.JobID
 	Name     string
 	Payload  string
+	DedupKey string
 	Attempts int
 }
 
-func (r *Repo) FindAndReserveJob(ctx context.Context, until time.Time) (Job, error) {
+type FailedJob struct {
+	ID        types.FailedJobID
+	Name      string
+	Payload   string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// PendingStat describes the jobs with the same name that are waiting in the queue.
+type PendingStat struct {
+	Name            string    `json:"name"`
+	Count           int       `json:"count"`
+	OldestCreatedAt time.Time `json:"min"`
+}
+
+// FindAndReserveJob reserves an available job. If names are given, only a job with one of them is reserved.
+func (r *Repo) FindAndReserveJob(ctx context.Context, until time.Time, names ...string) (Job, error) {
+	if len(names) == 0 {
+		return r.findAndReserveJob(ctx, until)
+	}
+	return r.findAndReserveJob(ctx, until, job.NameIn(names...))
+}
+
+// FindAndReserveJobExcept reserves an available job with a name other than the given ones.
+func (r *Repo) FindAndReserveJobExcept(ctx context.Context, until time.Time, names ...string) (Job, error) {
+	if len(names) == 0 {
+		return r.findAndReserveJob(ctx, until)
+	}
+	return r.findAndReserveJob(ctx, until, job.NameNotIn(names...))
+}
+
+func (r *Repo) findAndReserveJob(ctx context.Context, until time.Time, ps ...predicate.Job) (Job, error) {
 	var result Job
 	err := r.db.RunInTx(ctx, func(ctx context.Context) error {
 		foundJob, err := r.db.Job(ctx).Query().Where(job.And(
-			job.AvailableAtLT(time.Now()),
-			job.ReservedUntilLT(time.Now()),
+			append(ps,
+				job.AvailableAtLT(time.Now()),
+				job.ReservedUntilLT(time.Now()),
+			)...,
 		)).ForUpdate(sql.WithLockAction(sql.SkipLocked)).First(ctx)
 		switch {
 		case store.IsNotFound(err):
@@ -43,6 +86,7 @@ func (r *Repo) FindAndReserveJob(ctx context.Context, until time.Time) (Job, err
 			ID:       foundJob.ID,
 			Name:     foundJob.Name,
 			Payload:  foundJob.Payload,
+			DedupKey: foundJob.DedupKey,
 			Attempts: foundJob.Attempts,
 		}
 		return nil
@@ -50,6 +94,15 @@ func (r *Repo) FindAndReserveJob(ctx context.Context, until time.Time) (Job, err
 	return result, err
 }
 
+// ReleaseJob cancels the reservation of the job and doesn't count the attempt.
+func (r *Repo) ReleaseJob(ctx context.Context, jobID types.JobID) error {
+	err := r.db.Job(ctx).UpdateOneID(jobID).SetReservedUntil(time.Now()).AddAttempts(-1).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("releasing a job: %v", err)
+	}
+	return nil
+}
+
 func (r *Repo) CreateJob(ctx context.Context, name, payload string, availableAt time.Time) (types.JobID, error) {
 	newJob, err := r.db.Job(ctx).Create().
 		SetName(name).
@@ -62,6 +115,42 @@ func (r *Repo) CreateJob(ctx context.Context, name, payload string, availableAt
 	return newJob.ID, nil
 }
 
+// CreateUniqueJob creates a job with the dedup key. It returns ErrJobIsDuplicate
+// if a job with the same key is pending or was completed before the stored expiration time.
+func (r *Repo) CreateUniqueJob(
+	ctx context.Context,
+	name, payload, dedupKey string,
+	availableAt time.Time,
+) (types.JobID, error) {
+	// No own transaction: the caller's one is reused, ON CONFLICT DO NOTHING doesn't abort it.
+	completed, err := r.db.CompletedJob(ctx).Query().Where(
+		completedjob.DedupKey(dedupKey),
+		completedjob.ExpiresAtGT(time.Now()),
+	).Exist(ctx)
+	if err != nil {
+		return types.JobIDNil, fmt.Errorf("checking completed jobs: %v", err)
+	}
+	if completed {
+		return types.JobIDNil, ErrJobIsDuplicate
+	}
+
+	jobID, err := r.db.Job(ctx).Create().
+		SetName(name).
+		SetPayload(payload).
+		SetDedupKey(dedupKey).
+		SetAvailableAt(availableAt).
+		OnConflictColumns(job.FieldDedupKey).
+		DoNothing().
+		ID(ctx)
+	switch {
+	case errors.Is(err, stdsql.ErrNoRows):
+		return types.JobIDNil, ErrJobIsDuplicate
+	case err != nil:
+		return types.JobIDNil, fmt.Errorf("creating a unique job: %v", err)
+	}
+	return jobID, nil
+}
+
 func (r *Repo) CreateFailedJob(ctx context.Context, name, payload, reason string) error {
 	_, err := r.db.FailedJob(ctx).Create().
 		SetName(name).
@@ -81,3 +170,150 @@ func (r *Repo) DeleteJob(ctx context.Context, jobID types.JobID) error {
 	}
 	return nil
 }
+
+// CreateCompletedJob remembers the dedup key of the handled job until expiresAt.
+func (r *Repo) CreateCompletedJob(ctx context.Context, name, dedupKey string, expiresAt time.Time) error {
+	err := r.db.CompletedJob(ctx).Create().
+		SetName(name).
+		SetDedupKey(dedupKey).
+		SetExpiresAt(expiresAt).
+		OnConflictColumns(completedjob.FieldDedupKey).
+		UpdateExpiresAt().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("creating a completed job: %v", err)
+	}
+	return nil
+}
+
+// DeleteExpiredCompletedJobs forgets the dedup keys expired before the given time.
+func (r *Repo) DeleteExpiredCompletedJobs(ctx context.Context, before time.Time) (int, error) {
+	n, err := r.db.CompletedJob(ctx).Delete().Where(completedjob.ExpiresAtLT(before)).Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired completed jobs: %v", err)
+	}
+	return n, nil
+}
+
+// TryAdvisoryXactLock tries to acquire the transaction-level PostgreSQL advisory lock.
+// It must be called inside a transaction, the lock is released on its end.
+func (r *Repo) TryAdvisoryXactLock(ctx context.Context, key int64) (bool, error) {
+	rows, err := r.db.Query(ctx, "SELECT pg_try_advisory_xact_lock($1)", key)
+	if err != nil {
+		return false, fmt.Errorf("trying advisory lock: %v", err)
+	}
+	defer rows.Close()
+
+	locked, err := sql.ScanBool(rows)
+	if err != nil {
+		return false, fmt.Errorf("scanning advisory lock result: %v", err)
+	}
+	return locked, nil
+}
+
+// LockScheduleNextRun returns the next occurrence of the recurring job shared by the replicas
+// and locks it until the end of the transaction. The new schedule starts with firstRunAt.
+func (r *Repo) LockScheduleNextRun(ctx context.Context, name string, firstRunAt time.Time) (time.Time, error) {
+	err := r.db.JobSchedule(ctx).Create().
+		SetName(name).
+		SetNextRunAt(firstRunAt).
+		OnConflictColumns(jobschedule.FieldName).
+		DoNothing().
+		Exec(ctx)
+	if err != nil && !errors.Is(err, stdsql.ErrNoRows) {
+		return time.Time{}, fmt.Errorf("creating a job schedule: %v", err)
+	}
+
+	sch, err := r.db.JobSchedule(ctx).Query().
+		Where(jobschedule.Name(name)).
+		ForUpdate().
+		Only(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("locking a job schedule: %v", err)
+	}
+	return sch.NextRunAt, nil
+}
+
+// SetScheduleNextRun moves the recurring job to the next occurrence.
+func (r *Repo) SetScheduleNextRun(ctx context.Context, name string, nextRunAt time.Time) error {
+	err := r.db.JobSchedule(ctx).Update().
+		Where(jobschedule.Name(name)).
+		SetNextRunAt(nextRunAt).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("updating a job schedule: %v", err)
+	}
+	return nil
+}
+
+// GetPendingStats returns the number of pending jobs and the creation time of the oldest one per job name.
+// The jobs reserved by the workers are in flight, so they are not counted.
+func (r *Repo) GetPendingStats(ctx context.Context) ([]PendingStat, error) {
+	var stats []PendingStat
+	err := r.db.Job(ctx).Query().
+		Where(job.ReservedUntilLT(time.Now())).
+		GroupBy(job.FieldName).
+		Aggregate(store.Count(), store.Min(job.FieldCreatedAt)).
+		Scan(ctx, &stats)
+	if err != nil {
+		return nil, fmt.Errorf("getting pending jobs stats: %v", err)
+	}
+	return stats, nil
+}
+
+// GetFailedJobs returns the last limit jobs from the DLQ, the newest first.
+func (r *Repo) GetFailedJobs(ctx context.Context, limit int) ([]FailedJob, error) {
+	jobs, err := r.db.FailedJob(ctx).Query().
+		Order(store.Desc(failedjob.FieldCreatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting failed jobs: %v", err)
+	}
+	result := make([]FailedJob, 0, len(jobs))
+	for _, j := range jobs {
+		result = append(result, adaptFailedJob(j))
+	}
+	return result, nil
+}
+
+func (r *Repo) GetFailedJob(ctx context.Context, id types.FailedJobID) (FailedJob, error) {
+	j, err := r.db.FailedJob(ctx).Get(ctx, id)
+	switch {
+	case store.IsNotFound(err):
+		return FailedJob{}, ErrFailedJobNotFound
+	case err != nil:
+		return FailedJob{}, fmt.Errorf("getting a failed job: %v", err)
+	}
+	return adaptFailedJob(j), nil
+}
+
+// ReplayFailedJob moves the job from the DLQ back to the queue with the attempts reset.
+func (r *Repo) ReplayFailedJob(ctx context.Context, id types.FailedJobID) (types.JobID, error) {
+	var jobID types.JobID
+	err := r.db.RunInTx(ctx, func(ctx context.Context) error {
+		failed, err := r.GetFailedJob(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.db.FailedJob(ctx).DeleteOneID(id).Exec(ctx); err != nil {
+			return fmt.Errorf("deleting a failed job: %v", err)
+		}
+		jobID, err = r.CreateJob(ctx, failed.Name, failed.Payload, time.Now())
+		return err
+	})
+	if err != nil {
+		return types.JobIDNil, err
+	}
+	return jobID, nil
+}
+
+func adaptFailedJob(j *store.FailedJob) FailedJob {
+	return FailedJob{
+		ID:        j.ID,
+		Name:      j.Name,
+		Payload:   j.Payload,
+		Reason:    j.Reason,
+		CreatedAt: j.CreatedAt,
+	}
+}
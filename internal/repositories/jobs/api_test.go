@@ -3,7 +3,9 @@
 package jobsrepo_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 
 	jobsrepo "github.com/gerladeno/chat-service/internal/repositories/jobs"
 	"github.com/gerladeno/chat-service/internal/store"
+	"github.com/gerladeno/chat-service/internal/store/job"
 	"github.com/gerladeno/chat-service/internal/testingh"
 	"github.com/gerladeno/chat-service/internal/types"
 )
@@ -48,6 +51,8 @@ func (s *JobsRepoSuite) SetupTest() {
 	s.DBSuite.SetupTest()
 	s.Database.Job(s.Ctx).Delete().ExecX(s.Ctx)
 	s.Database.FailedJob(s.Ctx).Delete().ExecX(s.Ctx)
+	s.Database.CompletedJob(s.Ctx).Delete().ExecX(s.Ctx)
+	s.Database.JobSchedule(s.Ctx).Delete().ExecX(s.Ctx)
 }
 
 func (s *JobsRepoSuite) Test_FindAndReserveJob_JobFoundAndReserved() {
@@ -190,6 +195,125 @@ func (s *JobsRepoSuite) Test_CreateJob_Multiple() {
 	s.Equal(jobs, count)
 }
 
+func (s *JobsRepoSuite) Test_CreateUniqueJob() {
+	const dedupKey = "job_name:1"
+
+	// Action.
+	jobID, err := s.repo.CreateUniqueJob(s.Ctx, name, payload, dedupKey, availableAt)
+
+	// Assert.
+	s.Require().NoError(err)
+	s.Require().NotEmpty(jobID)
+
+	job, err := s.repo.FindAndReserveJob(s.Ctx, reservationTime())
+	s.Require().NoError(err)
+	s.Equal(jobID, job.ID)
+	s.Equal(dedupKey, job.DedupKey)
+
+	s.Run("pending duplicate", func() {
+		_, err := s.repo.CreateUniqueJob(s.Ctx, name, payload, dedupKey, availableAt)
+		s.Require().ErrorIs(err, jobsrepo.ErrJobIsDuplicate)
+	})
+
+	s.Run("completed duplicate", func() {
+		s.Require().NoError(s.repo.DeleteJob(s.Ctx, jobID))
+		s.Require().NoError(s.repo.CreateCompletedJob(s.Ctx, name, dedupKey, time.Now().Add(time.Minute)))
+
+		_, err := s.repo.CreateUniqueJob(s.Ctx, name, payload, dedupKey, availableAt)
+		s.Require().ErrorIs(err, jobsrepo.ErrJobIsDuplicate)
+	})
+
+	s.Run("expired completed job doesn't prevent creation", func() {
+		s.Require().NoError(s.repo.CreateCompletedJob(s.Ctx, name, dedupKey, time.Now().Add(-time.Minute)))
+
+		n, err := s.repo.DeleteExpiredCompletedJobs(s.Ctx, time.Now())
+		s.Require().NoError(err)
+		s.Equal(1, n)
+
+		jobID, err := s.repo.CreateUniqueJob(s.Ctx, name, payload, dedupKey, availableAt)
+		s.Require().NoError(err)
+		s.NotEmpty(jobID)
+	})
+}
+
+func (s *JobsRepoSuite) Test_CreateUniqueJob_InOuterTx() {
+	const dedupKey = "job_name:1"
+
+	// Action.
+	err := s.Database.RunInTx(s.Ctx, func(ctx context.Context) error {
+		if _, err := s.repo.CreateJob(ctx, "outer_before", payload, availableAt); err != nil {
+			return err
+		}
+		if _, err := s.repo.CreateUniqueJob(ctx, name, payload, dedupKey, availableAt); err != nil {
+			return err
+		}
+		if _, err := s.repo.CreateUniqueJob(ctx, name, payload, dedupKey, availableAt); !errors.Is(err, jobsrepo.ErrJobIsDuplicate) {
+			return fmt.Errorf("duplicate expected, got %v", err)
+		}
+		_, err := s.repo.CreateJob(ctx, "outer_after", payload, availableAt)
+		return err
+	})
+
+	// Assert.
+	s.Require().NoError(err)
+	names, err := s.Database.Job(s.Ctx).Query().Select(job.FieldName).Strings(s.Ctx)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"outer_before", name, "outer_after"}, names)
+}
+
+func (s *JobsRepoSuite) Test_TryAdvisoryXactLock_HeldUntilOuterTxEnds() {
+	const lockKey = 42
+
+	tryLock := func() bool {
+		var locked bool
+		s.Require().NoError(s.Database.RunInTx(s.Ctx, func(ctx context.Context) error {
+			var err error
+			locked, err = s.repo.TryAdvisoryXactLock(ctx, lockKey)
+			return err
+		}))
+		return locked
+	}
+
+	// Action.
+	err := s.Database.RunInTx(s.Ctx, func(ctx context.Context) error {
+		locked, err := s.repo.TryAdvisoryXactLock(ctx, lockKey)
+		if err != nil || !locked {
+			return fmt.Errorf("lock expected: %v", err)
+		}
+		if _, err := s.repo.CreateUniqueJob(ctx, name, payload, "job_name:1", availableAt); err != nil {
+			return err
+		}
+
+		// Assert.
+		s.False(tryLock(), "the lock is released before the transaction ends")
+		return nil
+	})
+	s.Require().NoError(err)
+	s.True(tryLock())
+}
+
+func (s *JobsRepoSuite) Test_LockScheduleNextRun() {
+	first := time.Now().Truncate(time.Second)
+	next := first.Add(time.Minute)
+
+	lock := func(firstRunAt time.Time) time.Time {
+		var runAt time.Time
+		s.Require().NoError(s.Database.RunInTx(s.Ctx, func(ctx context.Context) error {
+			var err error
+			runAt, err = s.repo.LockScheduleNextRun(ctx, name, firstRunAt)
+			return err
+		}))
+		return runAt
+	}
+
+	// Action & assert.
+	s.True(first.Equal(lock(first)), "the new schedule starts with the given occurrence")
+	s.True(first.Equal(lock(next)), "the existing schedule is kept")
+
+	s.Require().NoError(s.repo.SetScheduleNextRun(s.Ctx, name, next))
+	s.True(next.Equal(lock(first)))
+}
+
 func (s *JobsRepoSuite) Test_CreateFailedJob() {
 	err := s.repo.CreateFailedJob(s.Ctx, name, payload, reason)
 
@@ -222,6 +346,78 @@ func (s *JobsRepoSuite) Test_CreateFailedJob_Multiple() {
 	s.Equal(fJobs, count)
 }
 
+func (s *JobsRepoSuite) Test_GetPendingStats() {
+	// Arrange.
+	const otherName = "other_job_name"
+	for i := 0; i < 3; i++ {
+		_, err := s.repo.CreateJob(s.Ctx, name, payload, availableAt)
+		s.Require().NoError(err)
+	}
+	_, err := s.repo.CreateJob(s.Ctx, otherName, payload, availableAt)
+	s.Require().NoError(err)
+	oldest, err := s.Database.Job(s.Ctx).Query().Where(job.Name(name)).Order(store.Asc(job.FieldCreatedAt)).First(s.Ctx)
+	s.Require().NoError(err)
+
+	// The reserved job is in flight, not pending.
+	reservedID, err := s.repo.CreateJob(s.Ctx, name, payload, availableAt)
+	s.Require().NoError(err)
+	s.Require().NoError(s.Database.Job(s.Ctx).UpdateOneID(reservedID).SetReservedUntil(reservationTime()).Exec(s.Ctx))
+
+	// Action.
+	stats, err := s.repo.GetPendingStats(s.Ctx)
+
+	// Assert.
+	s.Require().NoError(err)
+	s.Require().Len(stats, 2)
+	byName := map[string]jobsrepo.PendingStat{stats[0].Name: stats[0], stats[1].Name: stats[1]}
+	s.Equal(3, byName[name].Count)
+	s.True(oldest.CreatedAt.Equal(byName[name].OldestCreatedAt))
+	s.Equal(1, byName[otherName].Count)
+}
+
+func (s *JobsRepoSuite) Test_GetFailedJobs() {
+	// Arrange.
+	const fJobs = 3
+	for i := 0; i < fJobs; i++ {
+		s.Require().NoError(s.repo.CreateFailedJob(s.Ctx, name, payload, reason))
+	}
+
+	// Action.
+	jobs, err := s.repo.GetFailedJobs(s.Ctx, fJobs-1)
+
+	// Assert.
+	s.Require().NoError(err)
+	s.Require().Len(jobs, fJobs-1)
+	s.False(jobs[0].CreatedAt.Before(jobs[1].CreatedAt))
+	s.Equal(name, jobs[0].Name)
+	s.Equal(payload, jobs[0].Payload)
+	s.Equal(reason, jobs[0].Reason)
+}
+
+func (s *JobsRepoSuite) Test_ReplayFailedJob() {
+	// Arrange.
+	s.Require().NoError(s.repo.CreateFailedJob(s.Ctx, name, payload, reason))
+	fJob, err := s.Database.FailedJob(s.Ctx).Query().Only(s.Ctx)
+	s.Require().NoError(err)
+
+	// Action.
+	jobID, err := s.repo.ReplayFailedJob(s.Ctx, fJob.ID)
+
+	// Assert.
+	s.Require().NoError(err)
+	j, err := s.Database.Job(s.Ctx).Get(s.Ctx, jobID)
+	s.Require().NoError(err)
+	s.Equal(name, j.Name)
+	s.Equal(payload, j.Payload)
+	s.Equal(0, j.Attempts)
+	s.Equal(0, s.Database.FailedJob(s.Ctx).Query().CountX(s.Ctx))
+
+	_, err = s.repo.GetFailedJob(s.Ctx, fJob.ID)
+	s.ErrorIs(err, jobsrepo.ErrFailedJobNotFound)
+	_, err = s.repo.ReplayFailedJob(s.Ctx, fJob.ID)
+	s.ErrorIs(err, jobsrepo.ErrFailedJobNotFound)
+}
+
 func (s *JobsRepoSuite) Test_DeleteJob() {
 	// Arrange.
 	jobExpected, err := s.Database.Job(s.Ctx).Create().
@@ -6,11 +6,63 @@ import (
 	"time"
 
 	"github.com/gerladeno/chat-service/internal/store"
+	"github.com/gerladeno/chat-service/internal/store/message"
 	"github.com/gerladeno/chat-service/internal/types"
 )
 
-func (r *Repo) MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID) error {
-	_, err := r.db.Message(ctx).UpdateOneID(msgID).SetCheckedAt(time.Now()).SetIsVisibleForManager(true).Save(ctx)
+type VerdictStatus string
+
+const (
+	VerdictStatusOK         VerdictStatus = "ok"
+	VerdictStatusSuspicious VerdictStatus = "suspicious"
+)
+
+const (
+	// VerdictSourceAFC is the external antifraud service.
+	VerdictSourceAFC = "afc"
+	// VerdictSourceLocal is the in-process fallback check used if AFC doesn't respond in time.
+	VerdictSourceLocal = "local"
+)
+
+// Verdict is the antifraud check result applied to the message.
+type Verdict struct {
+	Status    VerdictStatus
+	Source    string
+	At        time.Time // When the verdict was issued.
+	CheckedAt time.Time // When the verdict was applied.
+}
+
+// GetVerdictForUpdate returns the applied verdict or nil if the message wasn't checked yet.
+// Inside a transaction the message is locked until its end.
+func (r *Repo) GetVerdictForUpdate(ctx context.Context, msgID types.MessageID) (*Verdict, error) {
+	msg, err := r.db.Message(ctx).Query().Where(message.ID(msgID)).ForUpdate().Only(ctx)
+	switch {
+	case store.IsNotFound(err):
+		return nil, ErrMsgNotFound
+	case err != nil:
+		return nil, fmt.Errorf("get msg verdict: %v", err)
+	}
+	if msg.CheckedAt.IsZero() {
+		return nil, nil
+	}
+	return &Verdict{
+		Status:    VerdictStatus(msg.VerdictStatus),
+		Source:    msg.VerdictSource,
+		At:        msg.VerdictAt,
+		CheckedAt: msg.CheckedAt,
+	}, nil
+}
+
+// MarkAsVisibleForManager applies the ok verdict issued by source at the given time.
+func (r *Repo) MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID, source string, at time.Time) error {
+	_, err := r.db.Message(ctx).UpdateOneID(msgID).
+		SetCheckedAt(time.Now()).
+		SetVerdictStatus(message.VerdictStatusOk).
+		SetVerdictSource(source).
+		SetVerdictAt(at).
+		SetIsVisibleForManager(true).
+		SetIsBlocked(false).
+		Save(ctx)
 	switch {
 	case store.IsNotFound(err):
 		return ErrMsgNotFound
@@ -20,8 +72,16 @@ func (r *Repo) MarkAsVisibleForManager(ctx context.Context, msgID types.MessageI
 	return nil
 }
 
-func (r *Repo) BlockMessage(ctx context.Context, msgID types.MessageID) error {
-	_, err := r.db.Message(ctx).UpdateOneID(msgID).SetCheckedAt(time.Now()).SetIsBlocked(true).Save(ctx)
+// BlockMessage applies the suspicious verdict issued by source at the given time.
+func (r *Repo) BlockMessage(ctx context.Context, msgID types.MessageID, source string, at time.Time) error {
+	_, err := r.db.Message(ctx).UpdateOneID(msgID).
+		SetCheckedAt(time.Now()).
+		SetVerdictStatus(message.VerdictStatusSuspicious).
+		SetVerdictSource(source).
+		SetVerdictAt(at).
+		SetIsBlocked(true).
+		SetIsVisibleForManager(false).
+		Save(ctx)
 	switch {
 	case store.IsNotFound(err):
 		return ErrMsgNotFound
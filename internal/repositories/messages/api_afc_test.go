@@ -4,6 +4,7 @@ package messagesrepo_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -35,8 +36,10 @@ func (s *MsgRepoAntiFraudAPISuite) TestMarkAsVisibleForManager() {
 	// Arrange.
 	msgID := s.createMessage()
 
+	at := time.Now().Add(-time.Minute)
+
 	// Action.
-	err := s.repo.MarkAsVisibleForManager(s.Ctx, msgID)
+	err := s.repo.MarkAsVisibleForManager(s.Ctx, msgID, "afc", at)
 	s.Require().NoError(err)
 
 	// Assert.
@@ -46,14 +49,22 @@ func (s *MsgRepoAntiFraudAPISuite) TestMarkAsVisibleForManager() {
 	s.False(msg.CheckedAt.IsZero())
 	s.True(msg.IsVisibleForClient)
 	s.True(msg.IsVisibleForManager)
+
+	verdict, err := s.repo.GetVerdictForUpdate(s.Ctx, msgID)
+	s.Require().NoError(err)
+	s.Equal(messagesrepo.VerdictStatusOK, verdict.Status)
+	s.Equal("afc", verdict.Source)
+	s.WithinDuration(at, verdict.At, time.Millisecond)
 }
 
 func (s *MsgRepoAntiFraudAPISuite) TestBlockMessage() {
 	// Arrange.
 	msgID := s.createMessage()
 
+	at := time.Now().Add(-time.Minute)
+
 	// Action.
-	err := s.repo.BlockMessage(s.Ctx, msgID)
+	err := s.repo.BlockMessage(s.Ctx, msgID, "afc", at)
 	s.Require().NoError(err)
 
 	// Assert.
@@ -63,6 +74,53 @@ func (s *MsgRepoAntiFraudAPISuite) TestBlockMessage() {
 	s.False(msg.CheckedAt.IsZero())
 	s.True(msg.IsVisibleForClient)
 	s.False(msg.IsVisibleForManager)
+
+	verdict, err := s.repo.GetVerdictForUpdate(s.Ctx, msgID)
+	s.Require().NoError(err)
+	s.Equal(messagesrepo.VerdictStatusSuspicious, verdict.Status)
+	s.Equal("afc", verdict.Source)
+	s.WithinDuration(at, verdict.At, time.Millisecond)
+}
+
+func (s *MsgRepoAntiFraudAPISuite) TestRedactBody() {
+	// Arrange.
+	msgID := s.createMessage()
+
+	_, err := s.repo.GetSealedBody(s.Ctx, msgID)
+	s.Require().ErrorIs(err, messagesrepo.ErrBodyNotSealed)
+
+	// Action.
+	err = s.repo.RedactBody(s.Ctx, msgID, "4276 **** **** 1234", []byte("sealed"))
+	s.Require().NoError(err)
+
+	// Assert.
+	msg := s.Database.Message(s.Ctx).GetX(s.Ctx, msgID)
+	s.Equal("4276 **** **** 1234", msg.Body)
+
+	sealed, err := s.repo.GetSealedBody(s.Ctx, msgID)
+	s.Require().NoError(err)
+	s.Equal([]byte("sealed"), sealed)
+}
+
+func (s *MsgRepoAntiFraudAPISuite) TestRedactBody_MessageNotFound() {
+	err := s.repo.RedactBody(s.Ctx, types.NewMessageID(), "****", []byte("sealed"))
+	s.Require().ErrorIs(err, messagesrepo.ErrMsgNotFound)
+
+	_, err = s.repo.GetSealedBody(s.Ctx, types.NewMessageID())
+	s.Require().ErrorIs(err, messagesrepo.ErrMsgNotFound)
+}
+
+func (s *MsgRepoAntiFraudAPISuite) TestGetVerdictForUpdate() {
+	s.Run("not checked", func() {
+		verdict, err := s.repo.GetVerdictForUpdate(s.Ctx, s.createMessage())
+		s.Require().NoError(err)
+		s.Nil(verdict)
+	})
+
+	s.Run("not found", func() {
+		_, err := s.repo.GetVerdictForUpdate(s.Ctx, types.NewMessageID())
+		s.Require().ErrorIs(err, messagesrepo.ErrMsgNotFound)
+	})
 }
 
 func (s *MsgRepoAntiFraudAPISuite) createMessage() types.MessageID {
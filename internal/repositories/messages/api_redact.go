@@ -0,0 +1,43 @@
+package messagesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gerladeno/chat-service/internal/store"
+	"github.com/gerladeno/chat-service/internal/store/message"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+var ErrBodyNotSealed = errors.New("message body is not sealed")
+
+// RedactBody replaces the message body with the redacted one and keeps the original sealed for auditors.
+func (r *Repo) RedactBody(ctx context.Context, msgID types.MessageID, body string, sealedOriginal []byte) error {
+	_, err := r.db.Message(ctx).UpdateOneID(msgID).
+		SetBody(body).
+		SetSealedBody(sealedOriginal).
+		Save(ctx)
+	switch {
+	case store.IsNotFound(err):
+		return ErrMsgNotFound
+	case err != nil:
+		return fmt.Errorf("redact msg body: %v", err)
+	}
+	return nil
+}
+
+// GetSealedBody returns the original body of the redacted message.
+func (r *Repo) GetSealedBody(ctx context.Context, msgID types.MessageID) ([]byte, error) {
+	msg, err := r.db.Message(ctx).Query().Where(message.ID(msgID)).Select(message.FieldSealedBody).Only(ctx)
+	switch {
+	case store.IsNotFound(err):
+		return nil, ErrMsgNotFound
+	case err != nil:
+		return nil, fmt.Errorf("get msg sealed body: %v", err)
+	}
+	if len(msg.SealedBody) == 0 {
+		return nil, ErrBodyNotSealed
+	}
+	return msg.SealedBody, nil
+}
@@ -7,10 +7,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/gerladeno/chat-service/internal/eventspb"
 	clientevents "github.com/gerladeno/chat-service/internal/server-client/events"
 	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
 	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
 )
 
 func TestAdapter_Adapt(t *testing.T) {
@@ -69,3 +73,60 @@ func TestAdapter_Adapt(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapter_ProtoEvent(t *testing.T) {
+	authorID := types.MustParse[types.UserID]("3a2c5d0c-bc31-11ed-9f22-461e464ebed8")
+	cases := []struct {
+		name string
+		ev   eventstream.Event
+		exp  *eventspb.Event
+	}{
+		{
+			name: "blocked message",
+			ev: eventstream.NewMessageBlockedEvent(
+				types.MustParse[types.EventID]("d0ffbd36-bc30-11ed-8286-461e464ebed8"),
+				types.MustParse[types.RequestID]("cee5f290-bc30-11ed-b7fe-461e464ebed8"),
+				types.MustParse[types.MessageID]("cb36a888-bc30-11ed-b843-461e464ebed8"),
+			),
+			exp: &eventspb.Event{Event: &eventspb.Event_MessageBlockedEvent{MessageBlockedEvent: &eventspb.MessageBlockedEvent{
+				EventId:   "d0ffbd36-bc30-11ed-8286-461e464ebed8",
+				RequestId: "cee5f290-bc30-11ed-b7fe-461e464ebed8",
+				MessageId: "cb36a888-bc30-11ed-b843-461e464ebed8",
+			}}},
+		},
+
+		{
+			name: "client message",
+			ev: eventstream.NewNewMessageEvent(
+				types.MustParse[types.EventID]("d0ffbd36-bc30-11ed-8286-461e464ebed8"),
+				types.MustParse[types.RequestID]("cee5f290-bc30-11ed-b7fe-461e464ebed8"),
+				types.MustParse[types.ChatID]("31b4dc06-bc31-11ed-93cc-461e464ebed8"),
+				types.MustParse[types.MessageID]("cb36a888-bc30-11ed-b843-461e464ebed8"),
+				authorID,
+				time.Unix(1, 1).UTC(),
+				"Hello",
+				false,
+			),
+			exp: &eventspb.Event{Event: &eventspb.Event_NewMessageEvent{NewMessageEvent: &eventspb.NewMessageEvent{
+				EventId:   "d0ffbd36-bc30-11ed-8286-461e464ebed8",
+				RequestId: "cee5f290-bc30-11ed-b7fe-461e464ebed8",
+				MessageId: "cb36a888-bc30-11ed-b843-461e464ebed8",
+				AuthorId:  proto.String(authorID.String()),
+				Body:      "Hello",
+				CreatedAt: timestamppb.New(time.Unix(1, 1)),
+			}}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			adapted, err := clientevents.Adapter{}.Adapt(tt.ev)
+			require.NoError(t, err)
+
+			pe, ok := adapted.(websocketstream.ProtoEvent)
+			require.True(t, ok)
+			got := pe.ProtoEvent()
+			assert.True(t, proto.Equal(tt.exp, got), "got %v", got)
+		})
+	}
+}
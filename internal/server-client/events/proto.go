@@ -0,0 +1,46 @@
+package clientevents
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gerladeno/chat-service/internal/eventspb"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+var (
+	_ websocketstream.ProtoEvent = NewMessageEvent{}
+	_ websocketstream.ProtoEvent = MessageId{}
+)
+
+func (e NewMessageEvent) ProtoEvent() *eventspb.Event {
+	ev := &eventspb.NewMessageEvent{
+		EventId:   e.EventId.String(),
+		RequestId: e.RequestId.String(),
+		MessageId: e.MessageId.String(),
+		Body:      e.Body,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		IsService: e.IsService,
+	}
+	if e.AuthorId != nil {
+		ev.AuthorId = proto.String(e.AuthorId.String())
+	}
+	return &eventspb.Event{Event: &eventspb.Event_NewMessageEvent{NewMessageEvent: ev}}
+}
+
+// ProtoEvent distinguishes MessageSentEvent and MessageBlockedEvent by the event type, they are the same type.
+func (e MessageId) ProtoEvent() *eventspb.Event {
+	if e.EventType == eventstream.TypeMessageEventBlocked {
+		return &eventspb.Event{Event: &eventspb.Event_MessageBlockedEvent{MessageBlockedEvent: &eventspb.MessageBlockedEvent{
+			EventId:   e.EventId.String(),
+			RequestId: e.RequestId.String(),
+			MessageId: e.MessageId.String(),
+		}}}
+	}
+	return &eventspb.Event{Event: &eventspb.Event_MessageSentEvent{MessageSentEvent: &eventspb.MessageSentEvent{
+		EventId:   e.EventId.String(),
+		RequestId: e.RequestId.String(),
+		MessageId: e.MessageId.String(),
+	}}}
+}
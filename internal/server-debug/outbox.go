@@ -0,0 +1,134 @@
+package serverdebug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/gerladeno/chat-service/internal/services/outbox"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+const outboxPageFailedJobsLimit = 50
+
+type outboxInspector interface {
+	Stats(ctx context.Context) ([]outbox.JobStats, error)
+	FailedJobs(ctx context.Context, limit int) ([]outbox.FailedJob, error)
+	FailedJob(ctx context.Context, id types.FailedJobID) (outbox.FailedJob, error)
+	ReplayFailedJob(ctx context.Context, id types.FailedJobID) (types.JobID, error)
+}
+
+var outboxPageTmpl = template.Must(template.New("outbox").Parse(`<html>
+	<title>Outbox</title>
+<body>
+	<h2>Jobs</h2>
+	<table border="1" cellpadding="4">
+		<tr>
+			<th>Name</th><th>Pending</th><th>Oldest pending age</th>
+			<th>Executions</th><th>Failures</th><th>DLQ moves</th><th>Mean latency</th>
+{{range $b := .Buckets}}
+			<th>&le; {{$b}}</th>
+{{end}}
+		</tr>
+{{range $st := .Stats}}
+		<tr>
+			<td>{{$st.Name}}</td><td>{{$st.Pending}}</td><td>{{$st.OldestPendingAge}}</td>
+			<td>{{$st.Executions}}</td><td>{{$st.Failures}}</td><td>{{$st.DLQMoves}}</td><td>{{$st.Latency.Mean}}</td>
+	{{range $n := $st.Latency.Buckets}}
+			<td>{{$n}}</td>
+	{{end}}
+		</tr>
+{{end}}
+	</table>
+	<p>Executions, failures and latencies are counted by this replica since its start.</p>
+
+	<h2>DLQ (last {{.Limit}})</h2>
+	<table border="1" cellpadding="4">
+		<tr><th>ID</th><th>Name</th><th>Reason</th><th>Created at</th><th>Payload</th><th></th></tr>
+{{range $j := .FailedJobs}}
+		<tr>
+			<td><a href="/debug/outbox/dlq/{{$j.ID}}">{{$j.ID}}</a></td>
+			<td>{{$j.Name}}</td><td>{{$j.Reason}}</td><td>{{$j.CreatedAt}}</td>
+			<td><code>{{$j.Payload}}</code></td>
+			<td><button onclick="replay('{{$j.ID}}')">Replay</button></td>
+		</tr>
+{{end}}
+	</table>
+
+	<script>
+		function replay(id) {
+			const req = new XMLHttpRequest();
+			req.open('POST', '/debug/outbox/dlq/'+id+'/replay', false);
+			req.onload = function() { window.location.reload(); };
+			req.send();
+		};
+	</script>
+</body>
+</html>
+`))
+
+func (s *Server) OutboxPage(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	stats, err := s.outbox.Stats(ctx)
+	if err != nil {
+		return fmt.Errorf("getting outbox stats: %v", err)
+	}
+	failedJobs, err := s.outbox.FailedJobs(ctx, outboxPageFailedJobsLimit)
+	if err != nil {
+		return fmt.Errorf("getting outbox failed jobs: %v", err)
+	}
+
+	return outboxPageTmpl.Execute(eCtx.Response(), struct {
+		Stats      []outbox.JobStats
+		Buckets    []time.Duration
+		FailedJobs []outbox.FailedJob
+		Limit      int
+	}{
+		Stats:      stats,
+		Buckets:    outbox.LatencyBuckets,
+		FailedJobs: failedJobs,
+		Limit:      outboxPageFailedJobsLimit,
+	})
+}
+
+func (s *Server) OutboxFailedJob(eCtx echo.Context) error {
+	id, err := types.Parse[types.FailedJobID](eCtx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid failed job id: %v", err))
+	}
+	job, err := s.outbox.FailedJob(eCtx.Request().Context(), id)
+	if errors.Is(err, outbox.ErrFailedJobNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "failed job not found")
+	}
+	if err != nil {
+		return fmt.Errorf("getting outbox failed job: %v", err)
+	}
+	if err = eCtx.JSON(http.StatusOK, job); err != nil {
+		return fmt.Errorf("sending failed job: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) OutboxReplayFailedJob(eCtx echo.Context) error {
+	id, err := types.Parse[types.FailedJobID](eCtx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid failed job id: %v", err))
+	}
+	jobID, err := s.outbox.ReplayFailedJob(eCtx.Request().Context(), id)
+	if errors.Is(err, outbox.ErrFailedJobNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "failed job not found")
+	}
+	if err != nil {
+		return fmt.Errorf("replaying outbox failed job: %v", err)
+	}
+	if err = eCtx.JSON(http.StatusOK, map[string]types.JobID{"jobId": jobID}); err != nil {
+		return fmt.Errorf("sending replayed job id: %w", err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -25,10 +26,13 @@ const (
 
 //go:generate options-gen -out-filename=server_options.gen.go -from-struct=Options
 type Options struct {
-	addr                string      `option:"mandatory" validate:"required,hostname_port"`
-	v1ClientSwagger     *openapi3.T `option:"mandatory" validate:"required"`
-	v1ManagerSwagger    *openapi3.T `option:"mandatory" validate:"required"`
-	clientEventsSwagger *openapi3.T `option:"mandatory" validate:"required"`
+	addr                string          `option:"mandatory" validate:"required,hostname_port"`
+	v1ClientSwagger     *openapi3.T     `option:"mandatory" validate:"required"`
+	v1ManagerSwagger    *openapi3.T     `option:"mandatory" validate:"required"`
+	clientEventsSwagger *openapi3.T     `option:"mandatory" validate:"required"`
+	outbox              outboxInspector `option:"mandatory" validate:"required"`
+	// wsRegistries are the websocket connections by the server name.
+	wsRegistries map[string]WSRegistry `option:"mandatory" validate:"required"`
 }
 
 type Server struct {
@@ -37,6 +41,8 @@ type Server struct {
 	clientSwagger       *openapi3.T
 	managerSwagger      *openapi3.T
 	clientEventsSwagger *openapi3.T
+	outbox              outboxInspector
+	wsRegistries        map[string]WSRegistry
 }
 
 func New(opts Options) (*Server, error) {
@@ -58,6 +64,8 @@ func New(opts Options) (*Server, error) {
 		clientSwagger:       opts.v1ClientSwagger,
 		managerSwagger:      opts.v1ManagerSwagger,
 		clientEventsSwagger: opts.clientEventsSwagger,
+		outbox:              opts.outbox,
+		wsRegistries:        opts.wsRegistries,
 	}
 	index := newIndexPage()
 	e.GET("/version", s.Version)
@@ -75,6 +83,16 @@ func New(opts Options) (*Server, error) {
 	e.GET("/schema/clientEvents", s.SchemaClientEvents)
 	index.addPage("/schema/clientEvents", "Get client events OpenAPI specification")
 
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+	index.addPage("/metrics", "Prometheus metrics")
+	e.GET("/debug/outbox", s.OutboxPage)
+	e.GET("/debug/outbox/dlq/:id", s.OutboxFailedJob)
+	e.POST("/debug/outbox/dlq/:id/replay", s.OutboxReplayFailedJob)
+	index.addPage("/debug/outbox", "Outbox jobs stats and DLQ")
+	e.GET("/debug/ws", s.WSPage)
+	e.POST("/debug/ws/users/:id/disconnect", s.WSDisconnectUser)
+	index.addPage("/debug/ws", "Websocket connections")
+
 	e.GET("/", index.handler)
 	return s, nil
 }
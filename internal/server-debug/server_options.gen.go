@@ -16,6 +16,8 @@ func NewOptions(
 	v1ClientSwagger *openapi3.T,
 	v1ManagerSwagger *openapi3.T,
 	clientEventsSwagger *openapi3.T,
+	outbox outboxInspector,
+	wsRegistries map[string]WSRegistry,
 	options ...OptOptionsSetter,
 ) Options {
 	o := Options{}
@@ -26,6 +28,8 @@ func NewOptions(
 	o.v1ClientSwagger = v1ClientSwagger
 	o.v1ManagerSwagger = v1ManagerSwagger
 	o.clientEventsSwagger = clientEventsSwagger
+	o.outbox = outbox
+	o.wsRegistries = wsRegistries
 
 	for _, opt := range options {
 		opt(&o)
@@ -39,6 +43,8 @@ func (o *Options) Validate() error {
 	errs.Add(errors461e464ebed9.NewValidationError("v1ClientSwagger", _validate_Options_v1ClientSwagger(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("v1ManagerSwagger", _validate_Options_v1ManagerSwagger(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("clientEventsSwagger", _validate_Options_clientEventsSwagger(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("outbox", _validate_Options_outbox(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("wsRegistries", _validate_Options_wsRegistries(o)))
 	return errs.AsError()
 }
 
@@ -69,3 +75,17 @@ func _validate_Options_clientEventsSwagger(o *Options) error {
 	}
 	return nil
 }
+
+func _validate_Options_outbox(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.outbox, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `outbox` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_wsRegistries(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.wsRegistries, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `wsRegistries` did not pass the test: %w", err)
+	}
+	return nil
+}
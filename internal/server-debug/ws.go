@@ -0,0 +1,81 @@
+package serverdebug
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+type WSRegistry interface {
+	Connections() []websocketstream.ConnInfo
+	Disconnect(userID types.UserID) int
+}
+
+var wsPageTmpl = template.Must(template.New("ws").Parse(`<html>
+	<title>Websockets</title>
+<body>
+{{range $srv := .}}
+	<h2>{{$srv.Name}} ({{len $srv.Conns}})</h2>
+	<table border="1" cellpadding="4">
+		<tr><th>User</th><th>Remote address</th><th>Subprotocol</th><th>Connected at</th><th>Age</th><th>Bytes sent</th><th></th></tr>
+	{{range $c := $srv.Conns}}
+		<tr>
+			<td>{{$c.UserID}}</td><td>{{$c.RemoteAddr}}</td><td>{{$c.Subprotocol}}</td>
+			<td>{{$c.ConnectedAt}}</td><td>{{$c.Age}}</td><td>{{$c.BytesSent}}</td>
+			<td><button onclick="disconnect('{{$c.UserID}}')">Disconnect user</button></td>
+		</tr>
+	{{end}}
+	</table>
+{{end}}
+
+	<script>
+		function disconnect(id) {
+			const req = new XMLHttpRequest();
+			req.open('POST', '/debug/ws/users/'+id+'/disconnect', false);
+			req.onload = function() { window.location.reload(); };
+			req.send();
+		};
+	</script>
+</body>
+</html>
+`))
+
+func (s *Server) WSPage(eCtx echo.Context) error {
+	type server struct {
+		Name  string
+		Conns []websocketstream.ConnInfo
+	}
+	servers := make([]server, 0, len(s.wsRegistries))
+	for name, r := range s.wsRegistries {
+		servers = append(servers, server{Name: name, Conns: r.Connections()})
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+
+	return wsPageTmpl.Execute(eCtx.Response(), servers)
+}
+
+// WSDisconnectUser closes all the user websockets, e.g. when the user session is revoked.
+func (s *Server) WSDisconnectUser(eCtx echo.Context) error {
+	userID, err := types.Parse[types.UserID](eCtx.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid user id: %v", err))
+	}
+
+	var disconnected int
+	for _, r := range s.wsRegistries {
+		disconnected += r.Disconnect(userID)
+	}
+	s.lg.Info("user websockets disconnected", zap.Stringer("user_id", userID), zap.Int("count", disconnected))
+
+	if err = eCtx.JSON(http.StatusOK, map[string]int{"disconnected": disconnected}); err != nil {
+		return fmt.Errorf("sending disconnected count: %w", err)
+	}
+	return nil
+}
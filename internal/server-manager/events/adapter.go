@@ -0,0 +1,41 @@
+package managerevents
+
+import (
+	"errors"
+
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+var _ websocketstream.EventAdapter = Adapter{}
+
+var ErrUnsupportedEventType = errors.New("unsupported event type")
+
+// Redactor masks the sensitive data in the message body, e.g. bloodhound.Searcher.
+type Redactor interface {
+	Redact(msg string) string
+}
+
+// Adapter passes the events to the managers as they are in the stream, except the new messages bodies
+// that are masked by Redactor if it is set.
+type Adapter struct {
+	Redactor Redactor
+}
+
+func (a Adapter) Adapt(ev eventstream.Event) (any, error) {
+	switch v := ev.(type) {
+	case *eventstream.NewMessageEvent:
+		if a.Redactor == nil {
+			return NewMessageEvent{v}, nil
+		}
+		// The event is shared between the subscribers, so the copy is redacted.
+		redacted := *v
+		redacted.MessageBody = a.Redactor.Redact(v.MessageBody)
+		return NewMessageEvent{&redacted}, nil
+	case *eventstream.MessageSentEvent:
+		return MessageSentEvent{v}, nil
+	case *eventstream.MessageBlockedEvent:
+		return MessageBlockedEvent{v}, nil
+	}
+	return nil, ErrUnsupportedEventType
+}
@@ -0,0 +1,90 @@
+package managerevents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managerevents "github.com/gerladeno/chat-service/internal/server-manager/events"
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+func TestAdapter_Adapt(t *testing.T) {
+	newMessage := func(body string) eventstream.Event {
+		return eventstream.NewNewMessageEvent(
+			types.MustParse[types.EventID]("d0ffbd36-bc30-11ed-8286-461e464ebed8"),
+			types.MustParse[types.RequestID]("cee5f290-bc30-11ed-b7fe-461e464ebed8"),
+			types.MustParse[types.ChatID]("31b4dc06-bc31-11ed-93cc-461e464ebed8"),
+			types.MustParse[types.MessageID]("cb36a888-bc30-11ed-b843-461e464ebed8"),
+			types.MustParse[types.UserID]("3a2c5d0c-bc31-11ed-9f22-461e464ebed8"),
+			time.Unix(1, 1).UTC(),
+			body,
+			false,
+		)
+	}
+
+	t.Run("redacted", func(t *testing.T) {
+		ev := newMessage("my card is 4276 1234 5678 1235")
+
+		adapted, err := managerevents.Adapter{Redactor: bloodhound.NewSearcher()}.Adapt(ev)
+		require.NoError(t, err)
+
+		msg, ok := adapted.(managerevents.NewMessageEvent)
+		require.True(t, ok)
+		assert.Equal(t, "my card is 4276 **** **** 1235", msg.MessageBody)
+		assert.Equal(t, "my card is 4276 1234 5678 1235", ev.(*eventstream.NewMessageEvent).MessageBody,
+			"the shared event must not be modified")
+	})
+
+	t.Run("without redactor", func(t *testing.T) {
+		ev := newMessage("my card is 4276 1234 5678 1235")
+
+		adapted, err := managerevents.Adapter{}.Adapt(ev)
+		require.NoError(t, err)
+		assert.Equal(t, managerevents.NewMessageEvent{NewMessageEvent: ev.(*eventstream.NewMessageEvent)}, adapted)
+	})
+
+	t.Run("other events", func(t *testing.T) {
+		ev := eventstream.NewMessageSentEvent(
+			types.MustParse[types.EventID]("d0ffbd36-bc30-11ed-8286-461e464ebed8"),
+			types.MustParse[types.RequestID]("cee5f290-bc30-11ed-b7fe-461e464ebed8"),
+			types.MustParse[types.MessageID]("cb36a888-bc30-11ed-b843-461e464ebed8"),
+		)
+
+		adapted, err := managerevents.Adapter{Redactor: bloodhound.NewSearcher()}.Adapt(ev)
+		require.NoError(t, err)
+		assert.Equal(t, managerevents.MessageSentEvent{MessageSentEvent: ev.(*eventstream.MessageSentEvent)}, adapted)
+	})
+}
+
+func TestNewMessageEvent_ProtoEvent(t *testing.T) {
+	ev := eventstream.NewNewMessageEvent(
+		types.MustParse[types.EventID]("d0ffbd36-bc30-11ed-8286-461e464ebed8"),
+		types.MustParse[types.RequestID]("cee5f290-bc30-11ed-b7fe-461e464ebed8"),
+		types.MustParse[types.ChatID]("31b4dc06-bc31-11ed-93cc-461e464ebed8"),
+		types.MustParse[types.MessageID]("cb36a888-bc30-11ed-b843-461e464ebed8"),
+		types.UserIDNil,
+		time.Unix(1, 1).UTC(),
+		"Manager will coming soon",
+		true,
+	)
+
+	adapted, err := managerevents.Adapter{}.Adapt(ev)
+	require.NoError(t, err)
+	pe, ok := adapted.(websocketstream.ProtoEvent)
+	require.True(t, ok)
+
+	msg := pe.ProtoEvent().GetNewMessageEvent()
+	require.NotNil(t, msg)
+	assert.Equal(t, "d0ffbd36-bc30-11ed-8286-461e464ebed8", msg.GetEventId())
+	assert.Equal(t, "31b4dc06-bc31-11ed-93cc-461e464ebed8", msg.GetChatId())
+	assert.Equal(t, "Manager will coming soon", msg.GetBody())
+	assert.Equal(t, time.Unix(1, 1).UTC(), msg.GetCreatedAt().AsTime())
+	assert.True(t, msg.GetIsService())
+	assert.Nil(t, msg.AuthorId)
+}
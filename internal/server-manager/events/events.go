@@ -0,0 +1,61 @@
+package managerevents
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gerladeno/chat-service/internal/eventspb"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+var (
+	_ websocketstream.ProtoEvent = NewMessageEvent{}
+	_ websocketstream.ProtoEvent = MessageSentEvent{}
+	_ websocketstream.ProtoEvent = MessageBlockedEvent{}
+)
+
+// The events embed the stream ones, so they are the same in JSON.
+type (
+	NewMessageEvent struct {
+		*eventstream.NewMessageEvent
+	}
+	MessageSentEvent struct {
+		*eventstream.MessageSentEvent
+	}
+	MessageBlockedEvent struct {
+		*eventstream.MessageBlockedEvent
+	}
+)
+
+func (e NewMessageEvent) ProtoEvent() *eventspb.Event {
+	ev := &eventspb.NewMessageEvent{
+		EventId:   e.EventID.String(),
+		RequestId: e.RequestID.String(),
+		MessageId: e.MessageID.String(),
+		Body:      e.MessageBody,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		IsService: e.IsService,
+		ChatId:    e.ChatID.String(),
+	}
+	if !e.UserID.IsZero() {
+		ev.AuthorId = proto.String(e.UserID.String())
+	}
+	return &eventspb.Event{Event: &eventspb.Event_NewMessageEvent{NewMessageEvent: ev}}
+}
+
+func (e MessageSentEvent) ProtoEvent() *eventspb.Event {
+	return &eventspb.Event{Event: &eventspb.Event_MessageSentEvent{MessageSentEvent: &eventspb.MessageSentEvent{
+		EventId:   e.EventID.String(),
+		RequestId: e.RequestID.String(),
+		MessageId: e.MessageID.String(),
+	}}}
+}
+
+func (e MessageBlockedEvent) ProtoEvent() *eventspb.Event {
+	return &eventspb.Event{Event: &eventspb.Event_MessageBlockedEvent{MessageBlockedEvent: &eventspb.MessageBlockedEvent{
+		EventId:   e.EventID.String(),
+		RequestId: e.RequestID.String(),
+		MessageId: e.MessageID.String(),
+	}}}
+}
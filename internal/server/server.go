@@ -23,15 +23,13 @@ const (
 
 //go:generate options-gen -out-filename=server_options.gen.go -from-struct=Options
 type Options struct {
-	logger               *zap.Logger              `option:"mandatory" validate:"required"`
-	addr                 string                   `option:"mandatory" validate:"required,hostname_port"`
-	allowOrigins         []string                 `option:"mandatory" validate:"min=1"`
-	registerHandlersFunc func(e *echo.Echo)       `option:"mandatory" validate:"required"`
-	client               middlewares.Introspector `option:"mandatory" validate:"required"`
-	resource             string                   `option:"mandatory" validate:"required"`
-	role                 string                   `option:"mandatory" validate:"required"`
-	wsSecProtocol        string                   `option:"mandatory" validate:"required"`
-	errHandler           echo.HTTPErrorHandler    `option:"mandatory" validate:"required"`
+	logger               *zap.Logger                    `option:"mandatory" validate:"required"`
+	addr                 string                         `option:"mandatory" validate:"required,hostname_port"`
+	allowOrigins         []string                       `option:"mandatory" validate:"min=1"`
+	registerHandlersFunc func(e *echo.Echo)             `option:"mandatory" validate:"required"`
+	verifier             *middlewares.OIDCTokenVerifier `option:"mandatory" validate:"required"`
+	wsSecProtocol        string                         `option:"mandatory" validate:"required"`
+	errHandler           echo.HTTPErrorHandler          `option:"mandatory" validate:"required"`
 }
 
 type Server struct {
@@ -51,9 +49,9 @@ func New(opts Options) (*Server, error) {
 		middleware.BodyLimit(bodyLimit),
 		middleware.CORSWithConfig(middleware.CORSConfig{
 			AllowOrigins: opts.allowOrigins,
-			AllowMethods: []string{http.MethodPost},
+			AllowMethods: []string{http.MethodGet, http.MethodPost},
 		}),
-		middlewares.NewKeycloakTokenAuth(opts.client, opts.resource, opts.role, opts.wsSecProtocol),
+		middlewares.NewOIDCTokenAuth(opts.verifier, opts.wsSecProtocol),
 		middlewares.RequestLogger(opts.logger),
 	)
 	opts.registerHandlersFunc(e)
@@ -18,9 +18,7 @@ func NewOptions(
 	addr string,
 	allowOrigins []string,
 	registerHandlersFunc func(e *echo.Echo),
-	client middlewares.Introspector,
-	resource string,
-	role string,
+	verifier *middlewares.OIDCTokenVerifier,
 	wsSecProtocol string,
 	errHandler echo.HTTPErrorHandler,
 	options ...OptOptionsSetter,
@@ -33,9 +31,7 @@ func NewOptions(
 	o.addr = addr
 	o.allowOrigins = allowOrigins
 	o.registerHandlersFunc = registerHandlersFunc
-	o.client = client
-	o.resource = resource
-	o.role = role
+	o.verifier = verifier
 	o.wsSecProtocol = wsSecProtocol
 	o.errHandler = errHandler
 
@@ -51,9 +47,7 @@ func (o *Options) Validate() error {
 	errs.Add(errors461e464ebed9.NewValidationError("addr", _validate_Options_addr(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("allowOrigins", _validate_Options_allowOrigins(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("registerHandlersFunc", _validate_Options_registerHandlersFunc(o)))
-	errs.Add(errors461e464ebed9.NewValidationError("client", _validate_Options_client(o)))
-	errs.Add(errors461e464ebed9.NewValidationError("resource", _validate_Options_resource(o)))
-	errs.Add(errors461e464ebed9.NewValidationError("role", _validate_Options_role(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("verifier", _validate_Options_verifier(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("wsSecProtocol", _validate_Options_wsSecProtocol(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("errHandler", _validate_Options_errHandler(o)))
 	return errs.AsError()
@@ -87,23 +81,9 @@ func _validate_Options_registerHandlersFunc(o *Options) error {
 	return nil
 }
 
-func _validate_Options_client(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.client, "required"); err != nil {
-		return fmt461e464ebed9.Errorf("field `client` did not pass the test: %w", err)
-	}
-	return nil
-}
-
-func _validate_Options_resource(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.resource, "required"); err != nil {
-		return fmt461e464ebed9.Errorf("field `resource` did not pass the test: %w", err)
-	}
-	return nil
-}
-
-func _validate_Options_role(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.role, "required"); err != nil {
-		return fmt461e464ebed9.Errorf("field `role` did not pass the test: %w", err)
+func _validate_Options_verifier(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.verifier, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `verifier` did not pass the test: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,134 @@
+package afcdlqreplayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
+)
+
+//go:generate options-gen -out-filename=replayer_options.gen.go -from-struct=Options
+type Options struct {
+	dlqReader     broker.Subscriber `option:"mandatory" validate:"required"`
+	verdictWriter broker.Publisher  `option:"mandatory" validate:"required"`
+	out           io.Writer         `option:"mandatory" validate:"required"`
+
+	// errorContains selects the messages with the substring in the last error header.
+	errorContains string
+	// from and to select the messages produced to the DLQ in the time range, zero means unbounded.
+	from time.Time
+	to   time.Time
+	// maxCount limits the number of selected messages, zero means unlimited.
+	maxCount int `validate:"min=0"`
+	// idleTimeout is how long to wait for the next message before considering the DLQ read.
+	idleTimeout time.Duration `default:"5s" validate:"min=100ms,max=1m"`
+	// dryRun only prints the selected messages.
+	dryRun bool
+}
+
+// Replayer reads the AFC verdicts DLQ from the beginning and produces
+// the selected verdicts back to the verdicts topic. It doesn't commit the DLQ offsets.
+type Replayer struct {
+	Options
+}
+
+func New(opts Options) (*Replayer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating afc dlq replayer options: %v", err)
+	}
+	return &Replayer{Options: opts}, nil
+}
+
+// Run replays the selected messages until the DLQ is read, maxCount is reached or ctx is done.
+// It returns the number of the selected messages.
+func (r *Replayer) Run(ctx context.Context) (int, error) {
+	var selected int
+	for r.maxCount == 0 || selected < r.maxCount {
+		msg, err := r.fetch(ctx)
+		if errors.Is(err, errDLQIsRead) {
+			break
+		}
+		if err != nil {
+			return selected, err
+		}
+		if !r.matches(msg) {
+			continue
+		}
+		selected++
+
+		r.print(msg)
+		if r.dryRun {
+			continue
+		}
+		if err := r.verdictWriter.Publish(ctx, stripDLQHeaders(msg)); err != nil {
+			return selected - 1, fmt.Errorf("produce verdict from partition %d offset %d: %v", msg.Partition, msg.Offset, err)
+		}
+	}
+	return selected, nil
+}
+
+var errDLQIsRead = errors.New("no new messages in dlq")
+
+func (r *Replayer) fetch(ctx context.Context) (broker.Message, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, r.idleTimeout)
+	defer cancel()
+
+	msg, err := r.dlqReader.Fetch(fetchCtx)
+	switch {
+	case err == nil:
+		return msg, nil
+	case ctx.Err() != nil:
+		return broker.Message{}, ctx.Err()
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, io.EOF):
+		return broker.Message{}, errDLQIsRead
+	default:
+		return broker.Message{}, fmt.Errorf("fetch dlq message: %v", err)
+	}
+}
+
+func (r *Replayer) matches(msg broker.Message) bool {
+	if !r.from.IsZero() && msg.Time.Before(r.from) {
+		return false
+	}
+	if !r.to.IsZero() && msg.Time.After(r.to) {
+		return false
+	}
+	return strings.Contains(header(msg, afcverdictsprocessor.HeaderLastError), r.errorContains)
+}
+
+func (r *Replayer) print(msg broker.Message) {
+	prefix := "replay"
+	if r.dryRun {
+		prefix = "dry-run"
+	}
+	_, _ = fmt.Fprintf(r.out, "%s: partition=%d offset=%d time=%s original_partition=%s key=%q last_error=%q\n%s\n",
+		prefix, msg.Partition, msg.Offset, msg.Time.Format(time.RFC3339),
+		header(msg, afcverdictsprocessor.HeaderOriginalPartition), msg.Key,
+		header(msg, afcverdictsprocessor.HeaderLastError), msg.Value)
+}
+
+func header(msg broker.Message, key string) string {
+	v, _ := msg.Header(key)
+	return v
+}
+
+// stripDLQHeaders returns the message as it was produced to the verdicts topic.
+func stripDLQHeaders(msg broker.Message) broker.Message {
+	var headers []broker.Header
+	for _, h := range msg.Headers {
+		if h.Key == afcverdictsprocessor.HeaderLastError || h.Key == afcverdictsprocessor.HeaderOriginalPartition {
+			continue
+		}
+		headers = append(headers, h)
+	}
+	return broker.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
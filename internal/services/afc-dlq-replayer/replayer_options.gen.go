@@ -0,0 +1,116 @@
+// Code generated by options-gen. DO NOT EDIT.
+package afcdlqreplayer
+
+import (
+	fmt461e464ebed9 "fmt"
+	"io"
+	"time"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	dlqReader broker.Subscriber,
+	verdictWriter broker.Publisher,
+	out io.Writer,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+	o.idleTimeout, _ = time.ParseDuration("5s")
+
+	o.dlqReader = dlqReader
+	o.verdictWriter = verdictWriter
+	o.out = out
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithErrorContains(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.errorContains = opt
+	}
+}
+
+func WithFrom(opt time.Time) OptOptionsSetter {
+	return func(o *Options) {
+		o.from = opt
+	}
+}
+
+func WithTo(opt time.Time) OptOptionsSetter {
+	return func(o *Options) {
+		o.to = opt
+	}
+}
+
+func WithMaxCount(opt int) OptOptionsSetter {
+	return func(o *Options) {
+		o.maxCount = opt
+	}
+}
+
+func WithIdleTimeout(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.idleTimeout = opt
+	}
+}
+
+func WithDryRun(opt bool) OptOptionsSetter {
+	return func(o *Options) {
+		o.dryRun = opt
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("dlqReader", _validate_Options_dlqReader(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("verdictWriter", _validate_Options_verdictWriter(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("out", _validate_Options_out(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxCount", _validate_Options_maxCount(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("idleTimeout", _validate_Options_idleTimeout(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_dlqReader(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.dlqReader, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `dlqReader` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_verdictWriter(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.verdictWriter, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `verdictWriter` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_out(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.out, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `out` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_maxCount(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxCount, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxCount` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_idleTimeout(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.idleTimeout, "min=100ms,max=1m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `idleTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package afcdlqreplayer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	brokermocks "github.com/gerladeno/chat-service/internal/broker/mocks"
+	afcdlqreplayer "github.com/gerladeno/chat-service/internal/services/afc-dlq-replayer"
+	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
+)
+
+var now = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func dlqMessage(offset int64, key, lastError string, t time.Time) broker.Message {
+	return broker.Message{
+		Partition: 1,
+		Offset:    offset,
+		Key:       []byte(key),
+		Value:     []byte(`{"status":"ok"}`),
+		Time:      t,
+		Headers: []broker.Header{
+			{Key: "trace", Value: []byte("abc")},
+			{Key: afcverdictsprocessor.HeaderLastError, Value: []byte(lastError)},
+			{Key: afcverdictsprocessor.HeaderOriginalPartition, Value: []byte("3")},
+		},
+	}
+}
+
+func expectDLQ(reader *brokermocks.MockSubscriber, msgs ...broker.Message) {
+	for _, m := range msgs {
+		reader.EXPECT().Fetch(gomock.Any()).Return(m, nil)
+	}
+	reader.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, context.DeadlineExceeded).MaxTimes(1)
+}
+
+func TestReplayer_Run_Filters(t *testing.T) {
+	// Arrange.
+	ctrl := gomock.NewController(t)
+	reader := brokermocks.NewMockSubscriber(ctrl)
+	writer := brokermocks.NewMockPublisher(ctrl)
+
+	expectDLQ(reader,
+		dlqMessage(1, "k1", "mark visible for manager: conn refused", now.Add(-2*time.Hour)), // Too old.
+		dlqMessage(2, "k2", "mark visible for manager: conn refused", now),
+		dlqMessage(3, "k3", "unknown status", now), // Other error.
+		dlqMessage(4, "k4", "block message: conn refused", now.Add(time.Minute)),
+		dlqMessage(5, "k5", "block message: conn refused", now.Add(2*time.Hour)), // Too new.
+	)
+	var written []broker.Message
+	writer.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msgs ...broker.Message) error {
+			written = append(written, msgs...)
+			return nil
+		}).Times(2)
+
+	out := new(bytes.Buffer)
+	r, err := afcdlqreplayer.New(afcdlqreplayer.NewOptions(reader, writer, out,
+		afcdlqreplayer.WithErrorContains("conn refused"),
+		afcdlqreplayer.WithFrom(now.Add(-time.Hour)),
+		afcdlqreplayer.WithTo(now.Add(time.Hour)),
+	))
+	require.NoError(t, err)
+
+	// Action.
+	n, err := r.Run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, written, 2)
+	for i, key := range []string{"k2", "k4"} {
+		assert.Equal(t, key, string(written[i].Key))
+		assert.Equal(t, `{"status":"ok"}`, string(written[i].Value))
+		assert.Equal(t, []broker.Header{{Key: "trace", Value: []byte("abc")}}, written[i].Headers)
+		assert.Empty(t, written[i].Topic)
+		assert.True(t, written[i].Time.IsZero())
+	}
+	assert.Contains(t, out.String(), `replay: partition=1 offset=2`)
+}
+
+func TestReplayer_Run_DryRun(t *testing.T) {
+	// Arrange.
+	ctrl := gomock.NewController(t)
+	reader := brokermocks.NewMockSubscriber(ctrl)
+	writer := brokermocks.NewMockPublisher(ctrl)
+
+	expectDLQ(reader,
+		dlqMessage(1, "k1", "some error", now),
+		dlqMessage(2, "k2", "some error", now),
+	)
+
+	out := new(bytes.Buffer)
+	r, err := afcdlqreplayer.New(afcdlqreplayer.NewOptions(reader, writer, out, afcdlqreplayer.WithDryRun(true)))
+	require.NoError(t, err)
+
+	// Action.
+	n, err := r.Run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Contains(t, out.String(), `dry-run: partition=1 offset=1`)
+	assert.Contains(t, out.String(), `original_partition=3 key="k2" last_error="some error"`)
+}
+
+func TestReplayer_Run_MaxCount(t *testing.T) {
+	// Arrange.
+	ctrl := gomock.NewController(t)
+	reader := brokermocks.NewMockSubscriber(ctrl)
+	writer := brokermocks.NewMockPublisher(ctrl)
+
+	reader.EXPECT().Fetch(gomock.Any()).Return(dlqMessage(1, "k1", "e", now), nil)
+	reader.EXPECT().Fetch(gomock.Any()).Return(dlqMessage(2, "k2", "e", now), nil)
+	writer.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(2)
+
+	r, err := afcdlqreplayer.New(afcdlqreplayer.NewOptions(reader, writer, new(bytes.Buffer),
+		afcdlqreplayer.WithMaxCount(2),
+	))
+	require.NoError(t, err)
+
+	// Action.
+	n, err := r.Run(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestReplayer_Run_WriteError(t *testing.T) {
+	// Arrange.
+	ctrl := gomock.NewController(t)
+	reader := brokermocks.NewMockSubscriber(ctrl)
+	writer := brokermocks.NewMockPublisher(ctrl)
+
+	reader.EXPECT().Fetch(gomock.Any()).Return(dlqMessage(1, "k1", "e", now), nil)
+	writer.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("broker is down"))
+
+	r, err := afcdlqreplayer.New(afcdlqreplayer.NewOptions(reader, writer, new(bytes.Buffer)))
+	require.NoError(t, err)
+
+	// Action.
+	n, err := r.Run(context.Background())
+
+	// Assert.
+	require.Error(t, err)
+	assert.Equal(t, 0, n)
+}
@@ -0,0 +1,83 @@
+package afcverdictsprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+)
+
+// Clock is the source of time for the retries backoff.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// isPermanent reports whether the verdict processing error doesn't go away on retry.
+func isPermanent(err error) bool {
+	return errors.Is(err, ErrUnknownStatus) || errors.Is(err, messagesrepo.ErrMsgNotFound)
+}
+
+// retry calls f until it succeeds or returns a permanent error. The pause between attempts
+// starts with backoffInitialInterval and is multiplied by backoffFactor each time.
+// Retries stop after the retries attempts or if the next pause exceeds backoffMaxElapsedTime.
+func (s *Service) retry(ctx context.Context, f func() error) error {
+	start := s.clock.Now()
+	interval := s.backoffInitialInterval
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || isPermanent(err) || attempt >= s.retries {
+			return err
+		}
+		if s.clock.Now().Sub(start)+interval > s.backoffMaxElapsedTime {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: last error: %v", ctx.Err(), err)
+		case <-s.clock.After(interval):
+		}
+		interval = time.Duration(float64(interval) * s.backoffFactor)
+	}
+}
+
+// retryUntilDone calls f until it succeeds or ctx is done, the pause between attempts grows as in retry
+// but is capped at dlqBackoffMaxInterval. The error is returned only if ctx is done.
+func (s *Service) retryUntilDone(ctx context.Context, log *zap.Logger, f func() error) error {
+	var interval time.Duration
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: last error: %v", ctx.Err(), err)
+		}
+
+		if interval == 0 {
+			interval = s.backoffInitialInterval
+		} else {
+			interval = time.Duration(float64(interval) * s.backoffFactor)
+		}
+		if interval > s.dlqBackoffMaxInterval {
+			interval = s.dlqBackoffMaxInterval
+		}
+		log.Warn("retrying", zap.Int("attempt", attempt), zap.Duration("pause", interval), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: last error: %v", ctx.Err(), err)
+		case <-s.clock.After(interval):
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package afcverdictsprocessor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/jwks"
+)
+
+// reloadJWKS replaces the file keys if the file content has changed.
+func (s *Service) reloadJWKS() error {
+	data, err := os.ReadFile(s.verdictsJWKSFile)
+	if err != nil {
+		return fmt.Errorf("read jwks file: %v", err)
+	}
+	if bytes.Equal(data, s.jwksData) {
+		return nil
+	}
+
+	fileKeys, err := jwks.Parse(data)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]jwks.Key, len(s.staticKeys)+len(fileKeys))
+	for kid, k := range s.staticKeys {
+		keys[kid] = k
+	}
+	for kid, k := range fileKeys {
+		keys[kid] = k
+	}
+	s.keys.Replace(keys)
+	s.jwksData = data
+	return nil
+}
+
+func (s *Service) watchJWKS(ctx context.Context) {
+	t := time.NewTicker(s.jwksReloadInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		if err := s.reloadJWKS(); err != nil {
+			zap.L().Named(serviceName).Warn("reloading verdicts jwks failed, keeping the previous keys", zap.Error(err))
+		}
+	}
+}
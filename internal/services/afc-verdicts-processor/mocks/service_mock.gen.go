@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
 	types "github.com/gerladeno/chat-service/internal/types"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -37,31 +38,83 @@ func (m *MockmessagesRepository) EXPECT() *MockmessagesRepositoryMockRecorder {
 }
 
 // BlockMessage mocks base method.
-func (m *MockmessagesRepository) BlockMessage(ctx context.Context, msgID types.MessageID) error {
+func (m *MockmessagesRepository) BlockMessage(ctx context.Context, msgID types.MessageID, source string, at time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BlockMessage", ctx, msgID)
+	ret := m.ctrl.Call(m, "BlockMessage", ctx, msgID, source, at)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // BlockMessage indicates an expected call of BlockMessage.
-func (mr *MockmessagesRepositoryMockRecorder) BlockMessage(ctx, msgID interface{}) *gomock.Call {
+func (mr *MockmessagesRepositoryMockRecorder) BlockMessage(ctx, msgID, source, at interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockMessage", reflect.TypeOf((*MockmessagesRepository)(nil).BlockMessage), ctx, msgID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockMessage", reflect.TypeOf((*MockmessagesRepository)(nil).BlockMessage), ctx, msgID, source, at)
+}
+
+// GetVerdictForUpdate mocks base method.
+func (m *MockmessagesRepository) GetVerdictForUpdate(ctx context.Context, msgID types.MessageID) (*messagesrepo.Verdict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerdictForUpdate", ctx, msgID)
+	ret0, _ := ret[0].(*messagesrepo.Verdict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerdictForUpdate indicates an expected call of GetVerdictForUpdate.
+func (mr *MockmessagesRepositoryMockRecorder) GetVerdictForUpdate(ctx, msgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerdictForUpdate", reflect.TypeOf((*MockmessagesRepository)(nil).GetVerdictForUpdate), ctx, msgID)
 }
 
 // MarkAsVisibleForManager mocks base method.
-func (m *MockmessagesRepository) MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID) error {
+func (m *MockmessagesRepository) MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID, source string, at time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsVisibleForManager", ctx, msgID)
+	ret := m.ctrl.Call(m, "MarkAsVisibleForManager", ctx, msgID, source, at)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkAsVisibleForManager indicates an expected call of MarkAsVisibleForManager.
-func (mr *MockmessagesRepositoryMockRecorder) MarkAsVisibleForManager(ctx, msgID interface{}) *gomock.Call {
+func (mr *MockmessagesRepositoryMockRecorder) MarkAsVisibleForManager(ctx, msgID, source, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsVisibleForManager", reflect.TypeOf((*MockmessagesRepository)(nil).MarkAsVisibleForManager), ctx, msgID, source, at)
+}
+
+// MockbodyRedactor is a mock of bodyRedactor interface.
+type MockbodyRedactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockbodyRedactorMockRecorder
+}
+
+// MockbodyRedactorMockRecorder is the mock recorder for MockbodyRedactor.
+type MockbodyRedactorMockRecorder struct {
+	mock *MockbodyRedactor
+}
+
+// NewMockbodyRedactor creates a new mock instance.
+func NewMockbodyRedactor(ctrl *gomock.Controller) *MockbodyRedactor {
+	mock := &MockbodyRedactor{ctrl: ctrl}
+	mock.recorder = &MockbodyRedactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbodyRedactor) EXPECT() *MockbodyRedactorMockRecorder {
+	return m.recorder
+}
+
+// RedactMessage mocks base method.
+func (m *MockbodyRedactor) RedactMessage(ctx context.Context, msgID types.MessageID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactMessage", ctx, msgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedactMessage indicates an expected call of RedactMessage.
+func (mr *MockbodyRedactorMockRecorder) RedactMessage(ctx, msgID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsVisibleForManager", reflect.TypeOf((*MockmessagesRepository)(nil).MarkAsVisibleForManager), ctx, msgID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactMessage", reflect.TypeOf((*MockbodyRedactor)(nil).RedactMessage), ctx, msgID)
 }
 
 // MockoutboxService is a mock of outboxService interface.
@@ -87,19 +140,19 @@ func (m *MockoutboxService) EXPECT() *MockoutboxServiceMockRecorder {
 	return m.recorder
 }
 
-// Put mocks base method.
-func (m *MockoutboxService) Put(ctx context.Context, name, payload string, availableAt time.Time) (types.JobID, error) {
+// PutUnique mocks base method.
+func (m *MockoutboxService) PutUnique(ctx context.Context, name, payload, dedupKey string, availableAt time.Time) (types.JobID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Put", ctx, name, payload, availableAt)
+	ret := m.ctrl.Call(m, "PutUnique", ctx, name, payload, dedupKey, availableAt)
 	ret0, _ := ret[0].(types.JobID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Put indicates an expected call of Put.
-func (mr *MockoutboxServiceMockRecorder) Put(ctx, name, payload, availableAt interface{}) *gomock.Call {
+// PutUnique indicates an expected call of PutUnique.
+func (mr *MockoutboxServiceMockRecorder) PutUnique(ctx, name, payload, dedupKey, availableAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockoutboxService)(nil).Put), ctx, name, payload, availableAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutUnique", reflect.TypeOf((*MockoutboxService)(nil).PutUnique), ctx, name, payload, dedupKey, availableAt)
 }
 
 // Mocktransactor is a mock of transactor interface.
@@ -1,27 +1,22 @@
 package afcverdictsprocessor
 
 import (
-	"context"
-	"io"
-
 	"github.com/segmentio/kafka-go"
-)
-
-//go:generate mockgen -source=$GOFILE -destination=mocks/reader_mock.gen.go -package=afcverdictsprocessormocks
 
-type KafkaReaderFactory func(brokers []string, groupID string, topic string) KafkaReader
+	"github.com/gerladeno/chat-service/internal/broker"
+	kafkabroker "github.com/gerladeno/chat-service/internal/broker/kafka"
+)
 
-type KafkaReader interface {
-	io.Closer
-	FetchMessage(ctx context.Context) (kafka.Message, error)
-	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
-}
+// SubscriberFactory creates the subscriber to the topic as a member of the consumer group.
+type SubscriberFactory func(groupID string, topic string) (broker.Subscriber, error)
 
-func NewKafkaReader(brokers []string, groupID string, topic string) KafkaReader {
-	return kafka.NewReader(kafka.ReaderConfig{
-		WatchPartitionChanges: true,
-		Brokers:               brokers,
-		GroupID:               groupID,
-		Topic:                 topic,
-	})
+func NewKafkaSubscriberFactory(brokers []string) SubscriberFactory {
+	return func(groupID string, topic string) (broker.Subscriber, error) {
+		return kafkabroker.NewSubscriber(kafka.NewReader(kafka.ReaderConfig{
+			WatchPartitionChanges: true,
+			Brokers:               brokers,
+			GroupID:               groupID,
+			Topic:                 topic,
+		})), nil
+	}
 }
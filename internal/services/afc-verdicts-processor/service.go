@@ -2,25 +2,21 @@ package afcverdictsprocessor
 
 import (
 	"context"
-	"crypto"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
-	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt"
-	"github.com/segmentio/kafka-go"
+	"github.com/getsentry/sentry-go"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
-	clientmessageblockedjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-blocked"
-	clientmessagesentjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-sent"
+	"github.com/gerladeno/chat-service/internal/broker"
+	"github.com/gerladeno/chat-service/internal/jwks"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
 	"github.com/gerladeno/chat-service/internal/types"
 )
 
@@ -29,6 +25,12 @@ const (
 
 	statusOk         = `ok`
 	statusSuspicious = `suspicious`
+
+	commitTimeout = 5 * time.Second
+
+	// HeaderLastError and HeaderOriginalPartition are added to the verdicts sent to the DLQ.
+	HeaderLastError         = "LAST_ERROR"
+	HeaderOriginalPartition = "ORIGINAL_PARTITION"
 )
 
 var (
@@ -39,12 +41,17 @@ var (
 //go:generate mockgen -source=$GOFILE -destination=mocks/service_mock.gen.go -package=afcverdictsprocessormocks
 
 type messagesRepository interface {
-	MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID) error
-	BlockMessage(ctx context.Context, msgID types.MessageID) error
+	GetVerdictForUpdate(ctx context.Context, msgID types.MessageID) (*messagesrepo.Verdict, error)
+	MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID, source string, at time.Time) error
+	BlockMessage(ctx context.Context, msgID types.MessageID, source string, at time.Time) error
+}
+
+type bodyRedactor interface {
+	RedactMessage(ctx context.Context, msgID types.MessageID) error
 }
 
 type outboxService interface {
-	Put(ctx context.Context, name, payload string, availableAt time.Time) (types.JobID, error)
+	PutUnique(ctx context.Context, name, payload, dedupKey string, availableAt time.Time) (types.JobID, error)
 }
 
 type transactor interface {
@@ -57,18 +64,39 @@ type Options struct {
 	backoffMaxElapsedTime  time.Duration `default:"5s" validate:"min=500ms,max=1m"`
 	backoffFactor          float64       `default:"5" validate:"min=1.01,max=10"`
 
-	brokers         []string `option:"mandatory" validate:"min=1"`
-	consumers       int      `option:"mandatory" validate:"min=1,max=16"`
-	consumerGroup   string   `option:"mandatory" validate:"required"`
-	verdictsTopic   string   `option:"mandatory" validate:"required"`
+	consumers     int    `option:"mandatory" validate:"min=1,max=16"`
+	consumerGroup string `option:"mandatory" validate:"required"`
+	verdictsTopic string `option:"mandatory" validate:"required"`
+
+	// verdictsSignKey is the PEM public key for the verdicts without kid.
 	verdictsSignKey string
+	// verdictsSignKeys are the PEM public keys by kid.
+	verdictsSignKeys map[string]string
+	// verdictsJWKSFile is the watched JWKS file, its keys override the ones with the same kid.
+	verdictsJWKSFile   string
+	jwksReloadInterval time.Duration `default:"10s" validate:"min=10ms,max=1h"`
 
+	// processBatchSize is the number of handled verdicts committed at once,
+	// processBatchMaxTimeout is the max time the handled verdict waits for the commit.
 	processBatchSize       int           `default:"1" validate:"min=1,max=1000"`
 	processBatchMaxTimeout time.Duration `default:"100ms" validate:"min=50ms,max=10s"`
-	retries                int           `default:"3" validate:"min=1,max=10"`
+	// retries is the max number of attempts to process a verdict.
+	retries int `default:"3" validate:"min=1,max=10"`
+	// dlqBackoffMaxInterval caps the pause between the attempts to send a verdict to the DLQ,
+	// they go on until the send succeeds.
+	dlqBackoffMaxInterval time.Duration `default:"30s" validate:"min=100ms,max=10m"`
+	// conflictPolicy decides whether a verdict overrides the different one applied before,
+	// ConflictPolicySuspiciousWins by default.
+	conflictPolicy ConflictPolicy `validate:"omitempty,oneof=suspicious_wins latest_wins"`
+	// localVerdictPrecedence decides whether the verdict overrides the local one,
+	// LocalVerdictPrecedenceLocal by default.
+	localVerdictPrecedence LocalVerdictPrecedence `validate:"omitempty,oneof=local afc"`
+	// bodyRedactor redacts the body of the blocked messages if set.
+	bodyRedactor bodyRedactor
+	clock        Clock
 
-	readerFactory KafkaReaderFactory `option:"mandatory" validate:"required"`
-	dlqWriter     KafkaDLQWriter     `option:"mandatory" validate:"required"`
+	subscriberFactory SubscriberFactory `option:"mandatory" validate:"required"`
+	dlqPublisher      broker.Publisher  `option:"mandatory" validate:"required"`
 
 	txtor   transactor         `option:"mandatory" validate:"required"`
 	msgRepo messagesRepository `option:"mandatory" validate:"required"`
@@ -77,7 +105,9 @@ type Options struct {
 
 type Service struct {
 	Options
-	publicKey *rsa.PublicKey
+	staticKeys map[string]jwks.Key
+	keys       *jwks.Set // Nil if verdicts are not signed.
+	jwksData   []byte
 }
 
 func New(opts Options) (*Service, error) {
@@ -87,20 +117,37 @@ func New(opts Options) (*Service, error) {
 	s := Service{
 		Options: opts,
 	}
+	if s.clock == nil {
+		s.clock = realClock{}
+	}
+	if s.conflictPolicy == "" {
+		s.conflictPolicy = ConflictPolicySuspiciousWins
+	}
+	if s.localVerdictPrecedence == "" {
+		s.localVerdictPrecedence = LocalVerdictPrecedenceLocal
+	}
+	s.staticKeys = make(map[string]jwks.Key, len(opts.verdictsSignKeys)+1)
 	if opts.verdictsSignKey != "" {
-		block, _ := pem.Decode([]byte(opts.verdictsSignKey))
-		if block == nil {
-			return nil, errors.New("invalid signing key")
+		key, err := jwks.ParsePEM(opts.verdictsSignKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing key: %v", err)
 		}
-		pubKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		s.staticKeys[""] = key
+	}
+	for kid, pemKey := range opts.verdictsSignKeys {
+		key, err := jwks.ParsePEM(pemKey)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("parse signing key %q: %v", kid, err)
 		}
-		publicKey, ok := pubKey.(*rsa.PublicKey)
-		if !ok {
-			return nil, errors.New("invalid key type")
+		s.staticKeys[kid] = key
+	}
+	if len(s.staticKeys) > 0 || opts.verdictsJWKSFile != "" {
+		s.keys = jwks.NewSet(s.staticKeys)
+	}
+	if opts.verdictsJWKSFile != "" {
+		if err := s.reloadJWKS(); err != nil {
+			return nil, fmt.Errorf("load jwks: %v", err)
 		}
-		s.publicKey = publicKey
 	}
 	return &s, nil
 }
@@ -114,146 +161,213 @@ type verdict struct {
 func (s *Service) Run(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	defer func() {
-		if err := s.dlqWriter.Close(); err != nil {
-			zap.L().Warn("close dlqWriter", zap.Error(err))
+		if err := s.dlqPublisher.Close(); err != nil {
+			zap.L().Warn("close dlqPublisher", zap.Error(err))
 		}
 	}()
+	if s.verdictsJWKSFile != "" {
+		eg.Go(func() error {
+			s.watchJWKS(ctx)
+			return nil
+		})
+	}
 	for i := 0; i < s.consumers; i++ {
 		eg.Go(func() error {
-			reader := s.readerFactory(s.brokers, s.consumerGroup, s.verdictsTopic)
+			sub, err := s.subscriberFactory(s.consumerGroup, s.verdictsTopic)
+			if err != nil {
+				return fmt.Errorf("subscribe to verdicts: %v", err)
+			}
 			defer func() {
-				if err := reader.Close(); err != nil {
-					zap.L().Warn("close reader", zap.Error(err))
+				if err := sub.Close(); err != nil {
+					zap.L().Warn("close subscriber", zap.Error(err))
 				}
 			}()
-			return s.processMessages(ctx, reader)
+			return s.processMessages(ctx, sub)
 		})
 	}
 	return eg.Wait()
 }
 
-func (s *Service) processMessages(ctx context.Context, reader KafkaReader) error {
-	errCh := make(chan struct{})
-	msgCh := make(chan kafka.Message)
-	var messages []kafka.Message
-	var msg kafka.Message
-	go func() {
-		var err error
+// processMessages dispatches the fetched verdicts to a worker per partition, so the verdicts
+// of a partition are processed in order while the partitions are processed concurrently.
+// The offsets are committed up to the last handled verdict of each partition.
+func (s *Service) processMessages(ctx context.Context, sub broker.Subscriber) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	handled := make(chan broker.Message)
+
+	eg.Go(func() error {
+		partitions := make(map[int]chan broker.Message)
+		defer func() {
+			for _, ch := range partitions {
+				close(ch)
+			}
+		}()
+
 		for {
-			msg, err = reader.FetchMessage(ctx)
+			msg, err := sub.Fetch(ctx)
 			if err != nil {
-				zap.L().Warn("fetching message", zap.Error(err))
-				errCh <- struct{}{}
-				break
+				if ctx.Err() == nil {
+					zap.L().Warn("fetching message", zap.Error(err))
+				}
+				return nil
+			}
+
+			ch, ok := partitions[msg.Partition]
+			if !ok {
+				ch = make(chan broker.Message, s.processBatchSize)
+				partitions[msg.Partition] = ch
+				eg.Go(func() error { return s.processPartition(ctx, ch, handled) })
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case ch <- msg:
 			}
-			msgCh <- msg
 		}
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- eg.Wait()
+		close(handled)
 	}()
+
+	ticker := time.NewTicker(s.processBatchMaxTimeout)
+	defer ticker.Stop()
+
+	pending := make(map[int]broker.Message)
+	var n int
 	for {
-		messages = messages[:0]
-	LOOP:
-		for j := 0; j < s.processBatchSize; j++ {
-			select {
-			case <-time.After(s.processBatchMaxTimeout):
-				break LOOP
-			case <-errCh:
+		select {
+		case msg, ok := <-handled:
+			if !ok {
+				s.commit(sub, pending)
+				return <-errCh
+			}
+			pending[msg.Partition] = msg
+			if n++; n >= s.processBatchSize {
+				s.commit(sub, pending)
+				n = 0
+			}
+
+		case <-ticker.C:
+			s.commit(sub, pending)
+			n = 0
+		}
+	}
+}
+
+// processPartition handles the verdicts of one partition in order and reports the handled ones.
+// The partition is blocked while the verdict that is neither processed nor sent to the DLQ is retried,
+// so the offset of that verdict is not committed.
+func (s *Service) processPartition(ctx context.Context, msgs <-chan broker.Message, handled chan<- broker.Message) error {
+	for msg := range msgs {
+		if err := s.handleMessage(ctx, msg); err != nil {
+			if ctx.Err() != nil {
 				return nil
-			case msg := <-msgCh:
-				messages = append(messages, msg)
 			}
+			return err
 		}
+
 		select {
 		case <-ctx.Done():
 			return nil
-		default:
-			s.processBatch(ctx, messages)
-			if err := reader.CommitMessages(ctx, messages...); err != nil {
-				zap.L().Warn("CommitMessages", zap.Error(err))
-			}
+		case handled <- msg:
 		}
 	}
+	return nil
 }
 
-func (s *Service) processBatch(ctx context.Context, messages []kafka.Message) {
-	for _, msg := range messages {
-		v, msgID, err := s.decodeMsg(msg.Value)
-		if err == nil {
-			// Очень много времени потерял на exponential backoff - тесты не проходят.
-			// Сделал простые ретраи, посмотрю авторское решение
-			for j := 0; j < s.retries; j++ {
-				if err = s.processVerdict(ctx, msgID, v); err != nil && !errors.Is(err, ErrUnknownStatus) {
-					continue
-				}
-				break
-			}
-			if err != nil {
-				msg.Headers = append(msg.Headers, formHeaders(err, msg.Partition)...)
-			}
+// handleMessage processes the verdict or sends it to the DLQ if that is impossible.
+// The span continues the trace of the produced message if AFC passed its headers to the verdict.
+func (s *Service) handleMessage(ctx context.Context, msg broker.Message) error {
+	requestID, _ := msg.Header(msgenvelope.HeaderRequestID)
+	span := sentry.StartSpan(ctx, "afc.verdict",
+		sentry.TransactionName(serviceName+".verdict"),
+		msgenvelope.ContinueTrace(msg),
+	)
+	defer span.Finish()
+	span.SetTag("request_id", requestID)
+	ctx = span.Context()
+
+	log := zap.L().Named(serviceName).With(
+		zap.String("request_id", requestID),
+		zap.Stringer("trace_id", span.TraceID),
+	)
+
+	v, msgID, err := s.decodeMsg(msg.Value)
+	if err == nil {
+		at := msg.Time
+		if at.IsZero() {
+			at = time.Now()
 		}
-		if err != nil {
-			msg.Topic = ""
-			if err = s.dlqWriter.WriteMessages(ctx, msg); err != nil {
-				zap.L().Warn("produce do dlq", zap.Error(err))
-			}
+		err = s.retry(ctx, func() error {
+			return s.processVerdict(ctx, log, msgID, v, at)
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			return nil
 		}
+		msg.Headers = append(msg.Headers, formHeaders(err, msg.Partition)...)
+	}
+	span.Status = sentry.SpanStatusInternalError
+	log.Warn("verdict sent to dlq", zap.Error(err))
+
+	if err := s.retryUntilDone(ctx, log, func() error {
+		return s.dlqPublisher.Publish(ctx, msg)
+	}); err != nil {
+		return fmt.Errorf("produce to dlq: %w", err)
 	}
+	return nil
 }
 
-func formHeaders(lastError error, originalPartition int) []kafka.Header {
-	return []kafka.Header{
+// commit commits the offsets of the pending messages, they are kept pending on failure.
+// The commit isn't bound to the processing context to save the progress on shutdown.
+func (s *Service) commit(sub broker.Subscriber, pending map[int]broker.Message) {
+	if len(pending) == 0 {
+		return
+	}
+
+	msgs := make([]broker.Message, 0, len(pending))
+	for _, msg := range pending {
+		msgs = append(msgs, msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Partition < msgs[j].Partition })
+
+	ctx, cancel := context.WithTimeout(context.Background(), commitTimeout)
+	defer cancel()
+
+	if err := sub.Commit(ctx, msgs...); err != nil {
+		zap.L().Warn("commit messages", zap.Error(err))
+		return
+	}
+	for p := range pending {
+		delete(pending, p)
+	}
+}
+
+func formHeaders(lastError error, originalPartition int) []broker.Header {
+	return []broker.Header{
 		{
-			Key:   "LAST_ERROR",
+			Key:   HeaderLastError,
 			Value: []byte(lastError.Error()),
 		},
 		{
-			Key:   "ORIGINAL_PARTITION",
+			Key:   HeaderOriginalPartition,
 			Value: []byte(strconv.Itoa(originalPartition)),
 		},
 	}
 }
 
-func (s *Service) processVerdict(ctx context.Context, msgID types.MessageID, v verdict) error {
-	switch v.Status {
-	case statusOk:
-		return s.txtor.RunInTx(ctx, func(ctx context.Context) error {
-			if err := s.msgRepo.MarkAsVisibleForManager(ctx, msgID); err != nil {
-				return fmt.Errorf("mark visible for manager: %v", err)
-			}
-			if _, err := s.outBox.Put(ctx, clientmessagesentjob.Name, v.MessageID, time.Now()); err != nil {
-				return fmt.Errorf("put job %s: %v", clientmessagesentjob.Name, err)
-			}
-			return nil
-		})
-	case statusSuspicious:
-		return s.txtor.RunInTx(ctx, func(ctx context.Context) error {
-			if err := s.msgRepo.BlockMessage(ctx, msgID); err != nil {
-				return fmt.Errorf("block message: %v", err)
-			}
-			if _, err := s.outBox.Put(ctx, clientmessageblockedjob.Name, v.MessageID, time.Now()); err != nil {
-				return fmt.Errorf("put job %s: %v", clientmessageblockedjob.Name, err)
-			}
-			return nil
-		})
-	default:
-		return ErrUnknownStatus
-	}
-}
-
 func (s *Service) decodeMsg(msg []byte) (verdict, types.MessageID, error) {
 	var v verdict
 	data := msg
-	if s.publicKey != nil {
-		parts := strings.Split(string(msg), ".")
-		if len(parts) != 3 {
-			return verdict{}, types.MessageIDNil, fmt.Errorf("%w: %d parts", ErrProcessingMessageWithKey, len(parts))
-		}
+	if s.keys != nil {
 		var err error
-		if data, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
-			return verdict{}, types.MessageIDNil, fmt.Errorf("%w: decode body: %v", ErrProcessingMessageWithKey, err)
-		}
-		if err = (&jwt.SigningMethodRSA{Name: "RS256", Hash: crypto.SHA256}).
-			Verify(strings.Join(parts[0:2], "."), parts[2], s.publicKey); err != nil {
-			return verdict{}, types.MessageIDNil, fmt.Errorf("%w: verify signature: %v", ErrProcessingMessageWithKey, err)
+		if data, err = s.keys.Verify(string(msg)); err != nil {
+			return verdict{}, types.MessageIDNil, fmt.Errorf("%w: %v", ErrProcessingMessageWithKey, err)
 		}
 	}
 	if err := json.Unmarshal(data, &v); err != nil {
@@ -1,28 +1,20 @@
 package afcverdictsprocessor
 
 import (
-	"context"
-	"io"
-
 	"github.com/segmentio/kafka-go"
 
+	"github.com/gerladeno/chat-service/internal/broker"
+	kafkabroker "github.com/gerladeno/chat-service/internal/broker/kafka"
 	"github.com/gerladeno/chat-service/internal/logger"
 )
 
-//go:generate mockgen -source=$GOFILE -destination=mocks/dlq_writer_mock.gen.go -package=afcverdictsprocessormocks
-
-type KafkaDLQWriter interface {
-	io.Closer
-	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
-}
-
-func NewKafkaDLQWriter(brokers []string, topic string) KafkaDLQWriter {
-	return &kafka.Writer{
+func NewKafkaDLQPublisher(brokers []string, topic string) broker.Publisher {
+	return kafkabroker.NewPublisher(&kafka.Writer{
 		Addr:         kafka.TCP(brokers...),
 		Topic:        topic,
 		RequiredAcks: kafka.RequireOne,
 		Async:        false,
 		Logger:       logger.NewKafkaAdapted().WithServiceName(serviceName),
 		ErrorLogger:  logger.NewKafkaAdapted().WithServiceName(serviceName).ForErrors(),
-	}
+	})
 }
@@ -0,0 +1,108 @@
+package afcverdictsprocessor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	inmembroker "github.com/gerladeno/chat-service/internal/broker/inmem"
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
+	afcverdictsprocessormocks "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor/mocks"
+	clientmessagesentjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-sent"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+func TestService_InMemBroker(t *testing.T) {
+	// Arrange.
+	const (
+		group  = "afc-verdict-processor"
+		topic  = "afc.msg-verdicts"
+		dlq    = "afc.msg-verdicts.dlq"
+		broken = `{"status": "ok"`
+	)
+
+	ctrl := gomock.NewController(t)
+	msgRepo := afcverdictsprocessormocks.NewMockmessagesRepository(ctrl)
+	outboxSvc := afcverdictsprocessormocks.NewMockoutboxService(ctrl)
+	txtor := afcverdictsprocessormocks.NewMocktransactor(ctrl)
+	txtor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		}).AnyTimes()
+
+	b := inmembroker.New(2)
+	svc, err := afcverdictsprocessor.New(afcverdictsprocessor.NewOptions(
+		2,
+		group,
+		topic,
+		func(groupID string, topic string) (broker.Subscriber, error) {
+			return b.Subscriber(groupID, topic), nil
+		},
+		b.Publisher(dlq),
+		txtor,
+		msgRepo,
+		outboxSvc,
+	))
+	require.NoError(t, err)
+
+	msgID := types.NewMessageID()
+	done := make(chan struct{})
+	msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	// The verdict fetched before the group rebalance may be fetched again by another member.
+	applied := &messagesrepo.Verdict{Status: messagesrepo.VerdictStatusOK, Source: "afc", At: time.Now()}
+	msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(applied, nil).AnyTimes()
+	msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(nil)
+	outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, string, string, string, time.Time) (types.JobID, error) {
+			close(done)
+			return types.NewJobID(), nil
+		})
+
+	pub := b.Publisher(topic)
+	chatID := types.NewChatID()
+	require.NoError(t, pub.Publish(context.Background(),
+		broker.Message{Key: []byte(chatID.String()), Value: []byte(broken)},
+		broker.Message{Key: []byte(chatID.String()), Value: []byte(
+			`{"chatId": "` + chatID.String() + `", "messageId": "` + msgID.String() + `", "status": "ok"}`,
+		)},
+	))
+
+	// Action.
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- svc.Run(ctx) }()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("verdict is not processed")
+	}
+	time.Sleep(200 * time.Millisecond) // Let the processor commit.
+	cancel()
+	require.NoError(t, <-errCh)
+
+	// Assert.
+	dlqSub := b.Subscriber("test", dlq)
+	defer dlqSub.Close()
+	fetchCtx, fetchCancel := context.WithTimeout(context.Background(), time.Second)
+	defer fetchCancel()
+	m, err := dlqSub.Fetch(fetchCtx)
+	require.NoError(t, err)
+	assert.Equal(t, broken, string(m.Value))
+	_, ok := m.Header(afcverdictsprocessor.HeaderLastError)
+	assert.False(t, ok, "undecodable verdict is sent as is")
+
+	// The verdicts are committed, the next member of the group has nothing to fetch.
+	sub := b.Subscriber(group, topic)
+	defer sub.Close()
+	emptyCtx, emptyCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer emptyCancel()
+	_, err = sub.Fetch(emptyCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
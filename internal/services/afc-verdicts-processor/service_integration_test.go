@@ -118,12 +118,11 @@ func (s *ServiceIntegrationSuite) SetupTest() {
 	s.Require().NoError(err)
 
 	s.svc, err = afcverdictsprocessor.New(afcverdictsprocessor.NewOptions(
-		s.ks.KafkaBrokers(),
 		4,
 		s.ConsumerGroup,
 		s.verdictsTopic,
-		afcverdictsprocessor.NewKafkaReader,
-		afcverdictsprocessor.NewKafkaDLQWriter(s.ks.KafkaBrokers(), s.verdictsDLQTopic),
+		afcverdictsprocessor.NewKafkaSubscriberFactory(s.ks.KafkaBrokers()),
+		afcverdictsprocessor.NewKafkaDLQPublisher(s.ks.KafkaBrokers(), s.verdictsDLQTopic),
 		s.Database,
 		msgRepo,
 		outboxSvc,
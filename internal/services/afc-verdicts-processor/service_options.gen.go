@@ -5,6 +5,7 @@ import (
 	fmt461e464ebed9 "fmt"
 	"time"
 
+	"github.com/gerladeno/chat-service/internal/broker"
 	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
 	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
 )
@@ -12,12 +13,11 @@ import (
 type OptOptionsSetter func(o *Options)
 
 func NewOptions(
-	brokers []string,
 	consumers int,
 	consumerGroup string,
 	verdictsTopic string,
-	readerFactory KafkaReaderFactory,
-	dlqWriter KafkaDLQWriter,
+	subscriberFactory SubscriberFactory,
+	dlqPublisher broker.Publisher,
 	txtor transactor,
 	msgRepo messagesRepository,
 	outBox outboxService,
@@ -29,16 +29,17 @@ func NewOptions(
 	o.backoffInitialInterval, _ = time.ParseDuration("100ms")
 	o.backoffMaxElapsedTime, _ = time.ParseDuration("5s")
 	o.backoffFactor = 5
+	o.jwksReloadInterval, _ = time.ParseDuration("10s")
 	o.processBatchSize = 1
 	o.processBatchMaxTimeout, _ = time.ParseDuration("100ms")
 	o.retries = 3
+	o.dlqBackoffMaxInterval, _ = time.ParseDuration("30s")
 
-	o.brokers = brokers
 	o.consumers = consumers
 	o.consumerGroup = consumerGroup
 	o.verdictsTopic = verdictsTopic
-	o.readerFactory = readerFactory
-	o.dlqWriter = dlqWriter
+	o.subscriberFactory = subscriberFactory
+	o.dlqPublisher = dlqPublisher
 	o.txtor = txtor
 	o.msgRepo = msgRepo
 	o.outBox = outBox
@@ -73,6 +74,24 @@ func WithVerdictsSignKey(opt string) OptOptionsSetter {
 	}
 }
 
+func WithVerdictsSignKeys(opt map[string]string) OptOptionsSetter {
+	return func(o *Options) {
+		o.verdictsSignKeys = opt
+	}
+}
+
+func WithVerdictsJWKSFile(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.verdictsJWKSFile = opt
+	}
+}
+
+func WithJwksReloadInterval(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.jwksReloadInterval = opt
+	}
+}
+
 func WithProcessBatchSize(opt int) OptOptionsSetter {
 	return func(o *Options) {
 		o.processBatchSize = opt
@@ -91,20 +110,53 @@ func WithRetries(opt int) OptOptionsSetter {
 	}
 }
 
+func WithDlqBackoffMaxInterval(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.dlqBackoffMaxInterval = opt
+	}
+}
+
+func WithConflictPolicy(opt ConflictPolicy) OptOptionsSetter {
+	return func(o *Options) {
+		o.conflictPolicy = opt
+	}
+}
+
+func WithLocalVerdictPrecedence(opt LocalVerdictPrecedence) OptOptionsSetter {
+	return func(o *Options) {
+		o.localVerdictPrecedence = opt
+	}
+}
+
+func WithBodyRedactor(opt bodyRedactor) OptOptionsSetter {
+	return func(o *Options) {
+		o.bodyRedactor = opt
+	}
+}
+
+func WithClock(opt Clock) OptOptionsSetter {
+	return func(o *Options) {
+		o.clock = opt
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("backoffInitialInterval", _validate_Options_backoffInitialInterval(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("backoffMaxElapsedTime", _validate_Options_backoffMaxElapsedTime(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("backoffFactor", _validate_Options_backoffFactor(o)))
-	errs.Add(errors461e464ebed9.NewValidationError("brokers", _validate_Options_brokers(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("consumers", _validate_Options_consumers(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("consumerGroup", _validate_Options_consumerGroup(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("verdictsTopic", _validate_Options_verdictsTopic(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("jwksReloadInterval", _validate_Options_jwksReloadInterval(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("processBatchSize", _validate_Options_processBatchSize(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("processBatchMaxTimeout", _validate_Options_processBatchMaxTimeout(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("retries", _validate_Options_retries(o)))
-	errs.Add(errors461e464ebed9.NewValidationError("readerFactory", _validate_Options_readerFactory(o)))
-	errs.Add(errors461e464ebed9.NewValidationError("dlqWriter", _validate_Options_dlqWriter(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("dlqBackoffMaxInterval", _validate_Options_dlqBackoffMaxInterval(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("conflictPolicy", _validate_Options_conflictPolicy(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("localVerdictPrecedence", _validate_Options_localVerdictPrecedence(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("subscriberFactory", _validate_Options_subscriberFactory(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("dlqPublisher", _validate_Options_dlqPublisher(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("txtor", _validate_Options_txtor(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("msgRepo", _validate_Options_msgRepo(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("outBox", _validate_Options_outBox(o)))
@@ -132,13 +184,6 @@ func _validate_Options_backoffFactor(o *Options) error {
 	return nil
 }
 
-func _validate_Options_brokers(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.brokers, "min=1"); err != nil {
-		return fmt461e464ebed9.Errorf("field `brokers` did not pass the test: %w", err)
-	}
-	return nil
-}
-
 func _validate_Options_consumers(o *Options) error {
 	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.consumers, "min=1,max=16"); err != nil {
 		return fmt461e464ebed9.Errorf("field `consumers` did not pass the test: %w", err)
@@ -160,6 +205,13 @@ func _validate_Options_verdictsTopic(o *Options) error {
 	return nil
 }
 
+func _validate_Options_jwksReloadInterval(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.jwksReloadInterval, "min=10ms,max=1h"); err != nil {
+		return fmt461e464ebed9.Errorf("field `jwksReloadInterval` did not pass the test: %w", err)
+	}
+	return nil
+}
+
 func _validate_Options_processBatchSize(o *Options) error {
 	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.processBatchSize, "min=1,max=1000"); err != nil {
 		return fmt461e464ebed9.Errorf("field `processBatchSize` did not pass the test: %w", err)
@@ -181,16 +233,37 @@ func _validate_Options_retries(o *Options) error {
 	return nil
 }
 
-func _validate_Options_readerFactory(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.readerFactory, "required"); err != nil {
-		return fmt461e464ebed9.Errorf("field `readerFactory` did not pass the test: %w", err)
+func _validate_Options_dlqBackoffMaxInterval(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.dlqBackoffMaxInterval, "min=100ms,max=10m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `dlqBackoffMaxInterval` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_conflictPolicy(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.conflictPolicy, "omitempty,oneof=suspicious_wins latest_wins"); err != nil {
+		return fmt461e464ebed9.Errorf("field `conflictPolicy` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_localVerdictPrecedence(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.localVerdictPrecedence, "omitempty,oneof=local afc"); err != nil {
+		return fmt461e464ebed9.Errorf("field `localVerdictPrecedence` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_subscriberFactory(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.subscriberFactory, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `subscriberFactory` did not pass the test: %w", err)
 	}
 	return nil
 }
 
-func _validate_Options_dlqWriter(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.dlqWriter, "required"); err != nil {
-		return fmt461e464ebed9.Errorf("field `dlqWriter` did not pass the test: %w", err)
+func _validate_Options_dlqPublisher(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.dlqPublisher, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `dlqPublisher` did not pass the test: %w", err)
 	}
 	return nil
 }
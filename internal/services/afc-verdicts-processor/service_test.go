@@ -2,17 +2,32 @@ package afcverdictsprocessor_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
-	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/gerladeno/chat-service/internal/broker"
+	brokermocks "github.com/gerladeno/chat-service/internal/broker/mocks"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
 	afcverdictsprocessor "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor"
 	afcverdictsprocessormocks "github.com/gerladeno/chat-service/internal/services/afc-verdicts-processor/mocks"
 	clientmessageblockedjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-blocked"
@@ -36,10 +51,11 @@ type ServiceSuite struct {
 	outboxSvc   *afcverdictsprocessormocks.MockoutboxService
 	msgRepo     *afcverdictsprocessormocks.MockmessagesRepository
 	transactor  *afcverdictsprocessormocks.Mocktransactor
-	consumer    *afcverdictsprocessormocks.MockKafkaReader
-	dlqProducer *afcverdictsprocessormocks.MockKafkaDLQWriter
+	consumer    *brokermocks.MockSubscriber
+	dlqProducer *brokermocks.MockPublisher
 
 	signPrivateKey *rsa.PrivateKey
+	clock          *fakeClock
 	svc            *afcverdictsprocessor.Service
 }
 
@@ -69,8 +85,8 @@ func (s *ServiceSuite) SetupTest() {
 			return f(ctx)
 		}).AnyTimes()
 
-	s.consumer = afcverdictsprocessormocks.NewMockKafkaReader(s.ctrl)
-	s.dlqProducer = afcverdictsprocessormocks.NewMockKafkaDLQWriter(s.ctrl)
+	s.consumer = brokermocks.NewMockSubscriber(s.ctrl)
+	s.dlqProducer = brokermocks.NewMockPublisher(s.ctrl)
 
 	if k := s.SignPrivateKey; k != "" {
 		var err error
@@ -78,31 +94,40 @@ func (s *ServiceSuite) SetupTest() {
 		s.Require().NoError(err)
 	}
 
-	var err error
-	s.svc, err = afcverdictsprocessor.New(afcverdictsprocessor.NewOptions(
-		[]string{"test:9092"},
+	s.clock = newFakeClock()
+	s.svc = s.newService()
+
+	// Always.
+	s.consumer.EXPECT().Close().Return(nil)
+	s.dlqProducer.EXPECT().Close().Return(nil)
+}
+
+func (s *ServiceSuite) newService(opts ...afcverdictsprocessor.OptOptionsSetter) *afcverdictsprocessor.Service {
+	s.T().Helper()
+
+	opts = append([]afcverdictsprocessor.OptOptionsSetter{
+		afcverdictsprocessor.WithVerdictsSignKey(s.SignPubKey),
+		afcverdictsprocessor.WithBackoffInitialInterval(backoffInitialInterval),
+		afcverdictsprocessor.WithBackoffMaxElapsedTime(backoffMaxElapsedTime),
+		afcverdictsprocessor.WithClock(s.clock),
+	}, opts...)
+	svc, err := afcverdictsprocessor.New(afcverdictsprocessor.NewOptions(
 		1,
 		"afcverdictsprocessor_test.ServiceSuite",
 		"afc.unit-test.verdicts",
-		func(brokers []string, groupID string, topic string) afcverdictsprocessor.KafkaReader {
-			s.Equal([]string{"test:9092"}, brokers)
+		func(groupID string, topic string) (broker.Subscriber, error) {
 			s.Equal("afcverdictsprocessor_test.ServiceSuite", groupID)
 			s.Equal("afc.unit-test.verdicts", topic)
-			return s.consumer
+			return s.consumer, nil
 		},
 		s.dlqProducer,
 		s.transactor,
 		s.msgRepo,
 		s.outboxSvc,
-		afcverdictsprocessor.WithVerdictsSignKey(s.SignPubKey),
-		afcverdictsprocessor.WithBackoffInitialInterval(backoffInitialInterval),
-		afcverdictsprocessor.WithBackoffMaxElapsedTime(backoffMaxElapsedTime),
+		opts...,
 	))
 	s.Require().NoError(err)
-
-	// Always.
-	s.consumer.EXPECT().Close().Return(nil)
-	s.dlqProducer.EXPECT().Close().Return(nil)
+	return svc
 }
 
 func (s *ServiceSuite) TearDownTest() {
@@ -116,11 +141,11 @@ func (s *ServiceSuite) TestNotRetriableError_InvalidEncoding() {
   "chatId": "2d1bb2b4-1e11-11ed-9c9f-461e464ebed9",
   "messageId": "b611c338-1e11-11ed-b5ce-461e464ebed9",
   "status": "ok"`)
-	msg := kafka.Message{Value: v}
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(msg, nil)
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(kafka.Message{}, io.EOF).MaxTimes(1)
-	s.consumer.EXPECT().CommitMessages(gomock.Any(), msg)
-	s.dlqProducer.EXPECT().WriteMessages(gomock.Any(), kafkaMsgValueMatcher{v})
+	msg := broker.Message{Value: v}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+	s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{v})
 
 	// Action & assert.
 	s.runProcessorFor(100 * time.Millisecond)
@@ -135,14 +160,42 @@ func (s *ServiceSuite) TestNotRetriableError_InvalidVerdict() {
 	}
 	data := []byte(s.encode(v))
 
-	msg := kafka.Message{Value: data}
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(msg, nil)
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(kafka.Message{}, io.EOF).MaxTimes(1)
-	s.consumer.EXPECT().CommitMessages(gomock.Any(), msg)
-	s.dlqProducer.EXPECT().WriteMessages(gomock.Any(), kafkaMsgValueMatcher{data})
+	msg := broker.Message{Value: data}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+	s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{data})
 
-	// Action & assert.
+	// Action.
 	s.runProcessorFor(100 * time.Millisecond)
+
+	// Assert.
+	s.Empty(s.clock.Sleeps())
+}
+
+func (s *ServiceSuite) TestNotRetriableError_MessageNotFound() {
+	// Arrange.
+	msgID := types.NewMessageID()
+	v := verdict{
+		ChatID:    "2d1bb2b4-1e11-11ed-9c9f-461e464ebed9",
+		MessageID: msgID.String(),
+		Status:    "suspicious",
+	}
+	data := []byte(s.encode(v))
+
+	msg := broker.Message{Value: data}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().BlockMessage(gomock.Any(), msgID, "afc", gomock.Any()).Return(messagesrepo.ErrMsgNotFound)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+	s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{data})
+
+	// Action.
+	s.runProcessorFor(100 * time.Millisecond)
+
+	// Assert.
+	s.Empty(s.clock.Sleeps())
 }
 
 func (s *ServiceSuite) TestOperationRetriedWithSuccess() {
@@ -155,17 +208,23 @@ func (s *ServiceSuite) TestOperationRetriedWithSuccess() {
 	}
 	data := []byte(s.encode(v))
 
-	msg := kafka.Message{Value: data}
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(msg, nil)
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(kafka.Message{}, io.EOF).MaxTimes(1)
-	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID).Return(context.Canceled)
-	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID).Return(context.Canceled)
-	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID).Return(nil)
-	s.outboxSvc.EXPECT().Put(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any())
-	s.consumer.EXPECT().CommitMessages(gomock.Any(), msg)
+	msg := broker.Message{Value: data}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(context.Canceled)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(context.Canceled)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+
+	// Action.
+	s.runProcessorFor(100 * time.Millisecond)
 
-	// Action & assert.
-	s.runProcessorFor(backoffMaxElapsedTime)
+	// Assert.
+	s.Equal([]time.Duration{backoffInitialInterval, 5 * backoffInitialInterval}, s.clock.Sleeps())
 }
 
 func (s *ServiceSuite) TestOperationBackoffExceeded() {
@@ -178,15 +237,54 @@ func (s *ServiceSuite) TestOperationBackoffExceeded() {
 	}
 	data := []byte(s.encode(v))
 
-	msg := kafka.Message{Value: data}
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(msg, nil)
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(kafka.Message{}, io.EOF).MaxTimes(1)
-	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID).Return(context.Canceled).AnyTimes()
-	s.consumer.EXPECT().CommitMessages(gomock.Any(), msg)
-	s.dlqProducer.EXPECT().WriteMessages(gomock.Any(), kafkaMsgValueMatcher{data})
+	msg := broker.Message{Value: data}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil).Times(3)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(context.Canceled).Times(3)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+	s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{data})
 
-	// Action & assert.
-	s.runProcessorFor(2 * backoffMaxElapsedTime)
+	// Action.
+	s.runProcessorFor(100 * time.Millisecond)
+
+	// Assert.
+	s.Equal([]time.Duration{backoffInitialInterval, 5 * backoffInitialInterval}, s.clock.Sleeps())
+}
+
+func (s *ServiceSuite) TestOperationBackoffMaxElapsedTimeExceeded() {
+	// Arrange.
+	s.svc = s.newService(
+		afcverdictsprocessor.WithRetries(10),
+		afcverdictsprocessor.WithBackoffFactor(2),
+	)
+
+	msgID := types.NewMessageID()
+	v := verdict{
+		ChatID:    "2d1bb2b4-1e11-11ed-9c9f-461e464ebed9",
+		MessageID: msgID.String(),
+		Status:    "ok",
+	}
+	data := []byte(s.encode(v))
+
+	msg := broker.Message{Value: data}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	// 50ms + 100ms + 200ms fit into backoffMaxElapsedTime, the next 400ms pause doesn't.
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil).Times(4)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(context.Canceled).Times(4)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+	s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{data})
+
+	// Action.
+	s.runProcessorFor(100 * time.Millisecond)
+
+	// Assert.
+	s.Equal([]time.Duration{
+		backoffInitialInterval,
+		2 * backoffInitialInterval,
+		4 * backoffInitialInterval,
+	}, s.clock.Sleeps())
 }
 
 func (s *ServiceSuite) TestProcessMessagesWithoutErrors() {
@@ -208,23 +306,392 @@ func (s *ServiceSuite) TestProcessMessagesWithoutErrors() {
 	for _, v := range verdicts {
 		data := []byte(s.encode(v))
 
-		msg := kafka.Message{Value: data}
-		s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(msg, nil)
+		msg := broker.Message{Value: data}
+		msgID := types.MustParse[types.MessageID](v.MessageID)
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+		s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
 		if v.Status == "ok" {
-			s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), types.MustParse[types.MessageID](v.MessageID)).Return(nil)
-			s.outboxSvc.EXPECT().Put(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any())
+			s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(nil)
+			s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
 		} else {
-			s.msgRepo.EXPECT().BlockMessage(gomock.Any(), types.MustParse[types.MessageID](v.MessageID))
-			s.outboxSvc.EXPECT().Put(gomock.Any(), clientmessageblockedjob.Name, gomock.Any(), gomock.Any())
+			s.msgRepo.EXPECT().BlockMessage(gomock.Any(), msgID, "afc", gomock.Any())
+			s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessageblockedjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
 		}
-		s.consumer.EXPECT().CommitMessages(gomock.Any(), msg)
+		s.consumer.EXPECT().Commit(gomock.Any(), msg)
+	}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+
+	// Action & assert.
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestDLQWriteFailureBlocksPartition() {
+	// Arrange.
+	msgID := types.NewMessageID()
+	msg1 := broker.Message{Partition: 1, Offset: 1, Value: []byte(s.encode(verdict{
+		ChatID:    types.NewChatID().String(),
+		MessageID: msgID.String(),
+		Status:    "ok",
+	}))}
+	msg2 := broker.Message{Partition: 1, Offset: 2, Value: []byte(`{"status": "ok"`)}
+	msg3 := broker.Message{Partition: 1, Offset: 3, Value: msg1.Value}
+
+	gomock.InOrder(
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg1, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg2, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg3, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).DoAndReturn(waitForCancel).AnyTimes(),
+	)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+	s.consumer.EXPECT().Commit(gomock.Any(), msg1)
+
+	blocked := make(chan struct{})
+	gomock.InOrder(
+		s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{msg2.Value}).
+			Return(errors.New("unexpected")).Times(5),
+		s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{msg2.Value}).
+			DoAndReturn(func(ctx context.Context, _ broker.Message) error {
+				close(blocked)
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+	)
+
+	// Action.
+	cancel, errCh := s.runProcessor()
+	defer cancel()
+
+	// Assert.
+	select {
+	case <-blocked:
+	case err := <-errCh:
+		s.FailNow("processor is stopped", err)
+	case <-time.After(time.Second):
+		s.FailNow("verdict is not retried")
 	}
-	s.consumer.EXPECT().FetchMessage(gomock.Any()).Return(kafka.Message{}, io.EOF).MaxTimes(1)
+	cancel()
+	s.NoError(<-errCh)
+	s.Equal([]time.Duration{
+		backoffInitialInterval,
+		5 * backoffInitialInterval,
+		25 * backoffInitialInterval,
+		125 * backoffInitialInterval,
+		30 * time.Second,
+	}, s.clock.Sleeps())
+}
+
+func (s *ServiceSuite) TestDLQWriteRecoveryUnblocksPartition() {
+	// Arrange.
+	msgID := types.NewMessageID()
+	msg1 := broker.Message{Partition: 1, Offset: 1, Value: []byte(`{"status": "ok"`)}
+	msg2 := broker.Message{Partition: 1, Offset: 2, Value: []byte(s.encode(verdict{
+		ChatID:    types.NewChatID().String(),
+		MessageID: msgID.String(),
+		Status:    "ok",
+	}))}
+
+	gomock.InOrder(
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg1, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg2, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).DoAndReturn(waitForCancel).AnyTimes(),
+	)
+	gomock.InOrder(
+		s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{msg1.Value}).
+			Return(errors.New("unexpected")).Times(4),
+		s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{msg1.Value}).Return(nil),
+		s.consumer.EXPECT().Commit(gomock.Any(), msg1),
+	)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+	s.consumer.EXPECT().Commit(gomock.Any(), msg2)
 
 	// Action & assert.
 	s.runProcessorFor(100 * time.Millisecond)
 }
 
+func (s *ServiceSuite) TestPartitionsProcessedConcurrently() {
+	// Arrange.
+	newMsg := func(partition int, offset int64) (broker.Message, types.MessageID) {
+		msgID := types.NewMessageID()
+		return broker.Message{Partition: partition, Offset: offset, Value: []byte(s.encode(verdict{
+			ChatID:    types.NewChatID().String(),
+			MessageID: msgID.String(),
+			Status:    "ok",
+		}))}, msgID
+	}
+	slow1, slowID1 := newMsg(0, 1)
+	slow2, slowID2 := newMsg(0, 2)
+	fast, fastID := newMsg(1, 1)
+
+	gomock.InOrder(
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(slow1, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(slow2, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(fast, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).DoAndReturn(waitForCancel).AnyTimes(),
+	)
+
+	// The first verdict of partition 0 waits until partition 1 is committed.
+	fastCommitted := make(chan struct{})
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), slowID1).DoAndReturn(
+		func(ctx context.Context, _ types.MessageID) (*messagesrepo.Verdict, error) {
+			select {
+			case <-fastCommitted:
+				return nil, nil
+			case <-time.After(time.Second):
+				return nil, errors.New("partitions are processed sequentially")
+			}
+		})
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	gomock.InOrder(
+		s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), slowID1, "afc", gomock.Any()).Return(nil),
+		s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), slowID2, "afc", gomock.Any()).Return(nil),
+	)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), fastID, "afc", gomock.Any()).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(3)
+	s.consumer.EXPECT().Commit(gomock.Any(), fast).Do(func(context.Context, ...broker.Message) {
+		close(fastCommitted)
+	})
+	s.consumer.EXPECT().Commit(gomock.Any(), slow1)
+	s.consumer.EXPECT().Commit(gomock.Any(), slow2)
+
+	// Action & assert.
+	s.runProcessorFor(200 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestRedeliveredVerdictIsSkipped() {
+	s.expectVerdict(afcVerdict(messagesrepo.VerdictStatusOK, time.Now().Add(-time.Minute)), "ok", false)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestSuspiciousWins_SuspiciousOverridesOK() {
+	s.expectVerdict(afcVerdict(messagesrepo.VerdictStatusOK, time.Now().Add(time.Hour)), "suspicious", true)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestSuspiciousWins_OKDoesNotOverrideSuspicious() {
+	s.expectVerdict(afcVerdict(messagesrepo.VerdictStatusSuspicious, time.Now().Add(-time.Hour)), "ok", false)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestLatestWins_NewerVerdictOverrides() {
+	s.svc = s.newService(afcverdictsprocessor.WithConflictPolicy(afcverdictsprocessor.ConflictPolicyLatestWins))
+	s.expectVerdict(afcVerdict(messagesrepo.VerdictStatusSuspicious, time.Now().Add(-time.Hour)), "ok", true)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestLatestWins_OlderVerdictIsSkipped() {
+	s.svc = s.newService(afcverdictsprocessor.WithConflictPolicy(afcverdictsprocessor.ConflictPolicyLatestWins))
+	s.expectVerdict(afcVerdict(messagesrepo.VerdictStatusOK, time.Now().Add(time.Hour)), "suspicious", false)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestLocalVerdictPrecedence_LocalWins() {
+	local := messagesrepo.Verdict{Status: messagesrepo.VerdictStatusOK, Source: "local", At: time.Now().Add(-time.Minute)}
+	s.expectVerdict(local, "suspicious", false)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestLocalVerdictPrecedence_AFCWins() {
+	s.svc = s.newService(afcverdictsprocessor.WithLocalVerdictPrecedence(afcverdictsprocessor.LocalVerdictPrecedenceAFC))
+	local := messagesrepo.Verdict{Status: messagesrepo.VerdictStatusSuspicious, Source: "local", At: time.Now()}
+	s.expectVerdict(local, "ok", true)
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestBlockedMessageBodyRedacted() {
+	redactor := afcverdictsprocessormocks.NewMockbodyRedactor(s.ctrl)
+	s.svc = s.newService(afcverdictsprocessor.WithBodyRedactor(redactor))
+
+	msgID := types.NewMessageID()
+	msg := broker.Message{
+		Value: []byte(s.encode(verdict{
+			ChatID:    types.NewChatID().String(),
+			MessageID: msgID.String(),
+			Status:    "suspicious",
+		})),
+		Time: time.Now(),
+	}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(nil, nil)
+	s.msgRepo.EXPECT().BlockMessage(gomock.Any(), msgID, "afc", msg.Time).Return(nil)
+	redactor.EXPECT().RedactMessage(gomock.Any(), msgID).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessageblockedjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+func (s *ServiceSuite) TestVerdictContinuesMessageTrace() {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	msgID := types.NewMessageID()
+	msg := broker.Message{
+		Value: []byte(s.encode(verdict{
+			ChatID:    types.NewChatID().String(),
+			MessageID: msgID.String(),
+			Status:    "ok",
+		})),
+		Headers: []broker.Header{
+			{Key: msgenvelope.HeaderRequestID, Value: []byte(types.NewRequestID().String())},
+			{Key: msgenvelope.HeaderTraceParent, Value: []byte("00-" + traceID + "-" + spanID + "-01")},
+		},
+	}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).DoAndReturn(
+		func(ctx context.Context, _ types.MessageID) (*messagesrepo.Verdict, error) {
+			span := sentry.TransactionFromContext(ctx)
+			s.Require().NotNil(span)
+			s.Equal(traceID, span.TraceID.String())
+			s.Equal(spanID, span.ParentSpanID.String())
+			return nil, nil
+		})
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", gomock.Any()).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+
+	s.runProcessorFor(100 * time.Millisecond)
+}
+
+// expectVerdict expects the verdict with the given status to be processed
+// for the message already checked with the applied verdict.
+func (s *ServiceSuite) expectVerdict(applied messagesrepo.Verdict, status string, overrides bool) {
+	s.T().Helper()
+
+	msgID := types.NewMessageID()
+	msg := broker.Message{
+		Value: []byte(s.encode(verdict{
+			ChatID:    types.NewChatID().String(),
+			MessageID: msgID.String(),
+			Status:    status,
+		})),
+		Time: time.Now(),
+	}
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg, nil)
+	s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1)
+	applied.CheckedAt = applied.At
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(&applied, nil)
+	if overrides {
+		if status == "ok" {
+			s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID, "afc", msg.Time).Return(nil)
+			s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+		} else {
+			s.msgRepo.EXPECT().BlockMessage(gomock.Any(), msgID, "afc", msg.Time).Return(nil)
+			s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessageblockedjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+		}
+	}
+	s.consumer.EXPECT().Commit(gomock.Any(), msg)
+}
+
+func (s *ServiceSuite) TestSigningKeysRotation() {
+	// Arrange.
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	newPubKey, newKey, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	jwksFile := filepath.Join(s.T().TempDir(), "jwks.json")
+	s.writeJWKS(jwksFile, ecJWK("old", &oldKey.PublicKey))
+	s.svc = s.newService(
+		afcverdictsprocessor.WithVerdictsJWKSFile(jwksFile),
+		afcverdictsprocessor.WithJwksReloadInterval(10*time.Millisecond),
+	)
+
+	newVerdict := func() (verdict, types.MessageID) {
+		msgID := types.NewMessageID()
+		return verdict{ChatID: types.NewChatID().String(), MessageID: msgID.String(), Status: "ok"}, msgID
+	}
+	v1, msgID1 := newVerdict()
+	msg1 := broker.Message{Value: []byte(s.sign(v1, jwt.SigningMethodES256, "old", oldKey))}
+	v2, msgID2 := newVerdict()
+	msg2 := broker.Message{Value: []byte(s.sign(v2, jwt.SigningMethodEdDSA, "new", newKey))}
+	v3, _ := newVerdict()
+	msg3 := broker.Message{Value: []byte(s.sign(v3, jwt.SigningMethodES256, "old", oldKey))}
+
+	rotated := make(chan struct{})
+	gomock.InOrder(
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg1, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).DoAndReturn(func(ctx context.Context) (broker.Message, error) {
+			select {
+			case <-ctx.Done():
+				return broker.Message{}, ctx.Err()
+			case <-rotated:
+				return msg2, nil
+			}
+		}),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(msg3, nil),
+		s.consumer.EXPECT().Fetch(gomock.Any()).Return(broker.Message{}, io.EOF).MaxTimes(1),
+	)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID1).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID1, "afc", gomock.Any()).Return(nil)
+	s.msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID2).Return(nil, nil)
+	s.msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), msgID2, "afc", gomock.Any()).Return(nil)
+	s.outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	s.dlqProducer.EXPECT().Publish(gomock.Any(), msgValueMatcher{msg3.Value}) // The old key is revoked.
+	s.consumer.EXPECT().Commit(gomock.Any(), msg1)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg2)
+	s.consumer.EXPECT().Commit(gomock.Any(), msg3)
+
+	// Action.
+	cancel, errCh := s.runProcessor()
+	defer cancel()
+
+	time.Sleep(100 * time.Millisecond)
+	s.writeJWKS(jwksFile, edJWK("new", newPubKey))
+	time.Sleep(100 * time.Millisecond)
+	close(rotated)
+	time.Sleep(100 * time.Millisecond)
+
+	// Assert.
+	cancel()
+	s.NoError(<-errCh)
+}
+
+func (s *ServiceSuite) writeJWKS(path string, keys ...string) {
+	s.T().Helper()
+
+	data := fmt.Sprintf(`{"keys": [%s]}`, strings.Join(keys, ","))
+	s.Require().NoError(os.WriteFile(path, []byte(data), 0o600))
+}
+
+func (s *ServiceSuite) sign(v verdict, method jwt.SigningMethod, kid string, key any) string {
+	s.T().Helper()
+
+	token := jwt.NewWithClaims(method, v)
+	token.Header["kid"] = kid
+	result, err := token.SignedString(key)
+	s.Require().NoError(err)
+	return result
+}
+
+func ecJWK(kid string, k *ecdsa.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "EC", "kid": %q, "crv": "P-256", "x": %q, "y": %q}`, kid,
+		base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, 32))),
+		base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, 32))))
+}
+
+func edJWK(kid string, k ed25519.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "OKP", "kid": %q, "crv": "Ed25519", "x": %q}`, kid,
+		base64.RawURLEncoding.EncodeToString(k))
+}
+
+func afcVerdict(status messagesrepo.VerdictStatus, at time.Time) messagesrepo.Verdict {
+	return messagesrepo.Verdict{Status: status, Source: "afc", At: at}
+}
+
+func waitForCancel(ctx context.Context) (broker.Message, error) {
+	<-ctx.Done()
+	return broker.Message{}, ctx.Err()
+}
+
 func (s *ServiceSuite) runProcessorFor(timeout time.Duration) {
 	s.T().Helper()
 
@@ -261,6 +728,40 @@ func (s *ServiceSuite) encode(v verdict) string {
 	return string(result)
 }
 
+// fakeClock fires timers immediately and moves the time forward by their durations.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Now()}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
 type verdict struct {
 	ChatID    string `json:"chatId"`
 	MessageID string `json:"messageId"`
@@ -269,21 +770,21 @@ type verdict struct {
 
 func (v verdict) Valid() error { return nil }
 
-var _ gomock.Matcher = kafkaMsgValueMatcher{}
+var _ gomock.Matcher = msgValueMatcher{}
 
-type kafkaMsgValueMatcher struct {
+type msgValueMatcher struct {
 	v []byte
 }
 
-func (km kafkaMsgValueMatcher) Matches(x interface{}) bool {
-	v, ok := x.(kafka.Message)
+func (km msgValueMatcher) Matches(x interface{}) bool {
+	v, ok := x.(broker.Message)
 	if !ok {
 		return false
 	}
 	return string(v.Value) == string(km.v)
 }
 
-func (km kafkaMsgValueMatcher) String() string {
+func (km msgValueMatcher) String() string {
 	return string(km.v)
 }
 
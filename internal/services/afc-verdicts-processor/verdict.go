@@ -0,0 +1,151 @@
+package afcverdictsprocessor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	clientmessageblockedjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-blocked"
+	clientmessagesentjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-sent"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// ConflictPolicy decides what to do with a verdict for the message checked before with another outcome.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicySuspiciousWins never unblocks the message, but blocks the one considered ok before.
+	ConflictPolicySuspiciousWins ConflictPolicy = "suspicious_wins"
+	// ConflictPolicyLatestWins applies the verdict issued later.
+	ConflictPolicyLatestWins ConflictPolicy = "latest_wins"
+)
+
+// overrides reports whether the verdict should replace the applied one.
+// The verdict with the same outcome is never applied twice.
+func (p ConflictPolicy) overrides(applied messagesrepo.Verdict, status messagesrepo.VerdictStatus, at time.Time) bool {
+	if applied.Status == status {
+		return false
+	}
+	switch p {
+	case ConflictPolicyLatestWins:
+		return at.After(applied.At)
+	default:
+		return status == messagesrepo.VerdictStatusSuspicious
+	}
+}
+
+// LocalVerdictPrecedence decides whether the AFC verdict overrides the one applied by the local fallback check.
+type LocalVerdictPrecedence string
+
+const (
+	// LocalVerdictPrecedenceLocal ignores the AFC verdicts coming after the local one.
+	LocalVerdictPrecedenceLocal LocalVerdictPrecedence = "local"
+	// LocalVerdictPrecedenceAFC applies the AFC verdict with another outcome over the local one.
+	LocalVerdictPrecedenceAFC LocalVerdictPrecedence = "afc"
+)
+
+// verdictRule names the setting that decided whether the verdict overrides the applied one, for the audit log.
+type verdictRule struct {
+	name  string
+	value string
+}
+
+// overrides reports whether the verdict should replace the applied one and the rule that decided it.
+func (s *Service) overrides(
+	applied messagesrepo.Verdict,
+	status messagesrepo.VerdictStatus,
+	at time.Time,
+) (bool, verdictRule) {
+	if applied.Source == messagesrepo.VerdictSourceLocal {
+		rule := verdictRule{name: "local_verdict_precedence", value: string(s.localVerdictPrecedence)}
+		return s.localVerdictPrecedence == LocalVerdictPrecedenceAFC && applied.Status != status, rule
+	}
+	rule := verdictRule{name: "conflict_policy", value: string(s.conflictPolicy)}
+	return s.conflictPolicy.overrides(applied, status, at), rule
+}
+
+// processVerdict applies the verdict issued at the given time and notifies the client.
+func (s *Service) processVerdict(ctx context.Context, log *zap.Logger, msgID types.MessageID, v verdict, at time.Time) error {
+	status := messagesrepo.VerdictStatus(v.Status)
+	if status != messagesrepo.VerdictStatusOK && status != messagesrepo.VerdictStatusSuspicious {
+		return ErrUnknownStatus
+	}
+
+	var overridden *messagesrepo.Verdict
+	var skipped bool
+	var rule verdictRule
+	err := s.txtor.RunInTx(ctx, func(ctx context.Context) error {
+		applied, err := s.msgRepo.GetVerdictForUpdate(ctx, msgID)
+		if err != nil {
+			return fmt.Errorf("get applied verdict: %w", err)
+		}
+		if applied != nil {
+			var ok bool
+			if ok, rule = s.overrides(*applied, status, at); !ok {
+				skipped = true
+				return nil
+			}
+		}
+		overridden = applied
+		return s.applyVerdict(ctx, msgID, status, messagesrepo.VerdictSourceAFC, at)
+	})
+	if err != nil {
+		return err
+	}
+
+	log = log.With(zap.Stringer("msg_id", msgID), zap.String("status", v.Status))
+	switch {
+	case skipped:
+		log.Debug("verdict skipped, the message is already checked", zap.String(rule.name, rule.value))
+	case overridden != nil:
+		log.Named("audit").Warn("verdict overridden",
+			zap.String("rule", rule.name),
+			zap.String("policy", rule.value),
+			zap.String("source", messagesrepo.VerdictSourceAFC),
+			zap.Time("verdict_at", at),
+			zap.String("previous_status", string(overridden.Status)),
+			zap.String("previous_source", overridden.Source),
+			zap.Time("previous_verdict_at", overridden.At),
+			zap.Time("previous_checked_at", overridden.CheckedAt),
+		)
+	}
+	return nil
+}
+
+func (s *Service) applyVerdict(
+	ctx context.Context,
+	msgID types.MessageID,
+	status messagesrepo.VerdictStatus,
+	source string,
+	at time.Time,
+) error {
+	if status == messagesrepo.VerdictStatusOK {
+		if err := s.msgRepo.MarkAsVisibleForManager(ctx, msgID, source, at); err != nil {
+			return fmt.Errorf("mark visible for manager: %w", err)
+		}
+		if _, err := s.outBox.PutUnique(ctx,
+			clientmessagesentjob.Name, msgID.String(), clientmessagesentjob.DedupKey(msgID), time.Now(),
+		); err != nil {
+			return fmt.Errorf("put job %s: %v", clientmessagesentjob.Name, err)
+		}
+		return nil
+	}
+
+	if err := s.msgRepo.BlockMessage(ctx, msgID, source, at); err != nil {
+		return fmt.Errorf("block message: %w", err)
+	}
+	if s.bodyRedactor != nil {
+		if err := s.bodyRedactor.RedactMessage(ctx, msgID); err != nil {
+			return fmt.Errorf("redact message: %w", err)
+		}
+	}
+	if _, err := s.outBox.PutUnique(ctx,
+		clientmessageblockedjob.Name, msgID.String(), clientmessageblockedjob.DedupKey(msgID), time.Now(),
+	); err != nil {
+		return fmt.Errorf("put job %s: %v", clientmessageblockedjob.Name, err)
+	}
+	return nil
+}
@@ -1,50 +1,162 @@
 package bloodhound
 
 import (
-	"regexp"
+	"math/big"
 	"strings"
+	"sync"
 )
 
-var (
-	patternCardCVC    = regexp.MustCompile(`(\D|^|\n)\d{3}(\D|$|\n)`)
-	patternCardNumber = regexp.MustCompile(`\d{4}[- ]?\d{4}[- ]?\d{4}[- ]?\d{4}`)
-	patternCardDate   = regexp.MustCompile(`\d{2}/\d{2}`)
-	patternSMSCode    = regexp.MustCompile(`10-\d{4}`)
+var defaultSearcher = NewSearcher()
 
-	suspiciousPhrases = map[string]struct{}{
-		`login`:    {},
-		`password`: {},
-		`логин`:    {},
-		`парол`:    {},
+// Search tries to find sensitive information in the message with the default rules and returns Verdict.
+func Search(msg string) (Verdict, error) {
+	return defaultSearcher.Search(msg)
+}
+
+// Searcher looks for the facts of the enabled detectors only.
+// Its rules can be replaced at any moment, e.g. by the watched rules file.
+type Searcher struct {
+	enabled map[Fact]struct{} // Nil if all the facts are enabled.
+
+	mu        sync.RWMutex
+	rules     []compiledRule
+	rulesData []byte
+}
+
+// NewSearcher enables the detectors of the given facts, all the detectors if no facts given.
+// The searcher uses the DefaultRules.
+func NewSearcher(facts ...Fact) *Searcher {
+	s := new(Searcher)
+	if len(facts) > 0 {
+		s.enabled = make(map[Fact]struct{}, len(facts))
+		for _, f := range facts {
+			s.enabled[f] = struct{}{}
+		}
 	}
-)
+	if err := s.SetRules(DefaultRules()); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// SetRules replaces the rules of the searcher.
+func (s *Searcher) SetRules(rules []Rule) error {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = compiled
+	return nil
+}
 
 // Search tries to find sensitive information in the message and returns Verdict.
-func Search(msg string) (Verdict, error) {
+func (s *Searcher) Search(msg string) (Verdict, error) {
+	s.mu.RLock()
+	rules := s.rules
+	s.mu.RUnlock()
+
 	v := NewVerdict()
-	if patternCardCVC.MatchString(msg) {
-		v.AddFact(FactContainsCardCVC)
+	for _, r := range rules {
+		if _, ok := s.enabled[r.fact]; !ok && s.enabled != nil {
+			continue
+		}
+		if r.match(msg) {
+			v.addFact(r.fact, r.severity)
+		}
 	}
-	if patternCardNumber.MatchString(msg) {
-		v.AddFact(FactContainsCardNumber)
+	return v, nil
+}
+
+func digits(s string) ([]int, bool) {
+	d := make([]int, 0, len(s))
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return nil, false
+		}
+		d = append(d, int(r-'0'))
 	}
-	if patternCardDate.MatchString(msg) {
-		v.AddFact(FactContainsCardDate)
+	return d, true
+}
+
+// isValidCardNumber checks the Luhn checksum.
+func isValidCardNumber(s string) bool {
+	d, ok := digits(s)
+	if !ok || len(d) < 12 {
+		return false
 	}
-	if patternSMSCode.MatchString(msg) {
-		v.AddFact(FactContainsSMSCode)
+	var sum int
+	for i := len(d) - 1; i >= 0; i-- {
+		x := d[i]
+		if (len(d)-i)%2 == 0 {
+			if x *= 2; x > 9 {
+				x -= 9
+			}
+		}
+		sum += x
 	}
-	if containsSuspiciousPhrases(msg) {
-		v.AddFact(FactContainsSuspiciousPhrases)
+	return sum%10 == 0
+}
+
+// isValidIBAN checks the ISO 13616 mod-97 checksum.
+func isValidIBAN(s string) bool {
+	if len(s) < 15 || len(s) > 34 {
+		return false
 	}
-	return v, nil
+	var num strings.Builder
+	for _, r := range s[4:] + s[:4] {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			num.WriteString(big.NewInt(int64(r - 'A' + 10)).String())
+		case r >= '0' && r <= '9':
+			num.WriteRune(r)
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(num.String(), 10)
+	return ok && n.Mod(n, big.NewInt(97)).Int64() == 1
 }
 
-func containsSuspiciousPhrases(s string) bool {
-	for k := range suspiciousPhrases {
-		if strings.Contains(s, k) {
-			return true
+// isValidSNILS checks the control number of the insurance number.
+func isValidSNILS(s string) bool {
+	d, ok := digits(s)
+	if !ok || len(d) != 11 {
+		return false
+	}
+	var sum int
+	for i := 0; i < 9; i++ {
+		sum += d[i] * (9 - i)
+	}
+	control := sum % 101
+	if control == 100 {
+		control = 0
+	}
+	return control == d[9]*10+d[10]
+}
+
+// isValidINN checks the control digits of the taxpayer number of a company (10 digits) or a person (12 digits).
+func isValidINN(s string) bool {
+	d, ok := digits(s)
+	if !ok {
+		return false
+	}
+	control := func(coefs ...int) int {
+		var sum int
+		for i, c := range coefs {
+			sum += c * d[i]
 		}
+		return sum % 11 % 10
+	}
+	switch len(d) {
+	case 10:
+		return control(2, 4, 10, 3, 5, 9, 4, 6, 8) == d[9]
+	case 12:
+		return control(7, 2, 4, 10, 3, 5, 9, 4, 6, 8) == d[10] &&
+			control(3, 7, 2, 4, 10, 3, 5, 9, 4, 6, 8) == d[11]
+	default:
+		return false
 	}
-	return false
 }
@@ -13,11 +13,28 @@ import (
 func FuzzSearch(f *testing.F) {
 	f.Add("Мой логин такой же как на карте 5179-4279-0625-0126 (IVAN KUZYAKIN) – ivankuzyakin.")
 	f.Add("Hello, my dear friend!\n🙌")
+	f.Add("IBAN DE89 3704 0044 0532 0130 00, СНИЛС 112-233-445 95, ИНН 500100732259")
+	f.Add("Паспорт 45 06 № 123456, телефон +7 (999) 123-45-67, почта ivan@example.com")
 
 	f.Fuzz(func(t *testing.T, input string) {
-		_, err := bloodhound.Search(input)
+		v, err := bloodhound.Search(input)
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		if redacted := bloodhound.Redact(input); len(redacted) != len(input) {
+			t.Fatalf("redacted message length changed: %q", redacted)
+		}
+
+		// Each detector works the same on its own.
+		for _, fact := range bloodhound.Facts() {
+			single, err := bloodhound.NewSearcher(fact).Search(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if single.HasFact(fact) != v.HasFact(fact) || single.FactsNumber() > 1 {
+				t.Fatalf("fact %s: verdict %v, single detector verdict %v", fact, v, single)
+			}
+		}
 	})
 }
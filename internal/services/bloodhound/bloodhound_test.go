@@ -35,7 +35,7 @@ func TestSearch(t *testing.T) {
 		// "Dirty".
 		{
 			in: `Скидываю данные карты, как вы и просили:
-2200 1234 5678 9010
+2200 1234 5678 9019
 12/30
 961`,
 			verdict: bloodhound.NewVerdict(
@@ -63,7 +63,7 @@ func TestSearch(t *testing.T) {
 			facts:   1,
 		},
 		{
-			in: `Мой логин такой же как на карте 5179-4279-0625-0126 (IVAN KUZYAKIN) – ivankuzyakin.
+			in: `Мой логин такой же как на карте 5179-4279-0625-0120 (IVAN KUZYAKIN) – ivankuzyakin.
 Кстати у карты скоро истечёт срок действия, она до 07/22, может быть в этом проблема?`,
 			verdict: bloodhound.NewVerdict(
 				bloodhound.FactContainsCardNumber,
@@ -73,10 +73,55 @@ func TestSearch(t *testing.T) {
 			facts: 3,
 		},
 		{
-			in:      "Деньги я пересылал с карты 4279012606251579, прошло уже 3 дня, а они ещё не пришли.",
+			in:      "Деньги я пересылал с карты 4279012606251578, прошло уже 3 дня, а они ещё не пришли.",
 			verdict: bloodhound.NewVerdict(bloodhound.FactContainsCardNumber),
 			facts:   1,
 		},
+		{
+			in:      "Номер заказа 4279012606251579, доставка в пункт 12-345",
+			verdict: bloodhound.VerdictOK, // Not a card number, the checksum is invalid.
+			facts:   0,
+		},
+		{
+			in:      "Перевёл на счёт DE89 3704 0044 0532 0130 00, а деньги не дошли",
+			verdict: bloodhound.NewVerdict(bloodhound.FactContainsIBAN),
+			facts:   1,
+		},
+		{
+			in:      "Перевёл на счёт DE89 3704 0044 0532 0130 01, а деньги не дошли",
+			verdict: bloodhound.VerdictOK,
+			facts:   0,
+		},
+		{
+			in:      "Перезвоните мне на +7 (999) 123-45-67 или 89991234567",
+			verdict: bloodhound.NewVerdict(bloodhound.FactContainsPhone),
+			facts:   1,
+		},
+		{
+			in:      "Паспорт 45 06 № 123456, выдан ОВД",
+			verdict: bloodhound.NewVerdict(bloodhound.FactContainsPassport),
+			facts:   1,
+		},
+		{
+			in:      "СНИЛС 112-233-445 95, ИНН 7707083893",
+			verdict: bloodhound.NewVerdict(bloodhound.FactContainsSNILS, bloodhound.FactContainsINN),
+			facts:   2,
+		},
+		{
+			in:      "СНИЛС 112-233-445 96, ИНН 7707083890",
+			verdict: bloodhound.VerdictOK,
+			facts:   0,
+		},
+		{
+			in:      "Мой ИНН 500100732259",
+			verdict: bloodhound.NewVerdict(bloodhound.FactContainsINN),
+			facts:   1,
+		},
+		{
+			in:      "Пишите на ivan.kuzyakin@example.com",
+			verdict: bloodhound.NewVerdict(bloodhound.FactContainsEmail),
+			facts:   1,
+		},
 	}
 
 	for _, tt := range cases {
@@ -91,3 +136,29 @@ func TestSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestSearcher_EnabledDetectors(t *testing.T) {
+	const in = "Карта 4279012606251578, почта ivan.kuzyakin@example.com"
+
+	v, err := bloodhound.NewSearcher(bloodhound.FactContainsEmail).Search(in)
+	require.NoError(t, err)
+	assert.True(t, bloodhound.NewVerdict(bloodhound.FactContainsEmail).Equals(v), v)
+
+	v, err = bloodhound.NewSearcher().Search(in)
+	require.NoError(t, err)
+	assert.True(t, bloodhound.NewVerdict(
+		bloodhound.FactContainsCardNumber,
+		bloodhound.FactContainsEmail,
+	).Equals(v), v)
+}
+
+func TestParseFact(t *testing.T) {
+	for _, f := range bloodhound.Facts() {
+		parsed, err := bloodhound.ParseFact(f.String())
+		require.NoError(t, err)
+		assert.Equal(t, f, parsed)
+	}
+
+	_, err := bloodhound.ParseFact("unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package bloodhound
+
+import (
+	"sort"
+	"strings"
+)
+
+const maskChar = '*'
+
+// Match is the position of the detected fact in the message.
+type Match struct {
+	Fact  Fact
+	Start int // Byte offset of the first byte.
+	End   int // Byte offset after the last byte.
+}
+
+// Find returns the positions of the facts found in the message ordered by start.
+func (s *Searcher) Find(msg string) []Match {
+	s.mu.RLock()
+	rules := s.rules
+	s.mu.RUnlock()
+
+	var matches []Match
+	for _, r := range rules {
+		if _, ok := s.enabled[r.fact]; !ok && s.enabled != nil {
+			continue
+		}
+		for _, span := range r.find(msg) {
+			matches = append(matches, Match{Fact: r.fact, Start: span[0], End: span[1]})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	return matches
+}
+
+// Redact masks the sensitive data found in the message, e.g. a card number becomes 4276 **** **** 1234.
+// The suspicious phrases are kept as is.
+func (s *Searcher) Redact(msg string) string {
+	matches := s.Find(msg)
+	if len(matches) == 0 {
+		return msg
+	}
+
+	// The masks are ASCII only and replace ASCII chars, so the positions of the other matches stay valid.
+	b := []byte(msg)
+	for _, m := range matches {
+		mask(m.Fact, b[m.Start:m.End])
+	}
+	return string(b)
+}
+
+// Redact masks the sensitive data found with the default rules.
+func Redact(msg string) string {
+	return defaultSearcher.Redact(msg)
+}
+
+func mask(f Fact, b []byte) {
+	switch f {
+	case FactContainsSuspiciousPhrases:
+	case FactContainsCardNumber:
+		maskExcept(b, isDigit, 4, 4)
+	case FactContainsIBAN:
+		maskExcept(b, isAlnum, 4, 4)
+	case FactContainsEmail:
+		if at := strings.IndexByte(string(b), '@'); at > 0 {
+			maskExcept(b[:at], func(byte) bool { return true }, 1, 0)
+		}
+	default:
+		maskExcept(b, isDigit, 0, 0)
+	}
+}
+
+// maskExcept masks the chars passing the filter except the first head and the last tail of them.
+func maskExcept(b []byte, filter func(c byte) bool, head, tail int) {
+	var total int
+	for _, c := range b {
+		if filter(c) {
+			total++
+		}
+	}
+
+	var i int
+	for j, c := range b {
+		if !filter(c) {
+			continue
+		}
+		if i >= head && i < total-tail {
+			b[j] = maskChar
+		}
+		i++
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
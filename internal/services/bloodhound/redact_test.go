@@ -0,0 +1,56 @@
+package bloodhound_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+)
+
+func TestRedact(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{
+			in:  "Здравствуйте! Не могу зайти в мобильное приложение, что делать?",
+			out: "Здравствуйте! Не могу зайти в мобильное приложение, что делать?",
+		},
+		{
+			in:  "Карта 4276 1234 5678 1235, срок 12/30, код 961",
+			out: "Карта 4276 **** **** 1235, срок **/**, код ***",
+		},
+		{
+			in:  "Не карта: 4279012606251579",
+			out: "Не карта: 4279012606251579",
+		},
+		{
+			in:  "Мой логин ivan.kuzyakin@example.com, пароль не скажу",
+			out: "Мой логин i************@example.com, пароль не скажу",
+		},
+		{
+			in:  "Счёт DE89 3704 0044 0532 0130 00, телефон +7 (999) 123-45-67",
+			out: "Счёт DE89 **** **** **** **30 00, телефон +* (***) ***-**-**",
+		},
+		{
+			in:  "СНИЛС 112-233-445 95, ИНН 7707083893",
+			out: "СНИЛС ***-***-*** **, ИНН **********",
+		},
+	}
+
+	for _, tt := range cases {
+		assert.Equal(t, tt.out, bloodhound.Redact(tt.in))
+	}
+}
+
+func TestSearcher_Find(t *testing.T) {
+	const in = "Карта 4276 1234 5678 1235, почта ivan@example.com"
+
+	matches := bloodhound.NewSearcher(bloodhound.FactContainsCardNumber, bloodhound.FactContainsEmail).Find(in)
+	assert.Equal(t, []bloodhound.Match{
+		{Fact: bloodhound.FactContainsCardNumber, Start: 11, End: 30},
+		{Fact: bloodhound.FactContainsEmail, Start: 43, End: 59},
+	}, matches)
+	assert.Equal(t, "4276 1234 5678 1235", in[matches[0].Start:matches[0].End])
+	assert.Equal(t, "ivan@example.com", in[matches[1].Start:matches[1].End])
+}
@@ -0,0 +1,180 @@
+package bloodhound
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Rule detects the fact by a regex or by a list of phrases.
+type Rule struct {
+	Name string `toml:"name"`
+	// Fact is the fact name, e.g. "card_number".
+	Fact string `toml:"fact"`
+	// Severity is added to the verdict score, the default severity of the fact is used if zero.
+	Severity int `toml:"severity"`
+
+	// Either Regex or Phrases must be set.
+	Regex   string   `toml:"regex"`
+	Phrases []string `toml:"phrases"`
+
+	CaseInsensitive bool `toml:"case_insensitive"`
+	// WordBoundary requires the match not to be surrounded by letters or digits.
+	WordBoundary bool `toml:"word_boundary"`
+	// Checksum validates the match without spaces and dashes: luhn, iban, snils or inn.
+	Checksum string `toml:"checksum"`
+}
+
+// DefaultRules are used until the rules are loaded from a file.
+func DefaultRules() []Rule {
+	return []Rule{
+		// The standalone 3 digits, not a part of a phone or a document number.
+		{Name: "card-cvc", Fact: "card_cvc", Regex: `(?:^|[^\d\-/()])\d{3}(?:$|[^\d\-/()])`},
+		{Name: "card-number", Fact: "card_number", Regex: `\b\d{4}[- ]?\d{4}[- ]?\d{4}[- ]?\d{4}\b`, Checksum: "luhn"},
+		{Name: "card-date", Fact: "card_date", Regex: `\d{2}/\d{2}`},
+		{Name: "sms-code", Fact: "sms_code", Regex: `10-\d{4}`},
+		{
+			Name: "suspicious-phrases", Fact: "suspicious_phrases", CaseInsensitive: true,
+			Phrases: []string{"login", "password", "логин", "парол"},
+		},
+		{Name: "iban", Fact: "iban", Regex: `\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`, Checksum: "iban"},
+		{Name: "phone", Fact: "phone", Regex: `(?:\+7|\b8)[ -]?\(?\d{3}\)?[ -]?\d{3}[ -]?\d{2}[ -]?\d{2}\b`},
+		{Name: "passport", Fact: "passport", Regex: `\b\d{2} ?\d{2} (?:№ ?)?\d{6}\b`},
+		{Name: "snils", Fact: "snils", Regex: `\b\d{3}-?\d{3}-?\d{3}[- ]?\d{2}\b`, Checksum: "snils"},
+		{Name: "inn", Fact: "inn", Regex: `\b(?:\d{10}|\d{12})\b`, Checksum: "inn"},
+		{Name: "email", Fact: "email", Regex: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+	}
+}
+
+// ParseRules parses the TOML rules file with the [[rules]] tables.
+func ParseRules(data []byte) ([]Rule, error) {
+	var file struct {
+		Rules []Rule `toml:"rules"`
+	}
+	if _, err := toml.Decode(string(data), &file); err != nil {
+		return nil, fmt.Errorf("decode rules: %v", err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, errors.New("no rules")
+	}
+	return file.Rules, nil
+}
+
+var checksums = map[string]func(s string) bool{
+	"luhn":  isValidCardNumber,
+	"iban":  isValidIBAN,
+	"snils": isValidSNILS,
+	"inn":   isValidINN,
+}
+
+// notWordChar is a unicode aware alternative to \b which is ASCII only.
+const notWordChar = `[^\p{L}\p{N}_]`
+
+type compiledRule struct {
+	name     string
+	fact     Fact
+	severity int
+	re       *regexp.Regexp // The first group is the match.
+	checksum func(s string) bool
+}
+
+func compileRules(rules []Rule) ([]compiledRule, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, r := range rules {
+		c, err := r.compile()
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %v", r.Name, err)
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+func (r Rule) compile() (compiledRule, error) {
+	if r.Name == "" {
+		return compiledRule{}, errors.New("no name")
+	}
+	fact, err := ParseFact(r.Fact)
+	if err != nil {
+		return compiledRule{}, err
+	}
+	if r.Severity < 0 {
+		return compiledRule{}, errors.New("negative severity")
+	}
+	severity := r.Severity
+	if severity == 0 {
+		severity = fact.defaultSeverity()
+	}
+
+	var pattern string
+	switch {
+	case r.Regex != "" && len(r.Phrases) != 0:
+		return compiledRule{}, errors.New("both regex and phrases are set")
+	case r.Regex != "":
+		pattern = r.Regex
+	case len(r.Phrases) != 0:
+		quoted := make([]string, 0, len(r.Phrases))
+		for _, p := range r.Phrases {
+			quoted = append(quoted, regexp.QuoteMeta(p))
+		}
+		pattern = strings.Join(quoted, "|")
+	default:
+		return compiledRule{}, errors.New("neither regex nor phrases are set")
+	}
+
+	pattern = "(" + pattern + ")"
+	if r.WordBoundary {
+		pattern = "(?:^|" + notWordChar + ")" + pattern + "(?:$|" + notWordChar + ")"
+	}
+	if r.CaseInsensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("compile: %v", err)
+	}
+
+	var checksum func(s string) bool
+	if r.Checksum != "" {
+		var ok bool
+		if checksum, ok = checksums[r.Checksum]; !ok {
+			return compiledRule{}, fmt.Errorf("unknown checksum %q", r.Checksum)
+		}
+	}
+
+	return compiledRule{
+		name:     r.Name,
+		fact:     fact,
+		severity: severity,
+		re:       re,
+		checksum: checksum,
+	}, nil
+}
+
+func (r compiledRule) match(msg string) bool {
+	if r.checksum == nil {
+		return r.re.MatchString(msg)
+	}
+	return len(r.find(msg)) > 0
+}
+
+// find returns the positions of the matches.
+func (r compiledRule) find(msg string) [][2]int {
+	var spans [][2]int
+	for _, m := range r.re.FindAllStringSubmatchIndex(msg, -1) {
+		start, end := m[2], m[3]
+		if r.checksum != nil && !r.checksum(strings.Map(func(r rune) rune {
+			if r == ' ' || r == '-' {
+				return -1
+			}
+			return r
+		}, strings.ToUpper(msg[start:end]))) {
+			continue
+		}
+		spans = append(spans, [2]int{start, end})
+	}
+	return spans
+}
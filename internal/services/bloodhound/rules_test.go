@@ -0,0 +1,139 @@
+package bloodhound_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+)
+
+func TestSearcher_ExampleRulesFile(t *testing.T) {
+	s := bloodhound.NewSearcher()
+	require.NoError(t, s.LoadRulesFile("../../../configs/bloodhound-rules.example.toml"))
+
+	v, err := s.Search(`Скидываю данные карты: 2200 1234 5678 9019, 12/30, 961. Мой LOGIN ivan@example.com`)
+	require.NoError(t, err)
+	assert.True(t, bloodhound.NewVerdict(
+		bloodhound.FactContainsCardNumber,
+		bloodhound.FactContainsCardDate,
+		bloodhound.FactContainsCardCVC,
+		bloodhound.FactContainsSuspiciousPhrases,
+		bloodhound.FactContainsEmail,
+	).Equals(v), v)
+	assert.Equal(t, 10+3+5+2+2, v.Score())
+}
+
+func TestSearcher_SetRules(t *testing.T) {
+	cases := []struct {
+		name    string
+		rule    bloodhound.Rule
+		matches []string
+		misses  []string
+	}{
+		{
+			name:    "phrases",
+			rule:    bloodhound.Rule{Phrases: []string{"пароль", "pin"}},
+			matches: []string{"мой пароль 123", "pin: 1234", "spin"},
+			misses:  []string{"ПАРОЛЬ", "PIN"},
+		},
+		{
+			name:    "case insensitive phrases",
+			rule:    bloodhound.Rule{Phrases: []string{"пароль", "pin"}, CaseInsensitive: true},
+			matches: []string{"ПАРОЛЬ", "Pin: 1234"},
+			misses:  []string{"логин"},
+		},
+		{
+			name:    "word boundary phrases",
+			rule:    bloodhound.Rule{Phrases: []string{"пароль", "pin"}, WordBoundary: true},
+			matches: []string{"пароль", "мой пароль: 123", "(pin)"},
+			misses:  []string{"spin", "паролька", "pin2"},
+		},
+		{
+			name:    "regex with checksum",
+			rule:    bloodhound.Rule{Regex: `\d{16}`, Checksum: "luhn", WordBoundary: true},
+			matches: []string{"карта 4279012606251578."},
+			misses:  []string{"карта 4279012606251579", "карта 42790126062515780"},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rule.Name = "test"
+			tt.rule.Fact = "suspicious_phrases"
+			tt.rule.Severity = 7
+
+			s := bloodhound.NewSearcher()
+			require.NoError(t, s.SetRules([]bloodhound.Rule{tt.rule}))
+
+			for _, in := range tt.matches {
+				v, err := s.Search(in)
+				require.NoError(t, err)
+				assert.True(t, v.HasFact(bloodhound.FactContainsSuspiciousPhrases), in)
+				assert.Equal(t, 7, v.Score(), in)
+			}
+			for _, in := range tt.misses {
+				v, err := s.Search(in)
+				require.NoError(t, err)
+				assert.Equal(t, 0, v.FactsNumber(), in)
+			}
+		})
+	}
+}
+
+func TestSearcher_SetRules_Invalid(t *testing.T) {
+	for name, r := range map[string]bloodhound.Rule{
+		"no name":           {Fact: "email", Regex: "@"},
+		"unknown fact":      {Name: "r", Fact: "unknown", Regex: "@"},
+		"no regex":          {Name: "r", Fact: "email"},
+		"regex and phrases": {Name: "r", Fact: "email", Regex: "@", Phrases: []string{"@"}},
+		"invalid regex":     {Name: "r", Fact: "email", Regex: "("},
+		"unknown checksum":  {Name: "r", Fact: "email", Regex: "@", Checksum: "crc"},
+		"negative severity": {Name: "r", Fact: "email", Regex: "@", Severity: -1},
+	} {
+		assert.Error(t, bloodhound.NewSearcher().SetRules([]bloodhound.Rule{r}), name)
+	}
+}
+
+func TestSearcher_WatchRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.toml")
+	writeRules := func(phrase string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(`
+[[rules]]
+name = "phrase"
+fact = "suspicious_phrases"
+phrases = ["`+phrase+`"]
+`), 0o600))
+	}
+	found := func(s *bloodhound.Searcher, msg string) bool {
+		v, err := s.Search(msg)
+		require.NoError(t, err)
+		return v.HasFact(bloodhound.FactContainsSuspiciousPhrases)
+	}
+
+	writeRules("secret")
+	s := bloodhound.NewSearcher()
+	require.NoError(t, s.LoadRulesFile(path))
+	assert.True(t, found(s, "my secret"))
+	assert.False(t, found(s, "my password"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.WatchRulesFile(ctx, path, 10*time.Millisecond)
+
+	writeRules("password")
+	assert.Eventually(t, func() bool { return found(s, "my password") }, time.Second, 10*time.Millisecond)
+	assert.False(t, found(s, "my secret"))
+
+	// The invalid file is ignored.
+	require.NoError(t, os.WriteFile(path, []byte(`[[rules]]`), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, found(s, "my password"))
+}
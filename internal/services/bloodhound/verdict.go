@@ -4,10 +4,10 @@ import "fmt"
 
 var VerdictOK = NewVerdict()
 
-// Verdict contains facts about the analyzed message.
+// Verdict contains facts about the analyzed message and their severities.
 // If the message is "clean" than verdict equals VerdictOK.
 type Verdict struct {
-	facts map[Fact]struct{}
+	facts map[Fact]int
 }
 
 type Fact int
@@ -18,12 +18,85 @@ const (
 	FactContainsCardDate
 	FactContainsSMSCode
 	FactContainsSuspiciousPhrases
+	FactContainsIBAN
+	FactContainsPhone
+	FactContainsPassport
+	FactContainsSNILS
+	FactContainsINN
+	FactContainsEmail
 )
 
+var factNames = map[Fact]string{
+	FactContainsCardCVC:           "card_cvc",
+	FactContainsCardNumber:        "card_number",
+	FactContainsCardDate:          "card_date",
+	FactContainsSMSCode:           "sms_code",
+	FactContainsSuspiciousPhrases: "suspicious_phrases",
+	FactContainsIBAN:              "iban",
+	FactContainsPhone:             "phone",
+	FactContainsPassport:          "passport",
+	FactContainsSNILS:             "snils",
+	FactContainsINN:               "inn",
+	FactContainsEmail:             "email",
+}
+
+// Facts returns all the facts bloodhound detects.
+func Facts() []Fact {
+	facts := make([]Fact, 0, len(factNames))
+	for f := FactContainsCardCVC; f <= FactContainsEmail; f++ {
+		facts = append(facts, f)
+	}
+	return facts
+}
+
+// LogFacts are the facts validated by checksums, so they don't match the IPs, ports,
+// statuses and ids the logs are full of.
+func LogFacts() []Fact {
+	return []Fact{FactContainsCardNumber, FactContainsIBAN}
+}
+
+// ParseFact returns the fact by its name, e.g. "card_number".
+func ParseFact(name string) (Fact, error) {
+	for f, n := range factNames {
+		if n == name {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown fact %q", name)
+}
+
+var defaultSeverities = map[Fact]int{
+	FactContainsCardCVC:           5,
+	FactContainsCardNumber:        10,
+	FactContainsCardDate:          3,
+	FactContainsSMSCode:           8,
+	FactContainsSuspiciousPhrases: 2,
+	FactContainsIBAN:              8,
+	FactContainsPhone:             3,
+	FactContainsPassport:          8,
+	FactContainsSNILS:             8,
+	FactContainsINN:               5,
+	FactContainsEmail:             2,
+}
+
+func (f Fact) defaultSeverity() int {
+	if s, ok := defaultSeverities[f]; ok {
+		return s
+	}
+	return 1
+}
+
+func (f Fact) String() string {
+	if n, ok := factNames[f]; ok {
+		return n
+	}
+	return fmt.Sprintf("fact(%d)", int(f))
+}
+
 func NewVerdict(facts ...Fact) Verdict {
-	v := Verdict{facts: make(map[Fact]struct{}, len(facts))}
+	v := Verdict{facts: make(map[Fact]int, len(facts))}
 	for _, fact := range facts {
-		v.facts[fact] = struct{}{}
+		v.AddFact(fact)
 	}
 	return v
 }
@@ -32,6 +105,7 @@ func (v Verdict) String() string {
 	return fmt.Sprintf("%v", v.facts)
 }
 
+// Equals compares the facts of the verdicts, the severities are not taken into account.
 func (v Verdict) Equals(rhs Verdict) bool {
 	if v.FactsNumber() != rhs.FactsNumber() {
 		return false
@@ -44,11 +118,19 @@ func (v Verdict) Equals(rhs Verdict) bool {
 	return true
 }
 
+// AddFact adds the fact with its default severity.
 func (v *Verdict) AddFact(f Fact) {
+	v.addFact(f, f.defaultSeverity())
+}
+
+// addFact adds the fact, the max severity is kept if the fact is added several times.
+func (v *Verdict) addFact(f Fact, severity int) {
 	if v.facts == nil {
-		v.facts = make(map[Fact]struct{})
+		v.facts = make(map[Fact]int)
+	}
+	if cur, ok := v.facts[f]; !ok || severity > cur {
+		v.facts[f] = severity
 	}
-	v.facts[f] = struct{}{}
 }
 
 func (v Verdict) HasFact(f Fact) bool {
@@ -59,3 +141,12 @@ func (v Verdict) HasFact(f Fact) bool {
 func (v Verdict) FactsNumber() int {
 	return len(v.facts)
 }
+
+// Score is the sum of the facts severities, callers decide what score is suspicious.
+func (v Verdict) Score() int {
+	var score int
+	for _, severity := range v.facts {
+		score += severity
+	}
+	return score
+}
@@ -66,3 +66,13 @@ func TestVerdict_Equals(t *testing.T) {
 	v2.AddFact(bloodhound.FactContainsSMSCode)
 	assert.True(t, v1.Equals(v2))
 }
+
+func TestVerdict_Score(t *testing.T) {
+	assert.Equal(t, 0, bloodhound.VerdictOK.Score())
+
+	v := bloodhound.NewVerdict(bloodhound.FactContainsCardNumber, bloodhound.FactContainsCardCVC)
+	assert.Equal(t, 15, v.Score())
+
+	v.AddFact(bloodhound.FactContainsCardNumber)
+	assert.Equal(t, 15, v.Score(), "the fact is counted once")
+}
@@ -0,0 +1,59 @@
+package bloodhound
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LoadRulesFile replaces the rules with the ones from the file if its content has changed since the last load.
+func (s *Searcher) LoadRulesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read rules file: %v", err)
+	}
+
+	s.mu.RLock()
+	unchanged := bytes.Equal(data, s.rulesData)
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return err
+	}
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = compiled
+	s.rulesData = data
+	return nil
+}
+
+// WatchRulesFile reloads the rules file every interval until ctx is done.
+// The previous rules are kept if the file is invalid.
+func (s *Searcher) WatchRulesFile(ctx context.Context, path string, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		if err := s.LoadRulesFile(path); err != nil {
+			zap.L().Named("bloodhound").Warn("reloading rules failed, keeping the previous rules",
+				zap.String("path", path), zap.Error(err))
+		}
+	}
+}
@@ -15,6 +15,7 @@ const (
 type Event interface {
 	eventMarker()
 	Validate() error
+	ID() types.EventID
 }
 
 type event struct{}         //
@@ -35,6 +36,10 @@ func (e CoreEventFields) Matches(x any) bool {
 	return e.EventType == val.EventType && e.MessageID == val.MessageID && e.RequestID == val.RequestID
 }
 
+func (e CoreEventFields) ID() types.EventID {
+	return e.EventID
+}
+
 func (e CoreEventFields) String() string {
 	return e.EventType
 }
@@ -6,41 +6,78 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/segmentio/kafka-go"
+	"github.com/getsentry/sentry-go"
 
+	"github.com/gerladeno/chat-service/internal/broker"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
 	"github.com/gerladeno/chat-service/internal/types"
 )
 
+// SchemaVersion is the version of the Message JSON schema.
+const SchemaVersion = "1"
+
 type Message struct {
 	ID         types.MessageID `json:"id"`
 	ChatID     types.ChatID    `json:"chatId"`
 	Body       string          `json:"body"`
 	FromClient bool            `json:"fromClient"`
+
+	// RequestID is sent in the header to correlate the verdict with the request.
+	RequestID types.RequestID `json:"-"`
 }
 
 func (s *Service) ProduceMessage(ctx context.Context, msg Message) error {
+	span := sentry.StartSpan(ctx, "queue.publish", sentry.TransactionName(serviceName+".produce"))
+	defer span.Finish()
+	span.SetTag("request_id", msg.RequestID.String())
+
+	if err := s.produce(span.Context(), msg, msgenvelope.TraceHeaders(span)); err != nil {
+		span.Status = sentry.SpanStatusInternalError
+		return err
+	}
+	return nil
+}
+
+func (s *Service) produce(ctx context.Context, msg Message, traceHeaders []broker.Header) error {
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("marshalling message: %v", err)
 	}
+	header := msgenvelope.Header{
+		Version:       msgenvelope.Version,
+		Algorithm:     msgenvelope.AlgNone,
+		SchemaVersion: SchemaVersion,
+	}
 	cipherText := data
-	if s.cipher != nil {
-		nonce, err := s.nonceFactory(s.cipher.NonceSize())
+	if s.keys != nil {
+		keyID, aead := s.keys.Primary()
+		nonce, err := s.nonceFactory(aead.NonceSize())
 		if err != nil {
 			return fmt.Errorf("deriving nonce: %v", err)
 		}
-		cipherText = s.cipher.Seal(nonce, nonce, data, nil)
+		cipherText = aead.Seal(nonce, nonce, data, nil)
+		header.KeyID = keyID
+		header.Algorithm = msgenvelope.AlgAESGCM
+	}
+	now := time.Now()
+	headers := append(header.MessageHeaders(),
+		broker.Header{Key: msgenvelope.HeaderContentType, Value: []byte(msgenvelope.ContentTypeJSON)},
+		broker.Header{Key: msgenvelope.HeaderProducedAt, Value: []byte(now.UTC().Format(time.RFC3339Nano))},
+	)
+	if !msg.RequestID.IsZero() {
+		headers = append(headers, broker.Header{Key: msgenvelope.HeaderRequestID, Value: []byte(msg.RequestID.String())})
 	}
-	if err = s.wr.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(msg.ChatID.String()),
-		Value: cipherText,
-		Time:  time.Now(),
+	if err = s.pub.Publish(ctx, broker.Message{
+		Key:     []byte(msg.ChatID.String()),
+		Value:   cipherText,
+		Headers: append(headers, traceHeaders...),
+		Time:    now,
 	}); err != nil {
-		return fmt.Errorf("writing message to kafka: %v", err)
+		return fmt.Errorf("publishing message: %v", err)
 	}
 	return nil
 }
 
 func (s *Service) Close() error {
-	return s.wr.Close()
+	return s.pub.Close()
 }
@@ -1,33 +1,32 @@
 package msgproducer
 
 import (
-	"context"
-	"crypto/aes"
-	"crypto/cipher"
 	"crypto/rand"
-	"encoding/hex"
 	"fmt"
-	"io"
 
-	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/broker"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
 )
 
-type KafkaWriter interface {
-	io.Closer
-	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
-}
+// DefaultKeyID is the ID of the key set with WithEncryptKey.
+const DefaultKeyID = "default"
 
 //go:generate options-gen -out-filename=service_options.gen.go -from-struct=Options
 type Options struct {
-	wr           KafkaWriter `option:"mandatory" validate:"required"`
-	encryptKey   string      `validate:"omitempty,hexadecimal"`
+	pub        broker.Publisher `option:"mandatory" validate:"required"`
+	encryptKey string           `validate:"omitempty,hexadecimal"`
+	// encryptKeys is the key ring by key IDs, the older keys are kept for the consumers to decrypt.
+	encryptKeys map[string]string `validate:"omitempty,dive,hexadecimal"`
+	// primaryKeyID is the key new messages are encrypted with, DefaultKeyID if empty.
+	primaryKeyID string
 	nonceFactory func(size int) ([]byte, error)
 }
 
 type Service struct {
-	wr           KafkaWriter
-	cipher       cipher.AEAD
+	pub          broker.Publisher
+	keys         *msgenvelope.KeyRing
 	nonceFactory func(size int) ([]byte, error)
 }
 
@@ -39,27 +38,31 @@ func New(opts Options) (*Service, error) {
 		opts.nonceFactory = defaultNonceFactory
 	}
 
-	var aeadCipher cipher.AEAD
+	keys := make(map[string]string, len(opts.encryptKeys)+1)
 	if opts.encryptKey != "" {
-		key, err := hex.DecodeString(opts.encryptKey)
-		if err != nil {
-			return nil, fmt.Errorf("initing encryption decode hex string: %v", err)
-		}
-		aesBlock, err := aes.NewCipher(key)
-		if err != nil {
-			return nil, fmt.Errorf("initing encryption NewCipher: %v", err)
+		keys[DefaultKeyID] = opts.encryptKey
+	}
+	for id, k := range opts.encryptKeys {
+		keys[id] = k
+	}
+
+	var ring *msgenvelope.KeyRing
+	if len(keys) > 0 {
+		primaryKeyID := opts.primaryKeyID
+		if primaryKeyID == "" {
+			primaryKeyID = DefaultKeyID
 		}
-		aeadCipher, err = cipher.NewGCM(aesBlock)
-		if err != nil {
-			return nil, fmt.Errorf("initing encryption NewGCM: %v", err)
+		var err error
+		if ring, err = msgenvelope.NewKeyRing(primaryKeyID, keys); err != nil {
+			return nil, fmt.Errorf("initing encryption key ring: %v", err)
 		}
 	} else {
 		zap.L().Named(serviceName).Info("encryption disabled")
 	}
 
 	return &Service{
-		wr:           opts.wr,
-		cipher:       aeadCipher,
+		pub:          opts.pub,
+		keys:         ring,
 		nonceFactory: opts.nonceFactory,
 	}, nil
 }
@@ -13,7 +13,9 @@ import (
 	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/gerladeno/chat-service/internal/broker"
 	"github.com/gerladeno/chat-service/internal/logger"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
 	msgproducer "github.com/gerladeno/chat-service/internal/services/msg-producer"
 	"github.com/gerladeno/chat-service/internal/testingh"
 	"github.com/gerladeno/chat-service/internal/types"
@@ -58,7 +60,7 @@ func (s *ServiceIntegrationSuite) TearDownTest() {
 func (s *ServiceIntegrationSuite) TestPlainMessages() {
 	// Arrange.
 	svc, err := msgproducer.New(msgproducer.NewOptions(
-		msgproducer.NewKafkaWriter(s.KafkaBrokers(), s.messagesTopic, 1),
+		msgproducer.NewKafkaPublisher(s.KafkaBrokers(), s.messagesTopic, 1),
 	))
 	s.Require().NoError(err)
 	defer func() { s.Require().NoError(svc.Close()) }()
@@ -122,7 +124,7 @@ func (s *ServiceIntegrationSuite) TestPlainMessages() {
 func (s *ServiceIntegrationSuite) TestEncryptedMessages() {
 	// Arrange.
 	svc, err := msgproducer.New(msgproducer.NewOptions(
-		msgproducer.NewKafkaWriter(s.KafkaBrokers(), s.messagesTopic, 1),
+		msgproducer.NewKafkaPublisher(s.KafkaBrokers(), s.messagesTopic, 1),
 		msgproducer.WithEncryptKey("68566D597133743677397A2443264629"),
 		msgproducer.WithNonceFactory(func(size int) ([]byte, error) {
 			return bytes.Repeat([]byte{'1'}, size), nil
@@ -162,6 +164,23 @@ func (s *ServiceIntegrationSuite) TestEncryptedMessages() {
 		}
 	})
 
+	s.Run("envelope headers", func() {
+		for _, m := range producedMsgs {
+			headers := make([]broker.Header, 0, len(m.Headers))
+			for _, h := range m.Headers {
+				headers = append(headers, broker.Header{Key: h.Key, Value: h.Value})
+			}
+			h, err := msgenvelope.ParseHeader(headers)
+			s.Require().NoError(err)
+			s.Equal(msgenvelope.Header{
+				Version:       msgenvelope.Version,
+				KeyID:         msgproducer.DefaultKeyID,
+				Algorithm:     msgenvelope.AlgAESGCM,
+				SchemaVersion: msgproducer.SchemaVersion,
+			}, h)
+		}
+	})
+
 	s.Run("assert messages values and order", func() {
 		expectedChatMsgs := map[string][]string{
 			chat1: {
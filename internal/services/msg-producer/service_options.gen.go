@@ -4,6 +4,7 @@ package msgproducer
 import (
 	fmt461e464ebed9 "fmt"
 
+	"github.com/gerladeno/chat-service/internal/broker"
 	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
 	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
 )
@@ -11,14 +12,14 @@ import (
 type OptOptionsSetter func(o *Options)
 
 func NewOptions(
-	wr KafkaWriter,
+	pub broker.Publisher,
 	options ...OptOptionsSetter,
 ) Options {
 	o := Options{}
 
 	// Setting defaults from field tag (if present)
 
-	o.wr = wr
+	o.pub = pub
 
 	for _, opt := range options {
 		opt(&o)
@@ -32,6 +33,18 @@ func WithEncryptKey(opt string) OptOptionsSetter {
 	}
 }
 
+func WithEncryptKeys(opt map[string]string) OptOptionsSetter {
+	return func(o *Options) {
+		o.encryptKeys = opt
+	}
+}
+
+func WithPrimaryKeyID(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.primaryKeyID = opt
+	}
+}
+
 func WithNonceFactory(opt func(size int) ([]byte, error)) OptOptionsSetter {
 	return func(o *Options) {
 		o.nonceFactory = opt
@@ -40,14 +53,15 @@ func WithNonceFactory(opt func(size int) ([]byte, error)) OptOptionsSetter {
 
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
-	errs.Add(errors461e464ebed9.NewValidationError("wr", _validate_Options_wr(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("pub", _validate_Options_pub(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("encryptKey", _validate_Options_encryptKey(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("encryptKeys", _validate_Options_encryptKeys(o)))
 	return errs.AsError()
 }
 
-func _validate_Options_wr(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.wr, "required"); err != nil {
-		return fmt461e464ebed9.Errorf("field `wr` did not pass the test: %w", err)
+func _validate_Options_pub(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.pub, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `pub` did not pass the test: %w", err)
 	}
 	return nil
 }
@@ -58,3 +72,10 @@ func _validate_Options_encryptKey(o *Options) error {
 	}
 	return nil
 }
+
+func _validate_Options_encryptKeys(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.encryptKeys, "omitempty,dive,hexadecimal"); err != nil {
+		return fmt461e464ebed9.Errorf("field `encryptKeys` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -2,17 +2,17 @@ package msgproducer_test
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gerladeno/chat-service/internal/broker"
+	"github.com/gerladeno/chat-service/internal/msgenvelope"
 	msgproducer "github.com/gerladeno/chat-service/internal/services/msg-producer"
 	"github.com/gerladeno/chat-service/internal/types"
 )
@@ -21,23 +21,28 @@ func TestService_ProduceMessage(t *testing.T) {
 	const messagesCount = 10
 
 	cases := []struct {
-		name string
-		key  string
+		name  string
+		key   string
+		alg   string
+		keyID string
 	}{
 		{
 			name: "plain",
 			key:  "",
+			alg:  msgenvelope.AlgNone,
 		},
 		{
-			name: "encrypted",
-			key:  "24432646294A404E635266546A576E5A",
+			name:  "encrypted",
+			key:   "24432646294A404E635266546A576E5A",
+			alg:   msgenvelope.AlgAESGCM,
+			keyID: msgproducer.DefaultKeyID,
 		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange.
-			writer := new(kafkaWriterMock)
+			writer := new(publisherMock)
 			s, err := msgproducer.New(msgproducer.NewOptions(writer, msgproducer.WithEncryptKey(tt.key)))
 			require.NoError(t, err)
 			defer func() {
@@ -52,6 +57,7 @@ func TestService_ProduceMessage(t *testing.T) {
 					ChatID:     types.NewChatID(),
 					Body:       fmt.Sprintf("Message %d", i),
 					FromClient: i%3 != 0,
+					RequestID:  types.NewRequestID(),
 				})
 			}
 
@@ -64,14 +70,24 @@ func TestService_ProduceMessage(t *testing.T) {
 
 			// Assert.
 			produced := make([]msgproducer.Message, 0, messagesCount)
+			var ring *msgenvelope.KeyRing
+			if tt.key != "" {
+				ring, err = msgenvelope.NewKeyRing(msgproducer.DefaultKeyID, map[string]string{msgproducer.DefaultKeyID: tt.key})
+				require.NoError(t, err)
+			}
 			for _, m := range writer.msgs {
-				data := m.Value
-				if tt.key != "" {
-					data = requireMsgDecrypt(t, tt.key, data)
-				}
+				data, header, err := msgenvelope.Decrypt(ring, m)
+				require.NoError(t, err)
+				assert.Equal(t, msgenvelope.Header{
+					Version:       msgenvelope.Version,
+					KeyID:         tt.keyID,
+					Algorithm:     tt.alg,
+					SchemaVersion: msgproducer.SchemaVersion,
+				}, header)
 
 				msg := requireMsgUnmarshal(t, data)
 				assert.Equal(t, []byte(msg.ChatID.String()), m.Key)
+				msg.RequestID = requireCorrelationHeaders(t, m)
 
 				produced = append(produced, msg)
 			}
@@ -80,26 +96,81 @@ func TestService_ProduceMessage(t *testing.T) {
 	}
 }
 
-func requireMsgDecrypt(t *testing.T, keyStr string, data []byte) []byte {
-	t.Helper()
-
-	key, err := hex.DecodeString(keyStr)
+func TestService_KeyRotation(t *testing.T) {
+	const (
+		oldKey = "24432646294A404E635266546A576E5A"
+		newKey = "7234753778214125442A472D4B615064"
+	)
+
+	// Arrange.
+	writer := new(publisherMock)
+	before, err := msgproducer.New(msgproducer.NewOptions(writer,
+		msgproducer.WithEncryptKeys(map[string]string{"2023-05": oldKey}),
+		msgproducer.WithPrimaryKeyID("2023-05"),
+	))
 	require.NoError(t, err)
-
-	blockCipher, err := aes.NewCipher(key)
+	after, err := msgproducer.New(msgproducer.NewOptions(writer,
+		msgproducer.WithEncryptKeys(map[string]string{"2023-05": oldKey, "2023-06": newKey}),
+		msgproducer.WithPrimaryKeyID("2023-06"),
+	))
 	require.NoError(t, err)
 
-	aead, err := cipher.NewGCM(blockCipher)
+	msgs := []msgproducer.Message{
+		{ID: types.NewMessageID(), ChatID: types.NewChatID(), Body: "before rotation", FromClient: true},
+		{ID: types.NewMessageID(), ChatID: types.NewChatID(), Body: "after rotation", FromClient: true},
+	}
+
+	// Action.
+	require.NoError(t, before.ProduceMessage(context.Background(), msgs[0]))
+	require.NoError(t, after.ProduceMessage(context.Background(), msgs[1]))
+
+	// Assert.
+	ring, err := msgenvelope.NewKeyRing("2023-06", map[string]string{"2023-05": oldKey, "2023-06": newKey})
 	require.NoError(t, err)
+	require.Len(t, writer.msgs, 2)
+	for i, keyID := range []string{"2023-05", "2023-06"} {
+		data, header, err := msgenvelope.Decrypt(ring, writer.msgs[i])
+		require.NoError(t, err)
+		assert.Equal(t, keyID, header.KeyID)
+		assert.Equal(t, msgs[i], requireMsgUnmarshal(t, data))
+	}
 
-	raw, ns := data, aead.NonceSize()
+	t.Run("old key is unable to decrypt new messages", func(t *testing.T) {
+		oldRing, err := msgenvelope.NewKeyRing("2023-05", map[string]string{"2023-05": oldKey})
+		require.NoError(t, err)
+		_, _, err = msgenvelope.Decrypt(oldRing, writer.msgs[1])
+		require.ErrorIs(t, err, msgenvelope.ErrUnknownKey)
+	})
+}
 
-	nonce, encrypted := raw[:ns], raw[ns:]
+func TestService_PrimaryKeyNotInRing(t *testing.T) {
+	_, err := msgproducer.New(msgproducer.NewOptions(new(publisherMock),
+		msgproducer.WithEncryptKeys(map[string]string{"2023-05": "24432646294A404E635266546A576E5A"}),
+		msgproducer.WithPrimaryKeyID("2023-06"),
+	))
+	require.Error(t, err)
+}
 
-	decrypted, err := aead.Open(nil, nonce, encrypted, nil)
+func requireCorrelationHeaders(t *testing.T, m broker.Message) types.RequestID {
+	t.Helper()
+
+	contentType, _ := m.Header(msgenvelope.HeaderContentType)
+	assert.Equal(t, msgenvelope.ContentTypeJSON, contentType)
+
+	producedAt, _ := m.Header(msgenvelope.HeaderProducedAt)
+	at, err := time.Parse(time.RFC3339Nano, producedAt)
 	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), at, time.Minute)
+
+	traceParent, ok := m.Header(msgenvelope.HeaderTraceParent)
+	require.True(t, ok)
+	sentryTrace, ok := m.Header(msgenvelope.HeaderSentryTrace)
+	require.True(t, ok)
+	assert.Equal(t, strings.Split(traceParent, "-")[1], strings.Split(sentryTrace, "-")[0], "same trace id")
 
-	return decrypted
+	requestID, ok := m.Header(msgenvelope.HeaderRequestID)
+	require.True(t, ok)
+	return types.MustParse[types.RequestID](requestID)
 }
 
 func requireMsgUnmarshal(t *testing.T, data []byte) msgproducer.Message {
@@ -121,19 +192,19 @@ func requireMsgUnmarshal(t *testing.T, data []byte) msgproducer.Message {
 	}
 }
 
-var _ msgproducer.KafkaWriter = (*kafkaWriterMock)(nil)
+var _ broker.Publisher = (*publisherMock)(nil)
 
-type kafkaWriterMock struct {
-	msgs   []kafka.Message
+type publisherMock struct {
+	msgs   []broker.Message
 	closed bool
 }
 
-func (m *kafkaWriterMock) Close() error {
+func (m *publisherMock) Close() error {
 	m.closed = true
 	return nil
 }
 
-func (m *kafkaWriterMock) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+func (m *publisherMock) Publish(_ context.Context, msgs ...broker.Message) error {
 	m.msgs = append(m.msgs, msgs...)
 	return nil
 }
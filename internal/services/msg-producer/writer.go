@@ -3,13 +3,15 @@ package msgproducer
 import (
 	"github.com/segmentio/kafka-go"
 
+	"github.com/gerladeno/chat-service/internal/broker"
+	kafkabroker "github.com/gerladeno/chat-service/internal/broker/kafka"
 	"github.com/gerladeno/chat-service/internal/logger"
 )
 
 const serviceName = "msg-producer"
 
-func NewKafkaWriter(brokers []string, topic string, batchSize int) KafkaWriter {
-	return &kafka.Writer{
+func NewKafkaPublisher(brokers []string, topic string, batchSize int) broker.Publisher {
+	return kafkabroker.NewPublisher(&kafka.Writer{
 		Addr:         kafka.TCP(brokers...),
 		Topic:        topic,
 		Balancer:     &kafka.Hash{},
@@ -18,5 +20,5 @@ func NewKafkaWriter(brokers []string, topic string, batchSize int) KafkaWriter {
 		Async:        false,
 		Logger:       logger.NewKafkaAdapted().WithServiceName(serviceName),
 		ErrorLogger:  logger.NewKafkaAdapted().WithServiceName(serviceName).ForErrors(),
-	}
+	})
 }
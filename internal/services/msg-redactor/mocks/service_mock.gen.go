@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package msgredactormocks is a generated GoMock package.
+package msgredactormocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	types "github.com/gerladeno/chat-service/internal/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockmessagesRepository is a mock of messagesRepository interface.
+type MockmessagesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockmessagesRepositoryMockRecorder
+}
+
+// MockmessagesRepositoryMockRecorder is the mock recorder for MockmessagesRepository.
+type MockmessagesRepositoryMockRecorder struct {
+	mock *MockmessagesRepository
+}
+
+// NewMockmessagesRepository creates a new mock instance.
+func NewMockmessagesRepository(ctrl *gomock.Controller) *MockmessagesRepository {
+	mock := &MockmessagesRepository{ctrl: ctrl}
+	mock.recorder = &MockmessagesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmessagesRepository) EXPECT() *MockmessagesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMessageByID mocks base method.
+func (m *MockmessagesRepository) GetMessageByID(ctx context.Context, msgID types.MessageID) (*messagesrepo.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByID", ctx, msgID)
+	ret0, _ := ret[0].(*messagesrepo.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByID indicates an expected call of GetMessageByID.
+func (mr *MockmessagesRepositoryMockRecorder) GetMessageByID(ctx, msgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockmessagesRepository)(nil).GetMessageByID), ctx, msgID)
+}
+
+// RedactBody mocks base method.
+func (m *MockmessagesRepository) RedactBody(ctx context.Context, msgID types.MessageID, body string, sealedOriginal []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactBody", ctx, msgID, body, sealedOriginal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedactBody indicates an expected call of RedactBody.
+func (mr *MockmessagesRepositoryMockRecorder) RedactBody(ctx, msgID, body, sealedOriginal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactBody", reflect.TypeOf((*MockmessagesRepository)(nil).RedactBody), ctx, msgID, body, sealedOriginal)
+}
+
+// Mockredactor is a mock of redactor interface.
+type Mockredactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockredactorMockRecorder
+}
+
+// MockredactorMockRecorder is the mock recorder for Mockredactor.
+type MockredactorMockRecorder struct {
+	mock *Mockredactor
+}
+
+// NewMockredactor creates a new mock instance.
+func NewMockredactor(ctrl *gomock.Controller) *Mockredactor {
+	mock := &Mockredactor{ctrl: ctrl}
+	mock.recorder = &MockredactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockredactor) EXPECT() *MockredactorMockRecorder {
+	return m.recorder
+}
+
+// Redact mocks base method.
+func (m *Mockredactor) Redact(s string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redact", s)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Redact indicates an expected call of Redact.
+func (mr *MockredactorMockRecorder) Redact(s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redact", reflect.TypeOf((*Mockredactor)(nil).Redact), s)
+}
@@ -0,0 +1,64 @@
+package msgredactor
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/gerladeno/chat-service/internal/auditseal"
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/service_mock.gen.go -package=msgredactormocks
+
+type messagesRepository interface {
+	GetMessageByID(ctx context.Context, msgID types.MessageID) (*messagesrepo.Message, error)
+	RedactBody(ctx context.Context, msgID types.MessageID, body string, sealedOriginal []byte) error
+}
+
+type redactor interface {
+	Redact(s string) string
+}
+
+//go:generate options-gen -out-filename=service_options.gen.go -from-struct=Options
+type Options struct {
+	msgRepo  messagesRepository `option:"mandatory" validate:"required"`
+	redactor redactor           `option:"mandatory" validate:"required"`
+	// auditKey is the auditors public key the original body is sealed with.
+	auditKey *rsa.PublicKey `option:"mandatory" validate:"required"`
+}
+
+// Service redacts the stored message body keeping the original sealed for auditors.
+type Service struct {
+	Options
+}
+
+func New(opts Options) (*Service, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating msg redactor options: %v", err)
+	}
+	return &Service{Options: opts}, nil
+}
+
+// RedactMessage masks the sensitive data in the message body. The message without it is left untouched.
+func (s *Service) RedactMessage(ctx context.Context, msgID types.MessageID) error {
+	msg, err := s.msgRepo.GetMessageByID(ctx, msgID)
+	if err != nil {
+		return fmt.Errorf("get message: %w", err)
+	}
+
+	body := s.redactor.Redact(msg.Body)
+	if body == msg.Body {
+		return nil
+	}
+
+	sealed, err := auditseal.Seal(s.auditKey, []byte(msg.Body))
+	if err != nil {
+		return fmt.Errorf("seal original body: %v", err)
+	}
+	if err := s.msgRepo.RedactBody(ctx, msgID, body, sealed); err != nil {
+		return fmt.Errorf("redact body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package msgredactor_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gerladeno/chat-service/internal/auditseal"
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+	msgredactor "github.com/gerladeno/chat-service/internal/services/msg-redactor"
+	msgredactormocks "github.com/gerladeno/chat-service/internal/services/msg-redactor/mocks"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+func TestService_RedactMessage(t *testing.T) {
+	ctx := context.Background()
+	auditKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	newService := func(t *testing.T) (*msgredactor.Service, *msgredactormocks.MockmessagesRepository) {
+		ctrl := gomock.NewController(t)
+		msgRepo := msgredactormocks.NewMockmessagesRepository(ctrl)
+		svc, err := msgredactor.New(msgredactor.NewOptions(msgRepo, bloodhound.NewSearcher(), &auditKey.PublicKey))
+		require.NoError(t, err)
+		return svc, msgRepo
+	}
+
+	t.Run("sensitive data", func(t *testing.T) {
+		svc, msgRepo := newService(t)
+		msgID := types.NewMessageID()
+		const body = "Карта 4276 1234 5678 1235"
+
+		msgRepo.EXPECT().GetMessageByID(gomock.Any(), msgID).Return(&messagesrepo.Message{ID: msgID, Body: body}, nil)
+		msgRepo.EXPECT().RedactBody(gomock.Any(), msgID, "Карта 4276 **** **** 1235", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ types.MessageID, _ string, sealed []byte) error {
+				original, err := auditseal.Open(auditKey, sealed)
+				require.NoError(t, err)
+				assert.Equal(t, body, string(original))
+				return nil
+			})
+
+		require.NoError(t, svc.RedactMessage(ctx, msgID))
+	})
+
+	t.Run("nothing to redact", func(t *testing.T) {
+		svc, msgRepo := newService(t)
+		msgID := types.NewMessageID()
+
+		msgRepo.EXPECT().GetMessageByID(gomock.Any(), msgID).Return(&messagesrepo.Message{ID: msgID, Body: "Hello!"}, nil)
+
+		require.NoError(t, svc.RedactMessage(ctx, msgID))
+	})
+
+	t.Run("message not found", func(t *testing.T) {
+		svc, msgRepo := newService(t)
+		msgID := types.NewMessageID()
+
+		msgRepo.EXPECT().GetMessageByID(gomock.Any(), msgID).Return(nil, messagesrepo.ErrMsgNotFound)
+
+		require.ErrorIs(t, svc.RedactMessage(ctx, msgID), messagesrepo.ErrMsgNotFound)
+	})
+}
@@ -2,12 +2,18 @@ package outbox
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"go.uber.org/zap"
+
+	jobsrepo "github.com/gerladeno/chat-service/internal/repositories/jobs"
 	"github.com/gerladeno/chat-service/internal/types"
 )
 
+var ErrEmptyDedupKey = errors.New("empty dedup key")
+
 func (s *Service) Put(ctx context.Context, name, payload string, availableAt time.Time) (types.JobID, error) {
 	id, err := s.jobsRepo.CreateJob(ctx, name, payload, availableAt)
 	if err != nil {
@@ -15,3 +21,25 @@ func (s *Service) Put(ctx context.Context, name, payload string, availableAt tim
 	}
 	return id, nil
 }
+
+// PutUnique puts the job like Put, but it is a no-op if a job with the same dedupKey
+// is pending or was completed less than dedupTTL ago. In that case types.JobIDNil is returned.
+func (s *Service) PutUnique(
+	ctx context.Context,
+	name, payload, dedupKey string,
+	availableAt time.Time,
+) (types.JobID, error) {
+	if dedupKey == "" {
+		return types.JobIDNil, ErrEmptyDedupKey
+	}
+	id, err := s.jobsRepo.CreateUniqueJob(ctx, name, payload, dedupKey, availableAt)
+	switch {
+	case errors.Is(err, jobsrepo.ErrJobIsDuplicate):
+		zap.L().Named(serviceName).Debug("skip duplicated job",
+			zap.String("job", name), zap.String("dedup_key", dedupKey))
+		return types.JobIDNil, nil
+	case err != nil:
+		return types.JobIDNil, fmt.Errorf("putting a unique job: %v", err)
+	}
+	return id, nil
+}
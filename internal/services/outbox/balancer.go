@@ -0,0 +1,110 @@
+package outbox
+
+import "sync"
+
+// balancer distributes the workers between the job names. It limits the number
+// of concurrently executed jobs of each name and rotates the names in proportion
+// to their weights, so that one noisy job type can't monopolise the pool.
+type balancer struct {
+	mu      sync.Mutex
+	names   []string
+	weights map[string]int
+	limits  map[string]int
+	running map[string]int
+	ring    []string
+	cursor  int
+}
+
+func newBalancer() *balancer {
+	return &balancer{
+		weights: make(map[string]int),
+		limits:  make(map[string]int),
+		running: make(map[string]int),
+	}
+}
+
+// add registers the job name. Zero maxConcurrency means no limit except the number of workers.
+func (b *balancer) add(name string, maxConcurrency, weight int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if weight < 1 {
+		weight = 1
+	}
+	if maxConcurrency < 0 {
+		maxConcurrency = 0
+	}
+	b.names = append(b.names, name)
+	b.weights[name] = weight
+	b.limits[name] = maxConcurrency
+	b.rebuildRing()
+}
+
+// rebuildRing interleaves the names, every name appears in the ring weight times.
+func (b *balancer) rebuildRing() {
+	maxWeight := 0
+	for _, w := range b.weights {
+		if w > maxWeight {
+			maxWeight = w
+		}
+	}
+	b.ring = b.ring[:0]
+	for round := 0; round < maxWeight; round++ {
+		for _, name := range b.names {
+			if b.weights[name] > round {
+				b.ring = append(b.ring, name)
+			}
+		}
+	}
+	b.cursor = 0
+}
+
+// order returns the registered names in the order they should be tried for the next reservation.
+func (b *balancer) order() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.ring) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(b.names))
+	seen := make(map[string]struct{}, len(b.names))
+	for i := 0; i < len(b.ring) && len(result) < len(b.names); i++ {
+		name := b.ring[(b.cursor+i)%len(b.ring)]
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+	b.cursor = (b.cursor + 1) % len(b.ring)
+	return result
+}
+
+// acquire takes an execution slot of the name if the limit allows.
+func (b *balancer) acquire(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if limit := b.limits[name]; limit > 0 && b.running[name] >= limit {
+		return false
+	}
+	b.running[name]++
+	return true
+}
+
+func (b *balancer) release(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.running[name] > 0 {
+		b.running[name]--
+	}
+}
+
+func (b *balancer) registered() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]string(nil), b.names...)
+}
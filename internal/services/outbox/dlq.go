@@ -0,0 +1,54 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsrepo "github.com/gerladeno/chat-service/internal/repositories/jobs"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+var ErrFailedJobNotFound = jobsrepo.ErrFailedJobNotFound
+
+type FailedJob struct {
+	ID        types.FailedJobID
+	Name      string
+	Payload   string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// FailedJobs returns the last limit jobs from the DLQ.
+func (s *Service) FailedJobs(ctx context.Context, limit int) ([]FailedJob, error) {
+	jobs, err := s.jobsRepo.GetFailedJobs(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get failed jobs: %v", err)
+	}
+	result := make([]FailedJob, 0, len(jobs))
+	for _, j := range jobs {
+		result = append(result, FailedJob(j))
+	}
+	return result, nil
+}
+
+func (s *Service) FailedJob(ctx context.Context, id types.FailedJobID) (FailedJob, error) {
+	j, err := s.jobsRepo.GetFailedJob(ctx, id)
+	if err != nil {
+		return FailedJob{}, fmt.Errorf("get failed job: %w", err)
+	}
+	return FailedJob(j), nil
+}
+
+// ReplayFailedJob puts the job from the DLQ back to the queue.
+func (s *Service) ReplayFailedJob(ctx context.Context, id types.FailedJobID) (types.JobID, error) {
+	jobID, err := s.jobsRepo.ReplayFailedJob(ctx, id)
+	if err != nil {
+		return types.JobIDNil, fmt.Errorf("replay failed job: %w", err)
+	}
+	zap.L().Named(serviceName).Info("failed job replayed",
+		zap.Stringer("failed_job_id", id), zap.Stringer("job_id", jobID))
+	return jobID, nil
+}
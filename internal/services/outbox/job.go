@@ -21,11 +21,21 @@ type Job interface {
 	// An attempt is counted if the task was not completed due to an unknown error.
 	// When MaxAttempts() is exceeded, the task moves to the dlq (dead letter queue) table.
 	MaxAttempts() int
+
+	// MaxConcurrency is the maximum number of the jobs with this name executed at the same time
+	// by all workers of the service. Zero means that only the number of workers limits it.
+	MaxConcurrency() int
+
+	// Weight is the share of the reservation attempts given to the job name
+	// relative to the other names. The minimum and default weight is 1.
+	Weight() int
 }
 
 const (
 	defaultExecutionTimeout = 30 * time.Second
 	defaultMaxAttempts      = 30
+	defaultMaxConcurrency   = 0
+	defaultWeight           = 1
 )
 
 // DefaultJob is useful for embedding into other jobs.
@@ -39,6 +49,14 @@ func (j DefaultJob) MaxAttempts() int {
 	return defaultMaxAttempts
 }
 
+func (j DefaultJob) MaxConcurrency() int {
+	return defaultMaxConcurrency
+}
+
+func (j DefaultJob) Weight() int {
+	return defaultWeight
+}
+
 func MarshalPayload(messageID types.MessageID) (string, error) {
 	if messageID.IsZero() {
 		return "", types.ErrEntityIsNil
@@ -42,6 +42,11 @@ func New(opts Options) (*Job, error) {
 	return &Job{Options: opts}, nil
 }
 
+// DedupKey is the natural deduplication key of the job: one notification per message.
+func DedupKey(msgID types.MessageID) string {
+	return Name + ":" + msgID.String()
+}
+
 func (j *Job) Name() string {
 	return Name
 }
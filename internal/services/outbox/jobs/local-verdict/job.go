@@ -0,0 +1,155 @@
+package localverdictjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+	"github.com/gerladeno/chat-service/internal/services/outbox"
+	clientmessageblockedjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-blocked"
+	clientmessagesentjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-sent"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/job_mock.gen.go -package=localverdictjobmocks
+
+// Name is the job checking the message with bloodhound if AFC hasn't sent the verdict in time.
+const Name = "local-verdict"
+
+type messageRepository interface {
+	GetMessageByID(ctx context.Context, msgID types.MessageID) (*messagesrepo.Message, error)
+	GetVerdictForUpdate(ctx context.Context, msgID types.MessageID) (*messagesrepo.Verdict, error)
+	MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID, source string, at time.Time) error
+	BlockMessage(ctx context.Context, msgID types.MessageID, source string, at time.Time) error
+}
+
+type searcher interface {
+	Search(msg string) (bloodhound.Verdict, error)
+}
+
+type bodyRedactor interface {
+	RedactMessage(ctx context.Context, msgID types.MessageID) error
+}
+
+type outboxService interface {
+	PutUnique(ctx context.Context, name, payload, dedupKey string, availableAt time.Time) (types.JobID, error)
+}
+
+type transactor interface {
+	RunInTx(ctx context.Context, f func(context.Context) error) error
+}
+
+//go:generate options-gen -out-filename=job_options.gen.go -from-struct=Options
+type Options struct {
+	messageRepository messageRepository `option:"mandatory" validate:"required"`
+	outboxService     outboxService     `option:"mandatory" validate:"required"`
+	txtor             transactor        `option:"mandatory" validate:"required"`
+	searcher          searcher          `option:"mandatory" validate:"required"`
+
+	// blockScore is the verdict score the message is blocked with.
+	blockScore int `default:"1" validate:"min=1"`
+	// bodyRedactor redacts the body of the blocked messages if set.
+	bodyRedactor bodyRedactor
+}
+
+type Job struct {
+	outbox.DefaultJob
+	Options
+}
+
+func New(opts Options) (*Job, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating local verdict job options: %v", err)
+	}
+	return &Job{Options: opts}, nil
+}
+
+// DedupKey is the natural deduplication key of the job: one fallback check per message.
+func DedupKey(msgID types.MessageID) string {
+	return Name + ":" + msgID.String()
+}
+
+func (j *Job) Name() string {
+	return Name
+}
+
+// Handle checks the message with bloodhound unless it already has a verdict.
+func (j *Job) Handle(ctx context.Context, payload string) (err error) {
+	defer func() {
+		if err != nil {
+			zap.L().With(zap.String("payload", payload), zap.Error(err)).Debug("failed")
+		} else {
+			zap.L().With(zap.String("payload", payload)).Debug("success")
+		}
+	}()
+	msgID, err := types.Parse[types.MessageID](payload)
+	if err != nil {
+		return fmt.Errorf("parsing messageID: %v", err)
+	}
+
+	var verdict *bloodhound.Verdict
+	if err := j.txtor.RunInTx(ctx, func(ctx context.Context) error {
+		applied, err := j.messageRepository.GetVerdictForUpdate(ctx, msgID)
+		if err != nil {
+			return fmt.Errorf("getting applied verdict: %v", err)
+		}
+		if applied != nil {
+			return nil
+		}
+
+		msg, err := j.messageRepository.GetMessageByID(ctx, msgID)
+		if err != nil {
+			return fmt.Errorf("getting message by id: %v", err)
+		}
+		v, err := j.searcher.Search(msg.Body)
+		if err != nil {
+			return fmt.Errorf("searching sensitive data: %v", err)
+		}
+		verdict = &v
+		return j.apply(ctx, msgID, v)
+	}); err != nil {
+		return err
+	}
+
+	if verdict != nil {
+		zap.L().Named(Name).Info("local verdict applied, AFC hasn't responded in time",
+			zap.Stringer("msg_id", msgID),
+			zap.Stringer("facts", verdict),
+		)
+	}
+	return nil
+}
+
+func (j *Job) apply(ctx context.Context, msgID types.MessageID, v bloodhound.Verdict) error {
+	now := time.Now()
+	if v.Score() < j.blockScore {
+		if err := j.messageRepository.MarkAsVisibleForManager(ctx, msgID, messagesrepo.VerdictSourceLocal, now); err != nil {
+			return fmt.Errorf("mark visible for manager: %v", err)
+		}
+		if _, err := j.outboxService.PutUnique(ctx,
+			clientmessagesentjob.Name, msgID.String(), clientmessagesentjob.DedupKey(msgID), now,
+		); err != nil {
+			return fmt.Errorf("put job %s: %v", clientmessagesentjob.Name, err)
+		}
+		return nil
+	}
+
+	if err := j.messageRepository.BlockMessage(ctx, msgID, messagesrepo.VerdictSourceLocal, now); err != nil {
+		return fmt.Errorf("block message: %v", err)
+	}
+	if j.bodyRedactor != nil {
+		if err := j.bodyRedactor.RedactMessage(ctx, msgID); err != nil {
+			return fmt.Errorf("redact message: %w", err)
+		}
+	}
+	if _, err := j.outboxService.PutUnique(ctx,
+		clientmessageblockedjob.Name, msgID.String(), clientmessageblockedjob.DedupKey(msgID), now,
+	); err != nil {
+		return fmt.Errorf("put job %s: %v", clientmessageblockedjob.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Code generated by options-gen. DO NOT EDIT.
+package localverdictjob
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	messageRepository messageRepository,
+	outboxService outboxService,
+	txtor transactor,
+	searcher searcher,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+	o.blockScore = 1
+
+	o.messageRepository = messageRepository
+	o.outboxService = outboxService
+	o.txtor = txtor
+	o.searcher = searcher
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithBlockScore(opt int) OptOptionsSetter {
+	return func(o *Options) {
+		o.blockScore = opt
+	}
+}
+
+func WithBodyRedactor(opt bodyRedactor) OptOptionsSetter {
+	return func(o *Options) {
+		o.bodyRedactor = opt
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("messageRepository", _validate_Options_messageRepository(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("outboxService", _validate_Options_outboxService(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("txtor", _validate_Options_txtor(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("searcher", _validate_Options_searcher(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("blockScore", _validate_Options_blockScore(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_messageRepository(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.messageRepository, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `messageRepository` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_outboxService(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.outboxService, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `outboxService` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_txtor(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.txtor, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `txtor` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_searcher(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.searcher, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `searcher` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_blockScore(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.blockScore, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `blockScore` did not pass the test: %w", err)
+	}
+	return nil
+}
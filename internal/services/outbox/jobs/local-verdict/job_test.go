@@ -0,0 +1,121 @@
+package localverdictjob_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	"github.com/gerladeno/chat-service/internal/services/bloodhound"
+	"github.com/gerladeno/chat-service/internal/services/outbox"
+	clientmessageblockedjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-blocked"
+	clientmessagesentjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/client-message-sent"
+	localverdictjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/local-verdict"
+	localverdictjobmocks "github.com/gerladeno/chat-service/internal/services/outbox/jobs/local-verdict/mocks"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+func TestJob_Handle(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		opts    []localverdictjob.OptOptionsSetter
+		redact  bool
+		applied *messagesrepo.Verdict
+		expect  func(msgRepo *localverdictjobmocks.MockmessageRepository, outboxSvc *localverdictjobmocks.MockoutboxService)
+	}{
+		{
+			name: "clean message",
+			body: "Здравствуйте! Не могу зайти в мобильное приложение, что делать?",
+			expect: func(msgRepo *localverdictjobmocks.MockmessageRepository, outboxSvc *localverdictjobmocks.MockoutboxService) {
+				msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), gomock.Any(), messagesrepo.VerdictSourceLocal, gomock.Any())
+				outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name: "card number",
+			body: "Деньги я пересылал с карты 4279012606251578",
+			expect: func(msgRepo *localverdictjobmocks.MockmessageRepository, outboxSvc *localverdictjobmocks.MockoutboxService) {
+				msgRepo.EXPECT().BlockMessage(gomock.Any(), gomock.Any(), messagesrepo.VerdictSourceLocal, gomock.Any())
+				outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessageblockedjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:   "card number redacted",
+			body:   "Деньги я пересылал с карты 4279012606251578",
+			redact: true,
+			expect: func(msgRepo *localverdictjobmocks.MockmessageRepository, outboxSvc *localverdictjobmocks.MockoutboxService) {
+				msgRepo.EXPECT().BlockMessage(gomock.Any(), gomock.Any(), messagesrepo.VerdictSourceLocal, gomock.Any())
+				outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessageblockedjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name: "score below the threshold",
+			body: "Пишите на ivan.kuzyakin@example.com",
+			opts: []localverdictjob.OptOptionsSetter{localverdictjob.WithBlockScore(5)},
+			expect: func(msgRepo *localverdictjobmocks.MockmessageRepository, outboxSvc *localverdictjobmocks.MockoutboxService) {
+				msgRepo.EXPECT().MarkAsVisibleForManager(gomock.Any(), gomock.Any(), messagesrepo.VerdictSourceLocal, gomock.Any())
+				outboxSvc.EXPECT().PutUnique(gomock.Any(), clientmessagesentjob.Name, gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name: "afc verdict already applied",
+			body: "Деньги я пересылал с карты 4279012606251578",
+			applied: &messagesrepo.Verdict{
+				Status:    messagesrepo.VerdictStatusOK,
+				Source:    messagesrepo.VerdictSourceAFC,
+				At:        time.Now(),
+				CheckedAt: time.Now(),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange.
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			msgRepo := localverdictjobmocks.NewMockmessageRepository(ctrl)
+			outboxSvc := localverdictjobmocks.NewMockoutboxService(ctrl)
+			txtor := localverdictjobmocks.NewMocktransactor(ctrl)
+			txtor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, f func(ctx context.Context) error) error {
+					return f(ctx)
+				})
+			msgID := types.NewMessageID()
+			opts := tt.opts
+			if tt.redact {
+				redactor := localverdictjobmocks.NewMockbodyRedactor(ctrl)
+				redactor.EXPECT().RedactMessage(gomock.Any(), msgID).Return(nil)
+				opts = append(opts, localverdictjob.WithBodyRedactor(redactor))
+			}
+			job, err := localverdictjob.New(localverdictjob.NewOptions(msgRepo, outboxSvc, txtor, bloodhound.NewSearcher(), opts...))
+			require.NoError(t, err)
+
+			msgRepo.EXPECT().GetVerdictForUpdate(gomock.Any(), msgID).Return(tt.applied, nil)
+			if tt.applied == nil {
+				msgRepo.EXPECT().GetMessageByID(gomock.Any(), msgID).Return(&messagesrepo.Message{
+					ID:       msgID,
+					ChatID:   types.NewChatID(),
+					AuthorID: types.NewUserID(),
+					Body:     tt.body,
+				}, nil)
+			}
+			if tt.expect != nil {
+				tt.expect(msgRepo, outboxSvc)
+			}
+
+			// Action & assert.
+			payload, err := outbox.MarshalPayload(msgID)
+			require.NoError(t, err)
+			err = job.Handle(ctx, payload)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job.go
+
+// Package localverdictjobmocks is a generated GoMock package.
+package localverdictjobmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	bloodhound "github.com/gerladeno/chat-service/internal/services/bloodhound"
+	types "github.com/gerladeno/chat-service/internal/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockmessageRepository is a mock of messageRepository interface.
+type MockmessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockmessageRepositoryMockRecorder
+}
+
+// MockmessageRepositoryMockRecorder is the mock recorder for MockmessageRepository.
+type MockmessageRepositoryMockRecorder struct {
+	mock *MockmessageRepository
+}
+
+// NewMockmessageRepository creates a new mock instance.
+func NewMockmessageRepository(ctrl *gomock.Controller) *MockmessageRepository {
+	mock := &MockmessageRepository{ctrl: ctrl}
+	mock.recorder = &MockmessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmessageRepository) EXPECT() *MockmessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BlockMessage mocks base method.
+func (m *MockmessageRepository) BlockMessage(ctx context.Context, msgID types.MessageID, source string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockMessage", ctx, msgID, source, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockMessage indicates an expected call of BlockMessage.
+func (mr *MockmessageRepositoryMockRecorder) BlockMessage(ctx, msgID, source, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockMessage", reflect.TypeOf((*MockmessageRepository)(nil).BlockMessage), ctx, msgID, source, at)
+}
+
+// GetMessageByID mocks base method.
+func (m *MockmessageRepository) GetMessageByID(ctx context.Context, msgID types.MessageID) (*messagesrepo.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByID", ctx, msgID)
+	ret0, _ := ret[0].(*messagesrepo.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByID indicates an expected call of GetMessageByID.
+func (mr *MockmessageRepositoryMockRecorder) GetMessageByID(ctx, msgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockmessageRepository)(nil).GetMessageByID), ctx, msgID)
+}
+
+// GetVerdictForUpdate mocks base method.
+func (m *MockmessageRepository) GetVerdictForUpdate(ctx context.Context, msgID types.MessageID) (*messagesrepo.Verdict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerdictForUpdate", ctx, msgID)
+	ret0, _ := ret[0].(*messagesrepo.Verdict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerdictForUpdate indicates an expected call of GetVerdictForUpdate.
+func (mr *MockmessageRepositoryMockRecorder) GetVerdictForUpdate(ctx, msgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerdictForUpdate", reflect.TypeOf((*MockmessageRepository)(nil).GetVerdictForUpdate), ctx, msgID)
+}
+
+// MarkAsVisibleForManager mocks base method.
+func (m *MockmessageRepository) MarkAsVisibleForManager(ctx context.Context, msgID types.MessageID, source string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsVisibleForManager", ctx, msgID, source, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsVisibleForManager indicates an expected call of MarkAsVisibleForManager.
+func (mr *MockmessageRepositoryMockRecorder) MarkAsVisibleForManager(ctx, msgID, source, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsVisibleForManager", reflect.TypeOf((*MockmessageRepository)(nil).MarkAsVisibleForManager), ctx, msgID, source, at)
+}
+
+// Mocksearcher is a mock of searcher interface.
+type Mocksearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearcherMockRecorder
+}
+
+// MocksearcherMockRecorder is the mock recorder for Mocksearcher.
+type MocksearcherMockRecorder struct {
+	mock *Mocksearcher
+}
+
+// NewMocksearcher creates a new mock instance.
+func NewMocksearcher(ctrl *gomock.Controller) *Mocksearcher {
+	mock := &Mocksearcher{ctrl: ctrl}
+	mock.recorder = &MocksearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksearcher) EXPECT() *MocksearcherMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *Mocksearcher) Search(msg string) (bloodhound.Verdict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", msg)
+	ret0, _ := ret[0].(bloodhound.Verdict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MocksearcherMockRecorder) Search(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mocksearcher)(nil).Search), msg)
+}
+
+// MockbodyRedactor is a mock of bodyRedactor interface.
+type MockbodyRedactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockbodyRedactorMockRecorder
+}
+
+// MockbodyRedactorMockRecorder is the mock recorder for MockbodyRedactor.
+type MockbodyRedactorMockRecorder struct {
+	mock *MockbodyRedactor
+}
+
+// NewMockbodyRedactor creates a new mock instance.
+func NewMockbodyRedactor(ctrl *gomock.Controller) *MockbodyRedactor {
+	mock := &MockbodyRedactor{ctrl: ctrl}
+	mock.recorder = &MockbodyRedactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbodyRedactor) EXPECT() *MockbodyRedactorMockRecorder {
+	return m.recorder
+}
+
+// RedactMessage mocks base method.
+func (m *MockbodyRedactor) RedactMessage(ctx context.Context, msgID types.MessageID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactMessage", ctx, msgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedactMessage indicates an expected call of RedactMessage.
+func (mr *MockbodyRedactorMockRecorder) RedactMessage(ctx, msgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactMessage", reflect.TypeOf((*MockbodyRedactor)(nil).RedactMessage), ctx, msgID)
+}
+
+// MockoutboxService is a mock of outboxService interface.
+type MockoutboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxServiceMockRecorder
+}
+
+// MockoutboxServiceMockRecorder is the mock recorder for MockoutboxService.
+type MockoutboxServiceMockRecorder struct {
+	mock *MockoutboxService
+}
+
+// NewMockoutboxService creates a new mock instance.
+func NewMockoutboxService(ctrl *gomock.Controller) *MockoutboxService {
+	mock := &MockoutboxService{ctrl: ctrl}
+	mock.recorder = &MockoutboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxService) EXPECT() *MockoutboxServiceMockRecorder {
+	return m.recorder
+}
+
+// PutUnique mocks base method.
+func (m *MockoutboxService) PutUnique(ctx context.Context, name, payload, dedupKey string, availableAt time.Time) (types.JobID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutUnique", ctx, name, payload, dedupKey, availableAt)
+	ret0, _ := ret[0].(types.JobID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutUnique indicates an expected call of PutUnique.
+func (mr *MockoutboxServiceMockRecorder) PutUnique(ctx, name, payload, dedupKey, availableAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutUnique", reflect.TypeOf((*MockoutboxService)(nil).PutUnique), ctx, name, payload, dedupKey, availableAt)
+}
+
+// Mocktransactor is a mock of transactor interface.
+type Mocktransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactorMockRecorder
+}
+
+// MocktransactorMockRecorder is the mock recorder for Mocktransactor.
+type MocktransactorMockRecorder struct {
+	mock *Mocktransactor
+}
+
+// NewMocktransactor creates a new mock instance.
+func NewMocktransactor(ctrl *gomock.Controller) *Mocktransactor {
+	mock := &Mocktransactor{ctrl: ctrl}
+	mock.recorder = &MocktransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktransactor) EXPECT() *MocktransactorMockRecorder {
+	return m.recorder
+}
+
+// RunInTx mocks base method.
+func (m *Mocktransactor) RunInTx(ctx context.Context, f func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTx", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MocktransactorMockRecorder) RunInTx(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*Mocktransactor)(nil).RunInTx), ctx, f)
+}
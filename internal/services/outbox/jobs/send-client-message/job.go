@@ -73,6 +73,7 @@ func (j *Job) Handle(ctx context.Context, payload string) (err error) {
 		ChatID:     msg.ChatID,
 		Body:       msg.Body,
 		FromClient: !msg.AuthorID.IsZero(),
+		RequestID:  msg.RequestID,
 	}); err != nil {
 		return fmt.Errorf("producing message: %v", err)
 	}
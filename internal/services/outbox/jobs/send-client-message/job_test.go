@@ -36,6 +36,7 @@ func TestJob_Handle(t *testing.T) {
 
 	msg := messagesrepo.Message{
 		ID:                  msgID,
+		RequestID:           types.NewRequestID(),
 		ChatID:              chatID,
 		AuthorID:            clientID,
 		Body:                body,
@@ -52,6 +53,7 @@ func TestJob_Handle(t *testing.T) {
 		ChatID:     chatID,
 		Body:       body,
 		FromClient: true,
+		RequestID:  msg.RequestID,
 	}).Return(nil)
 
 	eventStream.EXPECT().Publish(ctx, msg.AuthorID, eventstream.NewNewMessageEvent(
@@ -10,3 +10,8 @@ func MarshalPayload(messageID types.MessageID) (string, error) {
 	}
 	return messageID.String(), nil
 }
+
+// DedupKey is the natural deduplication key of the job: the message is sent only once.
+func DedupKey(messageID types.MessageID) string {
+	return Name + ":" + messageID.String()
+}
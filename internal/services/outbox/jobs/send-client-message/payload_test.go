@@ -23,3 +23,9 @@ func TestMarshalPayload_Smoke(t *testing.T) {
 		assert.Empty(t, p)
 	})
 }
+
+func TestDedupKey(t *testing.T) {
+	msgID := types.NewMessageID()
+	assert.Equal(t, sendclientmessagejob.DedupKey(msgID), sendclientmessagejob.DedupKey(msgID))
+	assert.NotEqual(t, sendclientmessagejob.DedupKey(msgID), sendclientmessagejob.DedupKey(types.NewMessageID()))
+}
@@ -0,0 +1,216 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	jobsrepo "github.com/gerladeno/chat-service/internal/repositories/jobs"
+)
+
+const (
+	metricsNamespace      = "chat_service"
+	metricsCollectTimeout = 3 * time.Second
+)
+
+// LatencyBuckets are the upper bounds of the job handler latency histogram.
+var LatencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+type JobStats struct {
+	Name             string
+	Pending          int
+	OldestPendingAge time.Duration
+	Executions       uint64
+	Failures         uint64
+	DLQMoves         uint64
+	Latency          Histogram
+}
+
+// Histogram is the handler latency distribution. Buckets are cumulative and match LatencyBuckets.
+type Histogram struct {
+	Count   uint64
+	Sum     time.Duration
+	Buckets []uint64
+}
+
+// Mean returns the average handler latency.
+func (h Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// jobMetrics collects the in-memory per job name counters of the current replica.
+type jobMetrics struct {
+	mu   sync.Mutex
+	jobs map[string]*JobStats
+}
+
+func newJobMetrics() *jobMetrics {
+	return &jobMetrics{jobs: make(map[string]*JobStats)}
+}
+
+// get must be called under mu.
+func (m *jobMetrics) get(name string) *JobStats {
+	st, ok := m.jobs[name]
+	if !ok {
+		st = &JobStats{Name: name, Latency: Histogram{Buckets: make([]uint64, len(LatencyBuckets))}}
+		m.jobs[name] = st
+	}
+	return st
+}
+
+func (m *jobMetrics) observeExecution(name string, latency time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	st := m.get(name)
+	st.Executions++
+	if failed {
+		st.Failures++
+	}
+	st.Latency.Count++
+	st.Latency.Sum += latency
+	for i, b := range LatencyBuckets {
+		if latency <= b {
+			st.Latency.Buckets[i]++
+		}
+	}
+}
+
+func (m *jobMetrics) observeDLQMove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.get(name).DLQMoves++
+}
+
+// snapshot merges the counters with the pending stats. The names are sorted.
+func (m *jobMetrics) snapshot(registered []string, pending []jobsrepo.PendingStat) []JobStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, name := range registered {
+		m.get(name)
+	}
+	byName := make(map[string]JobStats, len(m.jobs)+len(pending))
+	for name, st := range m.jobs {
+		cp := *st
+		cp.Latency.Buckets = append([]uint64(nil), st.Latency.Buckets...)
+		byName[name] = cp
+	}
+	now := time.Now()
+	for _, p := range pending {
+		st, ok := byName[p.Name]
+		if !ok {
+			st = JobStats{Name: p.Name, Latency: Histogram{Buckets: make([]uint64, len(LatencyBuckets))}}
+		}
+		st.Pending = p.Count
+		st.OldestPendingAge = now.Sub(p.OldestCreatedAt)
+		byName[p.Name] = st
+	}
+
+	result := make([]JobStats, 0, len(byName))
+	for _, st := range byName {
+		result = append(result, st)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Stats returns the queue state from the DB and the execution counters of this replica per job name.
+func (s *Service) Stats(ctx context.Context) ([]JobStats, error) {
+	pending, err := s.jobsRepo.GetPendingStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get pending stats: %v", err)
+	}
+	return s.metrics.snapshot(s.balancer.registered(), pending), nil
+}
+
+var (
+	pendingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, serviceName, "pending_jobs"),
+		"Number of jobs waiting in the queue, the reserved ones excluded.",
+		[]string{"job"}, nil,
+	)
+	oldestPendingAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, serviceName, "oldest_pending_job_age_seconds"),
+		"Age of the oldest job waiting in the queue.",
+		[]string{"job"}, nil,
+	)
+	executionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, serviceName, "job_executions_total"),
+		"Number of job handler calls.",
+		[]string{"job"}, nil,
+	)
+	failuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, serviceName, "job_failures_total"),
+		"Number of job handler errors.",
+		[]string{"job"}, nil,
+	)
+	dlqMovesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, serviceName, "job_dlq_moves_total"),
+		"Number of jobs moved to the DLQ.",
+		[]string{"job"}, nil,
+	)
+	latencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, serviceName, "job_handle_duration_seconds"),
+		"Job handler latency.",
+		[]string{"job"}, nil,
+	)
+)
+
+// Describe implements prometheus.Collector.
+func (s *Service) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pendingDesc
+	ch <- oldestPendingAgeDesc
+	ch <- executionsDesc
+	ch <- failuresDesc
+	ch <- dlqMovesDesc
+	ch <- latencyDesc
+}
+
+// Collect implements prometheus.Collector. The pending jobs are counted on every scrape.
+func (s *Service) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsCollectTimeout)
+	defer cancel()
+
+	pending, err := s.jobsRepo.GetPendingStats(ctx)
+	if err != nil {
+		zap.L().Named(serviceName).Warn("collecting pending jobs metrics failed", zap.Error(err))
+	}
+	for _, st := range s.metrics.snapshot(s.balancer.registered(), pending) {
+		if err == nil {
+			ch <- prometheus.MustNewConstMetric(pendingDesc, prometheus.GaugeValue, float64(st.Pending), st.Name)
+			ch <- prometheus.MustNewConstMetric(oldestPendingAgeDesc, prometheus.GaugeValue,
+				st.OldestPendingAge.Seconds(), st.Name)
+		}
+		ch <- prometheus.MustNewConstMetric(executionsDesc, prometheus.CounterValue, float64(st.Executions), st.Name)
+		ch <- prometheus.MustNewConstMetric(failuresDesc, prometheus.CounterValue, float64(st.Failures), st.Name)
+		ch <- prometheus.MustNewConstMetric(dlqMovesDesc, prometheus.CounterValue, float64(st.DLQMoves), st.Name)
+
+		buckets := make(map[float64]uint64, len(LatencyBuckets))
+		for i, b := range LatencyBuckets {
+			buckets[b.Seconds()] = st.Latency.Buckets[i]
+		}
+		ch <- prometheus.MustNewConstHistogram(latencyDesc,
+			st.Latency.Count, st.Latency.Sum.Seconds(), buckets, st.Name)
+	}
+}
@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// schedulerLockKey is the key of the PostgreSQL advisory lock,
+// so that only one replica enqueues scheduled jobs at the same moment.
+const schedulerLockKey int64 = 0x6f7574626f78 // "outbox".
+
+var ErrJobNotRegistered = errors.New("job is not registered")
+
+// Schedule describes a recurring job.
+type Schedule struct {
+	// Spec is a standard cron expression ("*/5 * * * *") or a descriptor ("@hourly", "@every 30s").
+	Spec string
+	// Payload is passed to every scheduled execution of the job.
+	Payload string
+}
+
+type scheduledJob struct {
+	name     string
+	payload  string
+	schedule cron.Schedule
+	// next is the known occurrence, the shared one is checked when it is due.
+	next time.Time
+}
+
+// AddSchedule makes the registered job recurring.
+func (s *Service) AddSchedule(name string, schedule Schedule) error {
+	if _, ok := s.registry[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotRegistered, name)
+	}
+	sch, err := cron.ParseStandard(schedule.Spec)
+	if err != nil {
+		return fmt.Errorf("parsing schedule %q of job %s: %v", schedule.Spec, name, err)
+	}
+	s.schedules = append(s.schedules, &scheduledJob{
+		name:     name,
+		payload:  schedule.Payload,
+		schedule: sch,
+		next:     sch.Next(time.Now()),
+	})
+	return nil
+}
+
+func (s *Service) runScheduler(ctx context.Context) {
+	log := zap.L().With(zap.String("service", serviceName))
+	t := time.NewTicker(s.schedulerTick)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		if err := s.enqueueScheduled(ctx, time.Now()); err != nil {
+			log.Warn("enqueueing scheduled jobs failed", zap.Error(err))
+		}
+	}
+}
+
+// enqueueScheduled puts the due scheduled jobs into the queue. The occurrences are shared by the replicas
+// via the job schedules table, so the replica that got the lock enqueues each of them once
+// no matter when the replicas were started. The others check them again on the next tick.
+func (s *Service) enqueueScheduled(ctx context.Context, now time.Time) error {
+	var due []*scheduledJob
+	for _, sj := range s.schedules {
+		if !sj.next.After(now) {
+			due = append(due, sj)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	next := make(map[*scheduledJob]time.Time, len(due))
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		isLeader, err := s.jobsRepo.TryAdvisoryXactLock(ctx, schedulerLockKey)
+		if err != nil {
+			return fmt.Errorf("acquiring scheduler lock: %v", err)
+		}
+		if !isLeader {
+			return nil
+		}
+
+		for _, sj := range due {
+			runAt, err := s.jobsRepo.LockScheduleNextRun(ctx, sj.name, sj.next)
+			if err != nil {
+				return fmt.Errorf("getting schedule of job %s: %v", sj.name, err)
+			}
+			if runAt.After(now) {
+				next[sj] = runAt // Another replica has enqueued the occurrence.
+				continue
+			}
+
+			dedupKey := fmt.Sprintf("schedule:%s:%d", sj.name, runAt.Unix())
+			if _, err := s.PutUnique(ctx, sj.name, sj.payload, dedupKey, now); err != nil {
+				return fmt.Errorf("putting scheduled job %s: %v", sj.name, err)
+			}
+			// Missed occurrences are not caught up, the job is enqueued once.
+			next[sj] = sj.schedule.Next(now)
+			if err := s.jobsRepo.SetScheduleNextRun(ctx, sj.name, next[sj]); err != nil {
+				return fmt.Errorf("moving schedule of job %s: %v", sj.name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for sj, t := range next {
+		sj.next = t
+	}
+	return nil
+}
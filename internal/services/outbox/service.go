@@ -22,9 +22,21 @@ const (
 
 type jobsRepository interface {
 	CreateJob(ctx context.Context, name, payload string, availableAt time.Time) (types.JobID, error)
-	FindAndReserveJob(ctx context.Context, until time.Time) (jobsrepo.Job, error)
+	CreateUniqueJob(ctx context.Context, name, payload, dedupKey string, availableAt time.Time) (types.JobID, error)
+	FindAndReserveJob(ctx context.Context, until time.Time, names ...string) (jobsrepo.Job, error)
+	FindAndReserveJobExcept(ctx context.Context, until time.Time, names ...string) (jobsrepo.Job, error)
 	CreateFailedJob(ctx context.Context, name, payload, reason string) error
 	DeleteJob(ctx context.Context, jobID types.JobID) error
+	ReleaseJob(ctx context.Context, jobID types.JobID) error
+	CreateCompletedJob(ctx context.Context, name, dedupKey string, expiresAt time.Time) error
+	DeleteExpiredCompletedJobs(ctx context.Context, before time.Time) (int, error)
+	TryAdvisoryXactLock(ctx context.Context, key int64) (bool, error)
+	LockScheduleNextRun(ctx context.Context, name string, firstRunAt time.Time) (time.Time, error)
+	SetScheduleNextRun(ctx context.Context, name string, nextRunAt time.Time) error
+	GetPendingStats(ctx context.Context) ([]jobsrepo.PendingStat, error)
+	GetFailedJobs(ctx context.Context, limit int) ([]jobsrepo.FailedJob, error)
+	GetFailedJob(ctx context.Context, id types.FailedJobID) (jobsrepo.FailedJob, error)
+	ReplayFailedJob(ctx context.Context, id types.FailedJobID) (types.JobID, error)
 }
 
 type transactor interface {
@@ -40,15 +52,28 @@ type Options struct {
 	reserveFor time.Duration  `option:"mandatory" validate:"min=1s,max=10m"`
 	jobsRepo   *jobsrepo.Repo `option:"mandatory"`
 	db         transactor     `option:"mandatory"`
+
+	// dedupTTL is how long the dedup key of a completed job prevents putting the same job again.
+	dedupTTL time.Duration `default:"1h" validate:"min=1s,max=168h"`
+	// schedulerTick is how often the scheduler checks for due recurring jobs.
+	schedulerTick time.Duration `default:"1s" validate:"min=10ms,max=1m"`
+	// drainTimeout is the grace period given to the running jobs on shutdown.
+	drainTimeout time.Duration `default:"10s" validate:"max=5m"`
 }
 
 type Service struct {
-	workers    int
-	idleTime   time.Duration
-	reserveFor time.Duration
-	registry   map[string]Job
-	jobsRepo   jobsRepository
-	db         transactor
+	workers       int
+	idleTime      time.Duration
+	reserveFor    time.Duration
+	dedupTTL      time.Duration
+	schedulerTick time.Duration
+	drainTimeout  time.Duration
+	registry      map[string]Job
+	balancer      *balancer
+	metrics       *jobMetrics
+	schedules     []*scheduledJob
+	jobsRepo      jobsRepository
+	db            transactor
 }
 
 func New(opts Options) (*Service, error) {
@@ -56,36 +81,76 @@ func New(opts Options) (*Service, error) {
 		return nil, fmt.Errorf("validating outbox service options: %v", err)
 	}
 	return &Service{
-		registry:   make(map[string]Job),
-		workers:    opts.workers,
-		idleTime:   opts.idleTime,
-		reserveFor: opts.reserveFor,
-		jobsRepo:   opts.jobsRepo,
-		db:         opts.db,
+		registry:      make(map[string]Job),
+		balancer:      newBalancer(),
+		metrics:       newJobMetrics(),
+		workers:       opts.workers,
+		idleTime:      opts.idleTime,
+		reserveFor:    opts.reserveFor,
+		dedupTTL:      opts.dedupTTL,
+		schedulerTick: opts.schedulerTick,
+		drainTimeout:  opts.drainTimeout,
+		jobsRepo:      opts.jobsRepo,
+		db:            opts.db,
 	}, nil
 }
 
-func (s *Service) RegisterJob(job Job) error {
+// RegisterJob registers the job handler. Optional schedules make the job recurring.
+func (s *Service) RegisterJob(job Job, schedules ...Schedule) error {
 	if _, ok := s.registry[job.Name()]; ok {
 		return ErrJobAlreadyExists
 	}
 	s.registry[job.Name()] = job
+	n := len(s.schedules)
+	for _, sch := range schedules {
+		if err := s.AddSchedule(job.Name(), sch); err != nil {
+			delete(s.registry, job.Name())
+			s.schedules = s.schedules[:n]
+			return err
+		}
+	}
+	s.balancer.add(job.Name(), job.MaxConcurrency(), job.Weight())
 	return nil
 }
 
-func (s *Service) MustRegisterJob(job Job) {
-	if err := s.RegisterJob(job); err != nil {
+func (s *Service) MustRegisterJob(job Job, schedules ...Schedule) {
+	if err := s.RegisterJob(job, schedules...); err != nil {
 		panic(err)
 	}
 }
 
+// Run runs the workers until ctx is done. Then the workers stop reserving new jobs
+// and the running ones are given drainTimeout to finish.
 func (s *Service) Run(ctx context.Context) error {
+	handleCtx, cancelHandle := context.WithCancel(context.Background())
+	defer cancelHandle()
+	go func() {
+		select {
+		case <-ctx.Done():
+			utils.Sleep(handleCtx, s.drainTimeout)
+			cancelHandle()
+		case <-handleCtx.Done():
+		}
+	}()
+
 	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.runCompletedJobsCleaner(ctx)
+	}()
+	if len(s.schedules) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runScheduler(ctx)
+		}()
+	}
 	for i := 0; i < s.workers; i++ {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			s.runWorker(ctx, i)
+			s.runWorker(ctx, handleCtx, i)
 		}(i)
 	}
 	zap.L().Named(serviceName).Info("started outbox worker")
@@ -93,7 +158,7 @@ func (s *Service) Run(ctx context.Context) error {
 	return nil
 }
 
-func (s *Service) runWorker(ctx context.Context, workerID int) {
+func (s *Service) runWorker(ctx, handleCtx context.Context, workerID int) {
 	log := zap.L().With(zap.String("service", serviceName), zap.Int("worker_id", workerID))
 	var err error
 	for {
@@ -102,7 +167,7 @@ func (s *Service) runWorker(ctx context.Context, workerID int) {
 			return
 		default:
 		}
-		err = s.execute(ctx, log)
+		err = s.execute(ctx, handleCtx, log)
 		switch {
 		case errors.Is(err, jobsrepo.ErrNoJobs):
 			log.Debug(fmt.Sprintf("out of jobs, idling for %d milliseconds", s.idleTime.Milliseconds()))
@@ -113,8 +178,9 @@ func (s *Service) runWorker(ctx context.Context, workerID int) {
 	}
 }
 
-func (s *Service) execute(ctx context.Context, log *zap.Logger) (err error) {
-	task, err := s.jobsRepo.FindAndReserveJob(ctx, time.Now().Add(s.reserveFor))
+// execute reserves a job using ctx and handles it using handleCtx, that outlives ctx on shutdown.
+func (s *Service) execute(ctx, handleCtx context.Context, log *zap.Logger) (err error) {
+	task, err := s.reserve(ctx)
 	if err != nil {
 		return fmt.Errorf("get a new task: %w", err)
 	}
@@ -135,10 +201,25 @@ func (s *Service) execute(ctx context.Context, log *zap.Logger) (err error) {
 	if !ok {
 		return s.moveToDLQ(ctx, task, reasonJobNotFound)
 	}
+	defer s.balancer.release(task.Name)
 
-	ctx, cancel := context.WithTimeout(ctx, job.ExecutionTimeout())
+	ctx, cancel := context.WithTimeout(handleCtx, job.ExecutionTimeout())
 	defer cancel()
-	if err = job.Handle(ctx, task.Payload); err != nil {
+	start := time.Now()
+	err = job.Handle(ctx, task.Payload)
+	interrupted := err != nil && handleCtx.Err() != nil
+	if !interrupted {
+		s.metrics.observeExecution(task.Name, time.Since(start), err != nil)
+	}
+	if err != nil {
+		if interrupted {
+			// The job was interrupted by the shutdown, so the attempt is not counted
+			// and another replica is able to pick the job up immediately.
+			if err := s.jobsRepo.ReleaseJob(context.Background(), task.ID); err != nil {
+				l.Warn("err during releasing an interrupted job", zap.Error(err))
+			}
+			return fmt.Errorf("job %v interrupted by shutdown: %v", task, err)
+		}
 		if task.Attempts >= job.MaxAttempts() {
 			if err := s.moveToDLQ(context.Background(), task, reasonFailedAttemptsLimitExceeded); err != nil {
 				l.Warn("err during handling an error", zap.Error(err))
@@ -147,14 +228,69 @@ func (s *Service) execute(ctx context.Context, log *zap.Logger) (err error) {
 		return fmt.Errorf("handling a job %v: %v", task, err)
 	}
 	// Сюда мы попадаем, если джоба успешно выполнена. Даже если контекст истёк, её надо удалить.
-	if err = s.jobsRepo.DeleteJob(context.Background(), task.ID); err != nil {
-		return fmt.Errorf("delete successfully handled job: %v", err)
+	if err = s.complete(context.Background(), task); err != nil {
+		return fmt.Errorf("complete successfully handled job: %v", err)
 	}
 	return nil
 }
 
-func (s *Service) moveToDLQ(ctx context.Context, task jobsrepo.Job, reason string) error {
+// reserve tries the job names in the balancer order and reserves the first
+// available job whose name has a free execution slot. The slot is kept on success.
+func (s *Service) reserve(ctx context.Context) (jobsrepo.Job, error) {
+	until := time.Now().Add(s.reserveFor)
+	for _, name := range s.balancer.order() {
+		if !s.balancer.acquire(name) {
+			continue
+		}
+		task, err := s.jobsRepo.FindAndReserveJob(ctx, until, name)
+		if err == nil {
+			return task, nil
+		}
+		s.balancer.release(name)
+		if !errors.Is(err, jobsrepo.ErrNoJobs) {
+			return jobsrepo.Job{}, err
+		}
+	}
+	// The jobs without registered handlers are reserved to be moved to the DLQ.
+	return s.jobsRepo.FindAndReserveJobExcept(ctx, until, s.balancer.registered()...)
+}
+
+func (s *Service) complete(ctx context.Context, task jobsrepo.Job) error {
+	if task.DedupKey == "" {
+		return s.jobsRepo.DeleteJob(ctx, task.ID)
+	}
 	return s.db.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.jobsRepo.DeleteJob(ctx, task.ID); err != nil {
+			return fmt.Errorf("delete job: %v", err)
+		}
+		if err := s.jobsRepo.CreateCompletedJob(ctx, task.Name, task.DedupKey, time.Now().Add(s.dedupTTL)); err != nil {
+			return fmt.Errorf("remember dedup key: %v", err)
+		}
+		return nil
+	})
+}
+
+func (s *Service) runCompletedJobsCleaner(ctx context.Context) {
+	log := zap.L().With(zap.String("service", serviceName))
+	t := time.NewTicker(s.dedupTTL)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		n, err := s.jobsRepo.DeleteExpiredCompletedJobs(ctx, time.Now())
+		if err != nil {
+			log.Warn("deleting expired completed jobs failed", zap.Error(err))
+			continue
+		}
+		log.Debug(fmt.Sprintf("deleted %d expired completed jobs", n))
+	}
+}
+
+func (s *Service) moveToDLQ(ctx context.Context, task jobsrepo.Job, reason string) error {
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
 		if err := s.jobsRepo.DeleteJob(ctx, task.ID); err != nil {
 			return fmt.Errorf("delete not found job: %v", err)
 		}
@@ -163,4 +299,9 @@ func (s *Service) moveToDLQ(ctx context.Context, task jobsrepo.Job, reason strin
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	s.metrics.observeDLQMove(task.Name)
+	return nil
 }
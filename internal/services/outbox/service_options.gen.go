@@ -23,6 +23,9 @@ func NewOptions(
 	o := Options{}
 
 	// Setting defaults from field tag (if present)
+	o.dedupTTL, _ = time.ParseDuration("1h")
+	o.schedulerTick, _ = time.ParseDuration("1s")
+	o.drainTimeout, _ = time.ParseDuration("10s")
 
 	o.workers = workers
 	o.idleTime = idleTime
@@ -36,11 +39,32 @@ func NewOptions(
 	return o
 }
 
+func WithDedupTTL(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.dedupTTL = opt
+	}
+}
+
+func WithSchedulerTick(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.schedulerTick = opt
+	}
+}
+
+func WithDrainTimeout(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.drainTimeout = opt
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("workers", _validate_Options_workers(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("idleTime", _validate_Options_idleTime(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("reserveFor", _validate_Options_reserveFor(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("dedupTTL", _validate_Options_dedupTTL(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("schedulerTick", _validate_Options_schedulerTick(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("drainTimeout", _validate_Options_drainTimeout(o)))
 	return errs.AsError()
 }
 
@@ -64,3 +88,24 @@ func _validate_Options_reserveFor(o *Options) error {
 	}
 	return nil
 }
+
+func _validate_Options_dedupTTL(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.dedupTTL, "min=1s,max=168h"); err != nil {
+		return fmt461e464ebed9.Errorf("field `dedupTTL` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_schedulerTick(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.schedulerTick, "min=10ms,max=1m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `schedulerTick` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_drainTimeout(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.drainTimeout, "max=5m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `drainTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
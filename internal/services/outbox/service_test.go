@@ -6,6 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,6 +17,7 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/goleak"
+	"golang.org/x/sync/errgroup"
 
 	jobsrepo "github.com/gerladeno/chat-service/internal/repositories/jobs"
 	"github.com/gerladeno/chat-service/internal/services/outbox"
@@ -33,6 +37,7 @@ func TestMain(m *testing.M) {
 type OutboxServiceSuite struct {
 	testingh.DBSuite
 	ctrl      *gomock.Controller
+	jobsRepo  *jobsrepo.Repo
 	outboxSvc *outbox.Service
 }
 
@@ -44,14 +49,15 @@ func TestOutboxServiceSuite(t *testing.T) {
 func (s *OutboxServiceSuite) SetupSuite() {
 	s.DBSuite.SetupSuite()
 
-	jobsRepo, err := jobsrepo.New(jobsrepo.NewOptions(s.Database))
+	var err error
+	s.jobsRepo, err = jobsrepo.New(jobsrepo.NewOptions(s.Database))
 	s.Require().NoError(err)
 
 	s.outboxSvc, err = outbox.New(outbox.NewOptions(
 		workers,
 		idleTime,
 		reserveFor,
-		jobsRepo,
+		s.jobsRepo,
 		s.Database,
 	))
 	s.Require().NoError(err)
@@ -67,6 +73,8 @@ func (s *OutboxServiceSuite) SetupTest() {
 
 	s.Database.Job(s.Ctx).Delete().ExecX(s.Ctx)
 	s.Database.FailedJob(s.Ctx).Delete().ExecX(s.Ctx)
+	s.Database.CompletedJob(s.Ctx).Delete().ExecX(s.Ctx)
+	s.Database.JobSchedule(s.Ctx).Delete().ExecX(s.Ctx)
 }
 
 func (s *OutboxServiceSuite) TearDownTest() {
@@ -108,6 +116,122 @@ func (s *OutboxServiceSuite) TestPutJob() {
 	s.NotEmpty(j.CreatedAt)
 }
 
+func (s *OutboxServiceSuite) TestPutUniqueJob() {
+	// Arrange.
+	const jobName = "TestPutUniqueJob"
+	const jobPayload = "{}"
+	const dedupKey = "TestPutUniqueJob:42"
+
+	job := newJobMock(jobName, nop, time.Second, 1)
+	s.outboxSvc.MustRegisterJob(job)
+
+	// Action.
+	jobID, err := s.outboxSvc.PutUnique(s.Ctx, jobName, jobPayload, dedupKey, time.Now())
+	s.Require().NoError(err)
+	s.Require().NotEmpty(jobID)
+
+	// Assert.
+	s.Run("pending job is not duplicated", func() {
+		dupID, err := s.outboxSvc.PutUnique(s.Ctx, jobName, jobPayload, dedupKey, time.Now())
+		s.Require().NoError(err)
+		s.True(dupID.IsZero())
+		s.Equal(1, s.Store.Job.Query().CountX(s.Ctx))
+	})
+
+	s.runOutboxFor(idleTime)
+	s.Require().Equal(1, job.ExecutedTimes())
+	s.Require().Equal(0, s.Store.Job.Query().CountX(s.Ctx))
+	s.Require().Equal(1, s.Store.CompletedJob.Query().CountX(s.Ctx))
+
+	s.Run("recently completed job is not duplicated", func() {
+		dupID, err := s.outboxSvc.PutUnique(s.Ctx, jobName, jobPayload, dedupKey, time.Now())
+		s.Require().NoError(err)
+		s.True(dupID.IsZero())
+		s.Equal(0, s.Store.Job.Query().CountX(s.Ctx))
+	})
+
+	s.Run("empty dedup key", func() {
+		_, err := s.outboxSvc.PutUnique(s.Ctx, jobName, jobPayload, "", time.Now())
+		s.Require().ErrorIs(err, outbox.ErrEmptyDedupKey)
+	})
+}
+
+func (s *OutboxServiceSuite) TestRegisterJob_InvalidSchedule() {
+	job := newJobMock("TestRegisterJob_InvalidSchedule", nop, time.Second, 1)
+
+	err := s.outboxSvc.RegisterJob(job, outbox.Schedule{Spec: "every minute"})
+	s.Require().Error(err)
+
+	s.Run("job is not registered after failure", func() {
+		s.NoError(s.outboxSvc.RegisterJob(job))
+	})
+
+	s.Run("schedule of unknown job", func() {
+		err := s.outboxSvc.AddSchedule("unknown-job", outbox.Schedule{Spec: "@hourly"})
+		s.ErrorIs(err, outbox.ErrJobNotRegistered)
+	})
+}
+
+func (s *OutboxServiceSuite) TestScheduledJobs() {
+	// Arrange.
+	const jobName = "TestScheduledJobs"
+	const jobPayload = "{}"
+
+	var payloads []string
+	var mu sync.Mutex
+	job := newJobMock(jobName, func(ctx context.Context, payload string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		payloads = append(payloads, payload)
+		return nil
+	}, time.Second, 1)
+
+	// Two replicas compete for the scheduler lock.
+	newReplica := func() *outbox.Service {
+		svc, err := outbox.New(outbox.NewOptions(
+			workers,
+			idleTime,
+			reserveFor,
+			s.jobsRepo,
+			s.Database,
+			outbox.WithSchedulerTick(50*time.Millisecond),
+		))
+		s.Require().NoError(err)
+		s.Require().NoError(svc.RegisterJob(job, outbox.Schedule{Spec: "@every 2s", Payload: jobPayload}))
+		return svc
+	}
+
+	// Action.
+	ctx, cancel := context.WithTimeout(s.Ctx, 5*time.Second)
+	defer cancel()
+
+	eg, ctx := errgroup.WithContext(ctx)
+	first := newReplica()
+	eg.Go(func() error { return first.Run(ctx) })
+
+	// The second replica counts its own occurrences from a second later.
+	time.Sleep(time.Second)
+	second := newReplica()
+	eg.Go(func() error { return second.Run(ctx) })
+	s.Require().NoError(eg.Wait())
+
+	// Assert.
+	s.Equal(2, job.ExecutedTimes()) // Each occurrence is executed once despite two replicas.
+	s.Equal([]string{jobPayload, jobPayload}, payloads)
+	s.Equal(0, s.Store.FailedJob.Query().CountX(s.Ctx))
+
+	var runs []int64
+	for _, cj := range s.Store.CompletedJob.Query().AllX(s.Ctx) {
+		var run int64
+		_, err := fmt.Sscanf(cj.DedupKey, "schedule:"+jobName+":%d", &run)
+		s.Require().NoError(err)
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i] < runs[j] })
+	s.Require().Len(runs, 2)
+	s.Equal(int64(2), runs[1]-runs[0], "occurrences are shared by the replicas")
+}
+
 func (s *OutboxServiceSuite) TestAllJobsProcessed() {
 	// Arrange.
 	const jobName = "TestAllJobsProcessed"
@@ -195,6 +319,89 @@ func (s *OutboxServiceSuite) TestDLQ_AfterMaxAttemptsExceeding() {
 	s.Equal(maxAttempts, job.ExecutedTimes())
 }
 
+func (s *OutboxServiceSuite) TestStats() {
+	// Arrange.
+	const jobName = "TestStats"
+	const pendingJobName = "TestStats_pending"
+	const unknownJobName = "TestStats_unknown"
+
+	var executedTimes int
+	job := newJobMock(jobName, func(ctx context.Context, _ string) error {
+		executedTimes++
+		if executedTimes == 1 {
+			return errors.New("unknown")
+		}
+		return nil
+	}, time.Second, 3)
+	s.outboxSvc.MustRegisterJob(job)
+
+	_, err := s.outboxSvc.Put(s.Ctx, jobName, "{}", time.Now())
+	s.Require().NoError(err)
+	_, err = s.outboxSvc.Put(s.Ctx, unknownJobName, "{}", time.Now())
+	s.Require().NoError(err)
+	for i := 0; i < 2; i++ {
+		_, err = s.outboxSvc.Put(s.Ctx, pendingJobName, "{}", time.Now().Add(time.Hour))
+		s.Require().NoError(err)
+	}
+
+	// Action.
+	s.runOutboxFor(3 * reserveFor)
+	stats, err := s.outboxSvc.Stats(s.Ctx)
+	s.Require().NoError(err)
+
+	// Assert.
+	byName := make(map[string]outbox.JobStats, len(stats))
+	for _, st := range stats {
+		byName[st.Name] = st
+	}
+
+	st := byName[jobName]
+	s.Equal(0, st.Pending)
+	s.Equal(uint64(2), st.Executions)
+	s.Equal(uint64(1), st.Failures)
+	s.Equal(uint64(0), st.DLQMoves)
+	s.Equal(uint64(2), st.Latency.Count)
+	s.Len(st.Latency.Buckets, len(outbox.LatencyBuckets))
+	s.Equal(uint64(2), st.Latency.Buckets[len(st.Latency.Buckets)-1])
+
+	s.Equal(uint64(1), byName[unknownJobName].DLQMoves)
+
+	st = byName[pendingJobName]
+	s.Equal(2, st.Pending)
+	s.Positive(st.OldestPendingAge)
+	s.Equal(uint64(0), st.Executions)
+}
+
+func (s *OutboxServiceSuite) TestReplayFailedJob() {
+	// Arrange.
+	const jobName = "TestReplayFailedJob"
+	const jobPayload = `{"id":1}`
+	s.Require().NoError(s.jobsRepo.CreateFailedJob(s.Ctx, jobName, jobPayload, "too_many_errors"))
+
+	failedJobs, err := s.outboxSvc.FailedJobs(s.Ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(failedJobs, 1)
+	failed, err := s.outboxSvc.FailedJob(s.Ctx, failedJobs[0].ID)
+	s.Require().NoError(err)
+	s.Equal(failedJobs[0], failed)
+	s.Equal(jobPayload, failed.Payload)
+
+	// Action.
+	jobID, err := s.outboxSvc.ReplayFailedJob(s.Ctx, failed.ID)
+	s.Require().NoError(err)
+
+	// Assert.
+	s.Equal(0, s.Store.FailedJob.Query().CountX(s.Ctx))
+	j, err := s.Store.Job.Get(s.Ctx, jobID)
+	s.Require().NoError(err)
+	s.Equal(jobName, j.Name)
+	s.Equal(jobPayload, j.Payload)
+	s.Equal(0, j.Attempts)
+
+	_, err = s.outboxSvc.ReplayFailedJob(s.Ctx, failed.ID)
+	s.ErrorIs(err, outbox.ErrFailedJobNotFound)
+}
+
 func (s *OutboxServiceSuite) TestIfNoJobsThenWorkersSleepForIdleTime() {
 	// Arrange.
 	const jobName = "TestIfNoJobsThenWorkersSleepForIdleTime"
@@ -228,6 +435,173 @@ func (s *OutboxServiceSuite) TestIfNoJobsThenWorkersSleepForIdleTime() {
 	s.NoError(<-errCh)
 }
 
+func (s *OutboxServiceSuite) TestGracefulDrain_RunningJobFinished() {
+	// Arrange.
+	const jobName = "TestGracefulDrain_RunningJobFinished"
+
+	job := newJobMock(jobName, func(ctx context.Context, _ string) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(300 * time.Millisecond):
+			return nil
+		}
+	}, time.Second, 1)
+	s.outboxSvc.MustRegisterJob(job)
+
+	_, err := s.outboxSvc.Put(s.Ctx, jobName, "{}", time.Now())
+	s.Require().NoError(err)
+
+	// Action.
+	cancel, errCh := s.runOutbox()
+	defer cancel()
+
+	s.Require().Eventually(func() bool { return job.ExecutedTimes() == 1 }, time.Second, 10*time.Millisecond)
+	cancel()
+	s.NoError(<-errCh)
+
+	// Assert.
+	s.Equal(1, job.ExecutedTimes())
+	s.Equal(0, s.Store.Job.Query().CountX(s.Ctx))
+	s.Equal(0, s.Store.FailedJob.Query().CountX(s.Ctx))
+}
+
+func (s *OutboxServiceSuite) TestGracefulDrain_UnfinishedJobReleased() {
+	// Arrange.
+	const jobName = "TestGracefulDrain_UnfinishedJobReleased"
+	const drainTimeout = 100 * time.Millisecond
+
+	outboxSvc, err := outbox.New(outbox.NewOptions(
+		workers,
+		idleTime,
+		reserveFor,
+		s.jobsRepo,
+		s.Database,
+		outbox.WithDrainTimeout(drainTimeout),
+	))
+	s.Require().NoError(err)
+
+	job := newJobMock(jobName, func(ctx context.Context, _ string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, time.Minute, 1)
+	outboxSvc.MustRegisterJob(job)
+
+	jobID, err := outboxSvc.Put(s.Ctx, jobName, "{}", time.Now())
+	s.Require().NoError(err)
+
+	// Action.
+	ctx, cancel := context.WithCancel(s.Ctx)
+	defer cancel()
+	errCh := make(chan error)
+	go func() { errCh <- outboxSvc.Run(ctx) }()
+
+	s.Require().Eventually(func() bool { return job.ExecutedTimes() == 1 }, time.Second, 10*time.Millisecond)
+	stoppedAt := time.Now()
+	cancel()
+	s.NoError(<-errCh)
+
+	// Assert.
+	s.GreaterOrEqual(time.Since(stoppedAt), drainTimeout)
+	s.Equal(1, job.ExecutedTimes())
+	s.Equal(0, s.Store.FailedJob.Query().CountX(s.Ctx))
+
+	j, err := s.Store.Job.Get(s.Ctx, jobID)
+	s.Require().NoError(err)
+	s.Equal(0, j.Attempts)
+	s.False(j.ReservedUntil.After(time.Now()), "reservation must be released")
+}
+
+func (s *OutboxServiceSuite) TestMaxConcurrency() {
+	// Arrange.
+	const jobName = "TestMaxConcurrency"
+	const maxConcurrency = 2
+	const jobsCount = 10
+
+	var running, maxRunning int32
+	job := newJobMock(jobName, func(ctx context.Context, _ string) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}, time.Second, 1)
+	job.maxConcurrency = maxConcurrency
+	s.outboxSvc.MustRegisterJob(job)
+
+	for i := 0; i < jobsCount; i++ {
+		_, err := s.outboxSvc.Put(s.Ctx, jobName, strconv.Itoa(i), time.Now())
+		s.Require().NoError(err)
+	}
+
+	// Action.
+	s.runOutboxFor(2 * time.Second)
+
+	// Assert.
+	s.Equal(jobsCount, job.ExecutedTimes())
+	s.Equal(int32(maxConcurrency), atomic.LoadInt32(&maxRunning)) // Despite the workers number.
+	s.Equal(0, s.Store.Job.Query().CountX(s.Ctx))
+}
+
+func (s *OutboxServiceSuite) TestFairness() {
+	// Arrange.
+	const (
+		noisyJobName   = "TestFairness_noisy"
+		quietJobName   = "TestFairness_quiet"
+		noisyJobs      = 50
+		quietJobs      = 10
+		quietJobWeight = 2
+	)
+
+	outboxSvc, err := outbox.New(outbox.NewOptions(1, idleTime, reserveFor, s.jobsRepo, s.Database))
+	s.Require().NoError(err)
+
+	noisyJob := newJobMock(noisyJobName, nop, time.Second, 1)
+	outboxSvc.MustRegisterJob(noisyJob)
+
+	var noisyExecutedWhenQuietDone int32
+	quietJob := newJobMock(quietJobName, nil, time.Second, 1)
+	quietJob.handler = func(ctx context.Context, _ string) error {
+		if quietJob.ExecutedTimes() == quietJobs {
+			atomic.StoreInt32(&noisyExecutedWhenQuietDone, int32(noisyJob.ExecutedTimes()))
+		}
+		return nop(ctx, "")
+	}
+	quietJob.weight = quietJobWeight
+	outboxSvc.MustRegisterJob(quietJob)
+
+	// The noisy jobs are older, so without balancing they would be executed first.
+	for i := 0; i < noisyJobs; i++ {
+		_, err := outboxSvc.Put(s.Ctx, noisyJobName, strconv.Itoa(i), time.Now())
+		s.Require().NoError(err)
+	}
+	for i := 0; i < quietJobs; i++ {
+		_, err := outboxSvc.Put(s.Ctx, quietJobName, strconv.Itoa(i), time.Now())
+		s.Require().NoError(err)
+	}
+
+	// Action.
+	ctx, cancel := context.WithCancel(s.Ctx)
+	defer cancel()
+	errCh := make(chan error)
+	go func() { errCh <- outboxSvc.Run(ctx) }()
+
+	s.Require().Eventually(func() bool {
+		return quietJob.ExecutedTimes() == quietJobs
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	s.NoError(<-errCh)
+
+	// Assert.
+	// Quiet jobs get two reservation attempts of every three.
+	s.LessOrEqual(atomic.LoadInt32(&noisyExecutedWhenQuietDone), int32(quietJobs/quietJobWeight+1))
+}
+
 func (s *OutboxServiceSuite) runOutboxFor(timeout time.Duration) {
 	s.T().Helper()
 
@@ -256,11 +630,13 @@ var nop = func(ctx context.Context, s string) error {
 }
 
 type jobMock struct {
-	name          string
-	handler       func(ctx context.Context, s string) error
-	timeout       time.Duration
-	maxAttempts   int
-	executedTimes int32
+	name           string
+	handler        func(ctx context.Context, s string) error
+	timeout        time.Duration
+	maxAttempts    int
+	maxConcurrency int
+	weight         int
+	executedTimes  int32
 }
 
 func newJobMock(
@@ -274,6 +650,7 @@ func newJobMock(
 		handler:       h,
 		timeout:       executionTimeout,
 		maxAttempts:   maxAttempts,
+		weight:        1,
 		executedTimes: 0,
 	}
 }
@@ -295,6 +672,14 @@ func (j *jobMock) MaxAttempts() int {
 	return j.maxAttempts
 }
 
+func (j *jobMock) MaxConcurrency() int {
+	return j.maxConcurrency
+}
+
+func (j *jobMock) Weight() int {
+	return j.weight
+}
+
 // ExecutedTimes returns global (for all different jobs of this type
 // processed at different times) execution counter.
 func (j *jobMock) ExecutedTimes() int {
@@ -16,8 +16,10 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/gerladeno/chat-service/internal/store/chat"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
 	"github.com/gerladeno/chat-service/internal/store/failedjob"
 	"github.com/gerladeno/chat-service/internal/store/job"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
 	"github.com/gerladeno/chat-service/internal/store/message"
 	"github.com/gerladeno/chat-service/internal/store/problem"
 )
@@ -29,10 +31,14 @@ type Client struct {
 	Schema *migrate.Schema
 	// Chat is the client for interacting with the Chat builders.
 	Chat *ChatClient
+	// CompletedJob is the client for interacting with the CompletedJob builders.
+	CompletedJob *CompletedJobClient
 	// FailedJob is the client for interacting with the FailedJob builders.
 	FailedJob *FailedJobClient
 	// Job is the client for interacting with the Job builders.
 	Job *JobClient
+	// JobSchedule is the client for interacting with the JobSchedule builders.
+	JobSchedule *JobScheduleClient
 	// Message is the client for interacting with the Message builders.
 	Message *MessageClient
 	// Problem is the client for interacting with the Problem builders.
@@ -51,8 +57,10 @@ func NewClient(opts ...Option) *Client {
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.Chat = NewChatClient(c.config)
+	c.CompletedJob = NewCompletedJobClient(c.config)
 	c.FailedJob = NewFailedJobClient(c.config)
 	c.Job = NewJobClient(c.config)
+	c.JobSchedule = NewJobScheduleClient(c.config)
 	c.Message = NewMessageClient(c.config)
 	c.Problem = NewProblemClient(c.config)
 }
@@ -135,13 +143,15 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:       ctx,
-		config:    cfg,
-		Chat:      NewChatClient(cfg),
-		FailedJob: NewFailedJobClient(cfg),
-		Job:       NewJobClient(cfg),
-		Message:   NewMessageClient(cfg),
-		Problem:   NewProblemClient(cfg),
+		ctx:          ctx,
+		config:       cfg,
+		Chat:         NewChatClient(cfg),
+		CompletedJob: NewCompletedJobClient(cfg),
+		FailedJob:    NewFailedJobClient(cfg),
+		Job:          NewJobClient(cfg),
+		JobSchedule:  NewJobScheduleClient(cfg),
+		Message:      NewMessageClient(cfg),
+		Problem:      NewProblemClient(cfg),
 	}, nil
 }
 
@@ -159,13 +169,15 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:       ctx,
-		config:    cfg,
-		Chat:      NewChatClient(cfg),
-		FailedJob: NewFailedJobClient(cfg),
-		Job:       NewJobClient(cfg),
-		Message:   NewMessageClient(cfg),
-		Problem:   NewProblemClient(cfg),
+		ctx:          ctx,
+		config:       cfg,
+		Chat:         NewChatClient(cfg),
+		CompletedJob: NewCompletedJobClient(cfg),
+		FailedJob:    NewFailedJobClient(cfg),
+		Job:          NewJobClient(cfg),
+		JobSchedule:  NewJobScheduleClient(cfg),
+		Message:      NewMessageClient(cfg),
+		Problem:      NewProblemClient(cfg),
 	}, nil
 }
 
@@ -194,21 +206,21 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
-	c.Chat.Use(hooks...)
-	c.FailedJob.Use(hooks...)
-	c.Job.Use(hooks...)
-	c.Message.Use(hooks...)
-	c.Problem.Use(hooks...)
+	for _, n := range []interface{ Use(...Hook) }{
+		c.Chat, c.CompletedJob, c.FailedJob, c.Job, c.JobSchedule, c.Message, c.Problem,
+	} {
+		n.Use(hooks...)
+	}
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
-	c.Chat.Intercept(interceptors...)
-	c.FailedJob.Intercept(interceptors...)
-	c.Job.Intercept(interceptors...)
-	c.Message.Intercept(interceptors...)
-	c.Problem.Intercept(interceptors...)
+	for _, n := range []interface{ Intercept(...Interceptor) }{
+		c.Chat, c.CompletedJob, c.FailedJob, c.Job, c.JobSchedule, c.Message, c.Problem,
+	} {
+		n.Intercept(interceptors...)
+	}
 }
 
 // Mutate implements the ent.Mutator interface.
@@ -216,10 +228,14 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
 	case *ChatMutation:
 		return c.Chat.mutate(ctx, m)
+	case *CompletedJobMutation:
+		return c.CompletedJob.mutate(ctx, m)
 	case *FailedJobMutation:
 		return c.FailedJob.mutate(ctx, m)
 	case *JobMutation:
 		return c.Job.mutate(ctx, m)
+	case *JobScheduleMutation:
+		return c.JobSchedule.mutate(ctx, m)
 	case *MessageMutation:
 		return c.Message.mutate(ctx, m)
 	case *ProblemMutation:
@@ -379,6 +395,124 @@ func (c *ChatClient) mutate(ctx context.Context, m *ChatMutation) (Value, error)
 	}
 }
 
+// CompletedJobClient is a client for the CompletedJob schema.
+type CompletedJobClient struct {
+	config
+}
+
+// NewCompletedJobClient returns a client for the CompletedJob from the given config.
+func NewCompletedJobClient(c config) *CompletedJobClient {
+	return &CompletedJobClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `completedjob.Hooks(f(g(h())))`.
+func (c *CompletedJobClient) Use(hooks ...Hook) {
+	c.hooks.CompletedJob = append(c.hooks.CompletedJob, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `completedjob.Intercept(f(g(h())))`.
+func (c *CompletedJobClient) Intercept(interceptors ...Interceptor) {
+	c.inters.CompletedJob = append(c.inters.CompletedJob, interceptors...)
+}
+
+// Create returns a builder for creating a CompletedJob entity.
+func (c *CompletedJobClient) Create() *CompletedJobCreate {
+	mutation := newCompletedJobMutation(c.config, OpCreate)
+	return &CompletedJobCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of CompletedJob entities.
+func (c *CompletedJobClient) CreateBulk(builders ...*CompletedJobCreate) *CompletedJobCreateBulk {
+	return &CompletedJobCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for CompletedJob.
+func (c *CompletedJobClient) Update() *CompletedJobUpdate {
+	mutation := newCompletedJobMutation(c.config, OpUpdate)
+	return &CompletedJobUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *CompletedJobClient) UpdateOne(cj *CompletedJob) *CompletedJobUpdateOne {
+	mutation := newCompletedJobMutation(c.config, OpUpdateOne, withCompletedJob(cj))
+	return &CompletedJobUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *CompletedJobClient) UpdateOneID(id types.CompletedJobID) *CompletedJobUpdateOne {
+	mutation := newCompletedJobMutation(c.config, OpUpdateOne, withCompletedJobID(id))
+	return &CompletedJobUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for CompletedJob.
+func (c *CompletedJobClient) Delete() *CompletedJobDelete {
+	mutation := newCompletedJobMutation(c.config, OpDelete)
+	return &CompletedJobDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *CompletedJobClient) DeleteOne(cj *CompletedJob) *CompletedJobDeleteOne {
+	return c.DeleteOneID(cj.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *CompletedJobClient) DeleteOneID(id types.CompletedJobID) *CompletedJobDeleteOne {
+	builder := c.Delete().Where(completedjob.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &CompletedJobDeleteOne{builder}
+}
+
+// Query returns a query builder for CompletedJob.
+func (c *CompletedJobClient) Query() *CompletedJobQuery {
+	return &CompletedJobQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeCompletedJob},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a CompletedJob entity by its id.
+func (c *CompletedJobClient) Get(ctx context.Context, id types.CompletedJobID) (*CompletedJob, error) {
+	return c.Query().Where(completedjob.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *CompletedJobClient) GetX(ctx context.Context, id types.CompletedJobID) *CompletedJob {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *CompletedJobClient) Hooks() []Hook {
+	return c.hooks.CompletedJob
+}
+
+// Interceptors returns the client interceptors.
+func (c *CompletedJobClient) Interceptors() []Interceptor {
+	return c.inters.CompletedJob
+}
+
+func (c *CompletedJobClient) mutate(ctx context.Context, m *CompletedJobMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&CompletedJobCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&CompletedJobUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&CompletedJobUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&CompletedJobDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("store: unknown CompletedJob mutation op: %q", m.Op())
+	}
+}
+
 // FailedJobClient is a client for the FailedJob schema.
 type FailedJobClient struct {
 	config
@@ -615,6 +749,124 @@ func (c *JobClient) mutate(ctx context.Context, m *JobMutation) (Value, error) {
 	}
 }
 
+// JobScheduleClient is a client for the JobSchedule schema.
+type JobScheduleClient struct {
+	config
+}
+
+// NewJobScheduleClient returns a client for the JobSchedule from the given config.
+func NewJobScheduleClient(c config) *JobScheduleClient {
+	return &JobScheduleClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `jobschedule.Hooks(f(g(h())))`.
+func (c *JobScheduleClient) Use(hooks ...Hook) {
+	c.hooks.JobSchedule = append(c.hooks.JobSchedule, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `jobschedule.Intercept(f(g(h())))`.
+func (c *JobScheduleClient) Intercept(interceptors ...Interceptor) {
+	c.inters.JobSchedule = append(c.inters.JobSchedule, interceptors...)
+}
+
+// Create returns a builder for creating a JobSchedule entity.
+func (c *JobScheduleClient) Create() *JobScheduleCreate {
+	mutation := newJobScheduleMutation(c.config, OpCreate)
+	return &JobScheduleCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of JobSchedule entities.
+func (c *JobScheduleClient) CreateBulk(builders ...*JobScheduleCreate) *JobScheduleCreateBulk {
+	return &JobScheduleCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for JobSchedule.
+func (c *JobScheduleClient) Update() *JobScheduleUpdate {
+	mutation := newJobScheduleMutation(c.config, OpUpdate)
+	return &JobScheduleUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *JobScheduleClient) UpdateOne(js *JobSchedule) *JobScheduleUpdateOne {
+	mutation := newJobScheduleMutation(c.config, OpUpdateOne, withJobSchedule(js))
+	return &JobScheduleUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *JobScheduleClient) UpdateOneID(id types.JobScheduleID) *JobScheduleUpdateOne {
+	mutation := newJobScheduleMutation(c.config, OpUpdateOne, withJobScheduleID(id))
+	return &JobScheduleUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for JobSchedule.
+func (c *JobScheduleClient) Delete() *JobScheduleDelete {
+	mutation := newJobScheduleMutation(c.config, OpDelete)
+	return &JobScheduleDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *JobScheduleClient) DeleteOne(js *JobSchedule) *JobScheduleDeleteOne {
+	return c.DeleteOneID(js.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *JobScheduleClient) DeleteOneID(id types.JobScheduleID) *JobScheduleDeleteOne {
+	builder := c.Delete().Where(jobschedule.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &JobScheduleDeleteOne{builder}
+}
+
+// Query returns a query builder for JobSchedule.
+func (c *JobScheduleClient) Query() *JobScheduleQuery {
+	return &JobScheduleQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeJobSchedule},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a JobSchedule entity by its id.
+func (c *JobScheduleClient) Get(ctx context.Context, id types.JobScheduleID) (*JobSchedule, error) {
+	return c.Query().Where(jobschedule.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *JobScheduleClient) GetX(ctx context.Context, id types.JobScheduleID) *JobSchedule {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *JobScheduleClient) Hooks() []Hook {
+	return c.hooks.JobSchedule
+}
+
+// Interceptors returns the client interceptors.
+func (c *JobScheduleClient) Interceptors() []Interceptor {
+	return c.inters.JobSchedule
+}
+
+func (c *JobScheduleClient) mutate(ctx context.Context, m *JobScheduleMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&JobScheduleCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&JobScheduleUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&JobScheduleUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&JobScheduleDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("store: unknown JobSchedule mutation op: %q", m.Op())
+	}
+}
+
 // MessageClient is a client for the Message schema.
 type MessageClient struct {
 	config
@@ -918,9 +1170,10 @@ func (c *ProblemClient) mutate(ctx context.Context, m *ProblemMutation) (Value,
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		Chat, FailedJob, Job, Message, Problem []ent.Hook
+		Chat, CompletedJob, FailedJob, Job, JobSchedule, Message, Problem []ent.Hook
 	}
 	inters struct {
-		Chat, FailedJob, Job, Message, Problem []ent.Interceptor
+		Chat, CompletedJob, FailedJob, Job, JobSchedule, Message,
+		Problem []ent.Interceptor
 	}
 )
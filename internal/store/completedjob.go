@@ -0,0 +1,140 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// CompletedJob is the model entity for the CompletedJob schema.
+type CompletedJob struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID types.CompletedJobID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// DedupKey holds the value of the "dedup_key" field.
+	DedupKey string `json:"dedup_key,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*CompletedJob) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case completedjob.FieldName, completedjob.FieldDedupKey:
+			values[i] = new(sql.NullString)
+		case completedjob.FieldExpiresAt, completedjob.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case completedjob.FieldID:
+			values[i] = new(types.CompletedJobID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the CompletedJob fields.
+func (cj *CompletedJob) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case completedjob.FieldID:
+			if value, ok := values[i].(*types.CompletedJobID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				cj.ID = *value
+			}
+		case completedjob.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				cj.Name = value.String
+			}
+		case completedjob.FieldDedupKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field dedup_key", values[i])
+			} else if value.Valid {
+				cj.DedupKey = value.String
+			}
+		case completedjob.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				cj.ExpiresAt = value.Time
+			}
+		case completedjob.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				cj.CreatedAt = value.Time
+			}
+		default:
+			cj.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the CompletedJob.
+// This includes values selected through modifiers, order, etc.
+func (cj *CompletedJob) Value(name string) (ent.Value, error) {
+	return cj.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this CompletedJob.
+// Note that you need to call CompletedJob.Unwrap() before calling this method if this CompletedJob
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (cj *CompletedJob) Update() *CompletedJobUpdateOne {
+	return NewCompletedJobClient(cj.config).UpdateOne(cj)
+}
+
+// Unwrap unwraps the CompletedJob entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (cj *CompletedJob) Unwrap() *CompletedJob {
+	_tx, ok := cj.config.driver.(*txDriver)
+	if !ok {
+		panic("store: CompletedJob is not a transactional entity")
+	}
+	cj.config.driver = _tx.drv
+	return cj
+}
+
+// String implements the fmt.Stringer.
+func (cj *CompletedJob) String() string {
+	var builder strings.Builder
+	builder.WriteString("CompletedJob(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", cj.ID))
+	builder.WriteString("name=")
+	builder.WriteString(cj.Name)
+	builder.WriteString(", ")
+	builder.WriteString("dedup_key=")
+	builder.WriteString(cj.DedupKey)
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(cj.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(cj.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// CompletedJobs is a parsable slice of CompletedJob.
+type CompletedJobs []*CompletedJob
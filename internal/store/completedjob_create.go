@@ -0,0 +1,576 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// CompletedJobCreate is the builder for creating a CompletedJob entity.
+type CompletedJobCreate struct {
+	config
+	mutation *CompletedJobMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetName sets the "name" field.
+func (cjc *CompletedJobCreate) SetName(s string) *CompletedJobCreate {
+	cjc.mutation.SetName(s)
+	return cjc
+}
+
+// SetDedupKey sets the "dedup_key" field.
+func (cjc *CompletedJobCreate) SetDedupKey(s string) *CompletedJobCreate {
+	cjc.mutation.SetDedupKey(s)
+	return cjc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (cjc *CompletedJobCreate) SetExpiresAt(t time.Time) *CompletedJobCreate {
+	cjc.mutation.SetExpiresAt(t)
+	return cjc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cjc *CompletedJobCreate) SetCreatedAt(t time.Time) *CompletedJobCreate {
+	cjc.mutation.SetCreatedAt(t)
+	return cjc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cjc *CompletedJobCreate) SetNillableCreatedAt(t *time.Time) *CompletedJobCreate {
+	if t != nil {
+		cjc.SetCreatedAt(*t)
+	}
+	return cjc
+}
+
+// SetID sets the "id" field.
+func (cjc *CompletedJobCreate) SetID(tji types.CompletedJobID) *CompletedJobCreate {
+	cjc.mutation.SetID(tji)
+	return cjc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (cjc *CompletedJobCreate) SetNillableID(tji *types.CompletedJobID) *CompletedJobCreate {
+	if tji != nil {
+		cjc.SetID(*tji)
+	}
+	return cjc
+}
+
+// Mutation returns the CompletedJobMutation object of the builder.
+func (cjc *CompletedJobCreate) Mutation() *CompletedJobMutation {
+	return cjc.mutation
+}
+
+// Save creates the CompletedJob in the database.
+func (cjc *CompletedJobCreate) Save(ctx context.Context) (*CompletedJob, error) {
+	cjc.defaults()
+	return withHooks[*CompletedJob, CompletedJobMutation](ctx, cjc.sqlSave, cjc.mutation, cjc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cjc *CompletedJobCreate) SaveX(ctx context.Context) *CompletedJob {
+	v, err := cjc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cjc *CompletedJobCreate) Exec(ctx context.Context) error {
+	_, err := cjc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cjc *CompletedJobCreate) ExecX(ctx context.Context) {
+	if err := cjc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cjc *CompletedJobCreate) defaults() {
+	if _, ok := cjc.mutation.CreatedAt(); !ok {
+		v := completedjob.DefaultCreatedAt()
+		cjc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := cjc.mutation.ID(); !ok {
+		v := completedjob.DefaultID()
+		cjc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cjc *CompletedJobCreate) check() error {
+	if _, ok := cjc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`store: missing required field "CompletedJob.name"`)}
+	}
+	if _, ok := cjc.mutation.DedupKey(); !ok {
+		return &ValidationError{Name: "dedup_key", err: errors.New(`store: missing required field "CompletedJob.dedup_key"`)}
+	}
+	if _, ok := cjc.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`store: missing required field "CompletedJob.expires_at"`)}
+	}
+	if _, ok := cjc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`store: missing required field "CompletedJob.created_at"`)}
+	}
+	if v, ok := cjc.mutation.ID(); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`store: validator failed for field "CompletedJob.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cjc *CompletedJobCreate) sqlSave(ctx context.Context) (*CompletedJob, error) {
+	if err := cjc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cjc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cjc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*types.CompletedJobID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	cjc.mutation.id = &_node.ID
+	cjc.mutation.done = true
+	return _node, nil
+}
+
+func (cjc *CompletedJobCreate) createSpec() (*CompletedJob, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CompletedJob{config: cjc.config}
+		_spec = sqlgraph.NewCreateSpec(completedjob.Table, sqlgraph.NewFieldSpec(completedjob.FieldID, field.TypeUUID))
+	)
+	_spec.OnConflict = cjc.conflict
+	if id, ok := cjc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := cjc.mutation.Name(); ok {
+		_spec.SetField(completedjob.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := cjc.mutation.DedupKey(); ok {
+		_spec.SetField(completedjob.FieldDedupKey, field.TypeString, value)
+		_node.DedupKey = value
+	}
+	if value, ok := cjc.mutation.ExpiresAt(); ok {
+		_spec.SetField(completedjob.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := cjc.mutation.CreatedAt(); ok {
+		_spec.SetField(completedjob.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.CompletedJob.Create().
+//		SetName(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.CompletedJobUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (cjc *CompletedJobCreate) OnConflict(opts ...sql.ConflictOption) *CompletedJobUpsertOne {
+	cjc.conflict = opts
+	return &CompletedJobUpsertOne{
+		create: cjc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.CompletedJob.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (cjc *CompletedJobCreate) OnConflictColumns(columns ...string) *CompletedJobUpsertOne {
+	cjc.conflict = append(cjc.conflict, sql.ConflictColumns(columns...))
+	return &CompletedJobUpsertOne{
+		create: cjc,
+	}
+}
+
+type (
+	// CompletedJobUpsertOne is the builder for "upsert"-ing
+	//  one CompletedJob node.
+	CompletedJobUpsertOne struct {
+		create *CompletedJobCreate
+	}
+
+	// CompletedJobUpsert is the "OnConflict" setter.
+	CompletedJobUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *CompletedJobUpsert) SetExpiresAt(v time.Time) *CompletedJobUpsert {
+	u.Set(completedjob.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *CompletedJobUpsert) UpdateExpiresAt() *CompletedJobUpsert {
+	u.SetExcluded(completedjob.FieldExpiresAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.CompletedJob.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(completedjob.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *CompletedJobUpsertOne) UpdateNewValues() *CompletedJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(completedjob.FieldID)
+		}
+		if _, exists := u.create.mutation.Name(); exists {
+			s.SetIgnore(completedjob.FieldName)
+		}
+		if _, exists := u.create.mutation.DedupKey(); exists {
+			s.SetIgnore(completedjob.FieldDedupKey)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(completedjob.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.CompletedJob.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *CompletedJobUpsertOne) Ignore() *CompletedJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *CompletedJobUpsertOne) DoNothing() *CompletedJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the CompletedJobCreate.OnConflict
+// documentation for more info.
+func (u *CompletedJobUpsertOne) Update(set func(*CompletedJobUpsert)) *CompletedJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&CompletedJobUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *CompletedJobUpsertOne) SetExpiresAt(v time.Time) *CompletedJobUpsertOne {
+	return u.Update(func(s *CompletedJobUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *CompletedJobUpsertOne) UpdateExpiresAt() *CompletedJobUpsertOne {
+	return u.Update(func(s *CompletedJobUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// Exec executes the query.
+func (u *CompletedJobUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("store: missing options for CompletedJobCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *CompletedJobUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *CompletedJobUpsertOne) ID(ctx context.Context) (id types.CompletedJobID, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("store: CompletedJobUpsertOne.ID is not supported by MySQL driver. Use CompletedJobUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *CompletedJobUpsertOne) IDX(ctx context.Context) types.CompletedJobID {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// CompletedJobCreateBulk is the builder for creating many CompletedJob entities in bulk.
+type CompletedJobCreateBulk struct {
+	config
+	builders []*CompletedJobCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the CompletedJob entities in the database.
+func (cjcb *CompletedJobCreateBulk) Save(ctx context.Context) ([]*CompletedJob, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(cjcb.builders))
+	nodes := make([]*CompletedJob, len(cjcb.builders))
+	mutators := make([]Mutator, len(cjcb.builders))
+	for i := range cjcb.builders {
+		func(i int, root context.Context) {
+			builder := cjcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CompletedJobMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, cjcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = cjcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, cjcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, cjcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cjcb *CompletedJobCreateBulk) SaveX(ctx context.Context) []*CompletedJob {
+	v, err := cjcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cjcb *CompletedJobCreateBulk) Exec(ctx context.Context) error {
+	_, err := cjcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cjcb *CompletedJobCreateBulk) ExecX(ctx context.Context) {
+	if err := cjcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.CompletedJob.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.CompletedJobUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (cjcb *CompletedJobCreateBulk) OnConflict(opts ...sql.ConflictOption) *CompletedJobUpsertBulk {
+	cjcb.conflict = opts
+	return &CompletedJobUpsertBulk{
+		create: cjcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.CompletedJob.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (cjcb *CompletedJobCreateBulk) OnConflictColumns(columns ...string) *CompletedJobUpsertBulk {
+	cjcb.conflict = append(cjcb.conflict, sql.ConflictColumns(columns...))
+	return &CompletedJobUpsertBulk{
+		create: cjcb,
+	}
+}
+
+// CompletedJobUpsertBulk is the builder for "upsert"-ing
+// a bulk of CompletedJob nodes.
+type CompletedJobUpsertBulk struct {
+	create *CompletedJobCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.CompletedJob.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(completedjob.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *CompletedJobUpsertBulk) UpdateNewValues() *CompletedJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(completedjob.FieldID)
+			}
+			if _, exists := b.mutation.Name(); exists {
+				s.SetIgnore(completedjob.FieldName)
+			}
+			if _, exists := b.mutation.DedupKey(); exists {
+				s.SetIgnore(completedjob.FieldDedupKey)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(completedjob.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.CompletedJob.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *CompletedJobUpsertBulk) Ignore() *CompletedJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *CompletedJobUpsertBulk) DoNothing() *CompletedJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the CompletedJobCreateBulk.OnConflict
+// documentation for more info.
+func (u *CompletedJobUpsertBulk) Update(set func(*CompletedJobUpsert)) *CompletedJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&CompletedJobUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *CompletedJobUpsertBulk) SetExpiresAt(v time.Time) *CompletedJobUpsertBulk {
+	return u.Update(func(s *CompletedJobUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *CompletedJobUpsertBulk) UpdateExpiresAt() *CompletedJobUpsertBulk {
+	return u.Update(func(s *CompletedJobUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// Exec executes the query.
+func (u *CompletedJobUpsertBulk) Exec(ctx context.Context) error {
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("store: OnConflict was set for builder %d. Set it on the CompletedJobCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("store: missing options for CompletedJobCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *CompletedJobUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
+)
+
+// CompletedJobDelete is the builder for deleting a CompletedJob entity.
+type CompletedJobDelete struct {
+	config
+	hooks    []Hook
+	mutation *CompletedJobMutation
+}
+
+// Where appends a list predicates to the CompletedJobDelete builder.
+func (cjd *CompletedJobDelete) Where(ps ...predicate.CompletedJob) *CompletedJobDelete {
+	cjd.mutation.Where(ps...)
+	return cjd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (cjd *CompletedJobDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks[int, CompletedJobMutation](ctx, cjd.sqlExec, cjd.mutation, cjd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cjd *CompletedJobDelete) ExecX(ctx context.Context) int {
+	n, err := cjd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (cjd *CompletedJobDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(completedjob.Table, sqlgraph.NewFieldSpec(completedjob.FieldID, field.TypeUUID))
+	if ps := cjd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, cjd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	cjd.mutation.done = true
+	return affected, err
+}
+
+// CompletedJobDeleteOne is the builder for deleting a single CompletedJob entity.
+type CompletedJobDeleteOne struct {
+	cjd *CompletedJobDelete
+}
+
+// Where appends a list predicates to the CompletedJobDelete builder.
+func (cjdo *CompletedJobDeleteOne) Where(ps ...predicate.CompletedJob) *CompletedJobDeleteOne {
+	cjdo.cjd.mutation.Where(ps...)
+	return cjdo
+}
+
+// Exec executes the deletion query.
+func (cjdo *CompletedJobDeleteOne) Exec(ctx context.Context) error {
+	n, err := cjdo.cjd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{completedjob.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cjdo *CompletedJobDeleteOne) ExecX(ctx context.Context) {
+	if err := cjdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// CompletedJobQuery is the builder for querying CompletedJob entities.
+type CompletedJobQuery struct {
+	config
+	ctx        *QueryContext
+	order      []completedjob.OrderOption
+	inters     []Interceptor
+	predicates []predicate.CompletedJob
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the CompletedJobQuery builder.
+func (cjq *CompletedJobQuery) Where(ps ...predicate.CompletedJob) *CompletedJobQuery {
+	cjq.predicates = append(cjq.predicates, ps...)
+	return cjq
+}
+
+// Limit the number of records to be returned by this query.
+func (cjq *CompletedJobQuery) Limit(limit int) *CompletedJobQuery {
+	cjq.ctx.Limit = &limit
+	return cjq
+}
+
+// Offset to start from.
+func (cjq *CompletedJobQuery) Offset(offset int) *CompletedJobQuery {
+	cjq.ctx.Offset = &offset
+	return cjq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (cjq *CompletedJobQuery) Unique(unique bool) *CompletedJobQuery {
+	cjq.ctx.Unique = &unique
+	return cjq
+}
+
+// Order specifies how the records should be ordered.
+func (cjq *CompletedJobQuery) Order(o ...completedjob.OrderOption) *CompletedJobQuery {
+	cjq.order = append(cjq.order, o...)
+	return cjq
+}
+
+// First returns the first CompletedJob entity from the query.
+// Returns a *NotFoundError when no CompletedJob was found.
+func (cjq *CompletedJobQuery) First(ctx context.Context) (*CompletedJob, error) {
+	nodes, err := cjq.Limit(1).All(setContextOp(ctx, cjq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{completedjob.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (cjq *CompletedJobQuery) FirstX(ctx context.Context) *CompletedJob {
+	node, err := cjq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first CompletedJob ID from the query.
+// Returns a *NotFoundError when no CompletedJob ID was found.
+func (cjq *CompletedJobQuery) FirstID(ctx context.Context) (id types.CompletedJobID, err error) {
+	var ids []types.CompletedJobID
+	if ids, err = cjq.Limit(1).IDs(setContextOp(ctx, cjq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{completedjob.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (cjq *CompletedJobQuery) FirstIDX(ctx context.Context) types.CompletedJobID {
+	id, err := cjq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single CompletedJob entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one CompletedJob entity is found.
+// Returns a *NotFoundError when no CompletedJob entities are found.
+func (cjq *CompletedJobQuery) Only(ctx context.Context) (*CompletedJob, error) {
+	nodes, err := cjq.Limit(2).All(setContextOp(ctx, cjq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{completedjob.Label}
+	default:
+		return nil, &NotSingularError{completedjob.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (cjq *CompletedJobQuery) OnlyX(ctx context.Context) *CompletedJob {
+	node, err := cjq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only CompletedJob ID in the query.
+// Returns a *NotSingularError when more than one CompletedJob ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (cjq *CompletedJobQuery) OnlyID(ctx context.Context) (id types.CompletedJobID, err error) {
+	var ids []types.CompletedJobID
+	if ids, err = cjq.Limit(2).IDs(setContextOp(ctx, cjq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{completedjob.Label}
+	default:
+		err = &NotSingularError{completedjob.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (cjq *CompletedJobQuery) OnlyIDX(ctx context.Context) types.CompletedJobID {
+	id, err := cjq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of CompletedJobs.
+func (cjq *CompletedJobQuery) All(ctx context.Context) ([]*CompletedJob, error) {
+	ctx = setContextOp(ctx, cjq.ctx, "All")
+	if err := cjq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*CompletedJob, *CompletedJobQuery]()
+	return withInterceptors[[]*CompletedJob](ctx, cjq, qr, cjq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (cjq *CompletedJobQuery) AllX(ctx context.Context) []*CompletedJob {
+	nodes, err := cjq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of CompletedJob IDs.
+func (cjq *CompletedJobQuery) IDs(ctx context.Context) (ids []types.CompletedJobID, err error) {
+	if cjq.ctx.Unique == nil && cjq.path != nil {
+		cjq.Unique(true)
+	}
+	ctx = setContextOp(ctx, cjq.ctx, "IDs")
+	if err = cjq.Select(completedjob.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (cjq *CompletedJobQuery) IDsX(ctx context.Context) []types.CompletedJobID {
+	ids, err := cjq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (cjq *CompletedJobQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, cjq.ctx, "Count")
+	if err := cjq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, cjq, querierCount[*CompletedJobQuery](), cjq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (cjq *CompletedJobQuery) CountX(ctx context.Context) int {
+	count, err := cjq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (cjq *CompletedJobQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, cjq.ctx, "Exist")
+	switch _, err := cjq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("store: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (cjq *CompletedJobQuery) ExistX(ctx context.Context) bool {
+	exist, err := cjq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the CompletedJobQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (cjq *CompletedJobQuery) Clone() *CompletedJobQuery {
+	if cjq == nil {
+		return nil
+	}
+	return &CompletedJobQuery{
+		config:     cjq.config,
+		ctx:        cjq.ctx.Clone(),
+		order:      append([]completedjob.OrderOption{}, cjq.order...),
+		inters:     append([]Interceptor{}, cjq.inters...),
+		predicates: append([]predicate.CompletedJob{}, cjq.predicates...),
+		// clone intermediate query.
+		sql:  cjq.sql.Clone(),
+		path: cjq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.CompletedJob.Query().
+//		GroupBy(completedjob.FieldName).
+//		Aggregate(store.Count()).
+//		Scan(ctx, &v)
+func (cjq *CompletedJobQuery) GroupBy(field string, fields ...string) *CompletedJobGroupBy {
+	cjq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &CompletedJobGroupBy{build: cjq}
+	grbuild.flds = &cjq.ctx.Fields
+	grbuild.label = completedjob.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	client.CompletedJob.Query().
+//		Select(completedjob.FieldName).
+//		Scan(ctx, &v)
+func (cjq *CompletedJobQuery) Select(fields ...string) *CompletedJobSelect {
+	cjq.ctx.Fields = append(cjq.ctx.Fields, fields...)
+	sbuild := &CompletedJobSelect{CompletedJobQuery: cjq}
+	sbuild.label = completedjob.Label
+	sbuild.flds, sbuild.scan = &cjq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a CompletedJobSelect configured with the given aggregations.
+func (cjq *CompletedJobQuery) Aggregate(fns ...AggregateFunc) *CompletedJobSelect {
+	return cjq.Select().Aggregate(fns...)
+}
+
+func (cjq *CompletedJobQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range cjq.inters {
+		if inter == nil {
+			return fmt.Errorf("store: uninitialized interceptor (forgotten import store/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, cjq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range cjq.ctx.Fields {
+		if !completedjob.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("store: invalid field %q for query", f)}
+		}
+	}
+	if cjq.path != nil {
+		prev, err := cjq.path(ctx)
+		if err != nil {
+			return err
+		}
+		cjq.sql = prev
+	}
+	return nil
+}
+
+func (cjq *CompletedJobQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*CompletedJob, error) {
+	var (
+		nodes = []*CompletedJob{}
+		_spec = cjq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*CompletedJob).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &CompletedJob{config: cjq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(cjq.modifiers) > 0 {
+		_spec.Modifiers = cjq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, cjq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (cjq *CompletedJobQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := cjq.querySpec()
+	if len(cjq.modifiers) > 0 {
+		_spec.Modifiers = cjq.modifiers
+	}
+	_spec.Node.Columns = cjq.ctx.Fields
+	if len(cjq.ctx.Fields) > 0 {
+		_spec.Unique = cjq.ctx.Unique != nil && *cjq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, cjq.driver, _spec)
+}
+
+func (cjq *CompletedJobQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(completedjob.Table, completedjob.Columns, sqlgraph.NewFieldSpec(completedjob.FieldID, field.TypeUUID))
+	_spec.From = cjq.sql
+	if unique := cjq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if cjq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := cjq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, completedjob.FieldID)
+		for i := range fields {
+			if fields[i] != completedjob.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := cjq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := cjq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := cjq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := cjq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (cjq *CompletedJobQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(cjq.driver.Dialect())
+	t1 := builder.Table(completedjob.Table)
+	columns := cjq.ctx.Fields
+	if len(columns) == 0 {
+		columns = completedjob.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if cjq.sql != nil {
+		selector = cjq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if cjq.ctx.Unique != nil && *cjq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range cjq.modifiers {
+		m(selector)
+	}
+	for _, p := range cjq.predicates {
+		p(selector)
+	}
+	for _, p := range cjq.order {
+		p(selector)
+	}
+	if offset := cjq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := cjq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (cjq *CompletedJobQuery) ForUpdate(opts ...sql.LockOption) *CompletedJobQuery {
+	if cjq.driver.Dialect() == dialect.Postgres {
+		cjq.Unique(false)
+	}
+	cjq.modifiers = append(cjq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return cjq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (cjq *CompletedJobQuery) ForShare(opts ...sql.LockOption) *CompletedJobQuery {
+	if cjq.driver.Dialect() == dialect.Postgres {
+		cjq.Unique(false)
+	}
+	cjq.modifiers = append(cjq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return cjq
+}
+
+// CompletedJobGroupBy is the group-by builder for CompletedJob entities.
+type CompletedJobGroupBy struct {
+	selector
+	build *CompletedJobQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (cjgb *CompletedJobGroupBy) Aggregate(fns ...AggregateFunc) *CompletedJobGroupBy {
+	cjgb.fns = append(cjgb.fns, fns...)
+	return cjgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cjgb *CompletedJobGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cjgb.build.ctx, "GroupBy")
+	if err := cjgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*CompletedJobQuery, *CompletedJobGroupBy](ctx, cjgb.build, cjgb, cjgb.build.inters, v)
+}
+
+func (cjgb *CompletedJobGroupBy) sqlScan(ctx context.Context, root *CompletedJobQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(cjgb.fns))
+	for _, fn := range cjgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*cjgb.flds)+len(cjgb.fns))
+		for _, f := range *cjgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*cjgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cjgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// CompletedJobSelect is the builder for selecting fields of CompletedJob entities.
+type CompletedJobSelect struct {
+	*CompletedJobQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (cjs *CompletedJobSelect) Aggregate(fns ...AggregateFunc) *CompletedJobSelect {
+	cjs.fns = append(cjs.fns, fns...)
+	return cjs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cjs *CompletedJobSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cjs.ctx, "Select")
+	if err := cjs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*CompletedJobQuery, *CompletedJobSelect](ctx, cjs.CompletedJobQuery, cjs, cjs.inters, v)
+}
+
+func (cjs *CompletedJobSelect) sqlScan(ctx context.Context, root *CompletedJobQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(cjs.fns))
+	for _, fn := range cjs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*cjs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cjs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,194 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
+)
+
+// CompletedJobUpdate is the builder for updating CompletedJob entities.
+type CompletedJobUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CompletedJobMutation
+}
+
+// Where appends a list predicates to the CompletedJobUpdate builder.
+func (cju *CompletedJobUpdate) Where(ps ...predicate.CompletedJob) *CompletedJobUpdate {
+	cju.mutation.Where(ps...)
+	return cju
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (cju *CompletedJobUpdate) SetExpiresAt(t time.Time) *CompletedJobUpdate {
+	cju.mutation.SetExpiresAt(t)
+	return cju
+}
+
+// Mutation returns the CompletedJobMutation object of the builder.
+func (cju *CompletedJobUpdate) Mutation() *CompletedJobMutation {
+	return cju.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cju *CompletedJobUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks[int, CompletedJobMutation](ctx, cju.sqlSave, cju.mutation, cju.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cju *CompletedJobUpdate) SaveX(ctx context.Context) int {
+	affected, err := cju.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cju *CompletedJobUpdate) Exec(ctx context.Context) error {
+	_, err := cju.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cju *CompletedJobUpdate) ExecX(ctx context.Context) {
+	if err := cju.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (cju *CompletedJobUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(completedjob.Table, completedjob.Columns, sqlgraph.NewFieldSpec(completedjob.FieldID, field.TypeUUID))
+	if ps := cju.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cju.mutation.ExpiresAt(); ok {
+		_spec.SetField(completedjob.FieldExpiresAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cju.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{completedjob.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cju.mutation.done = true
+	return n, nil
+}
+
+// CompletedJobUpdateOne is the builder for updating a single CompletedJob entity.
+type CompletedJobUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CompletedJobMutation
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (cjuo *CompletedJobUpdateOne) SetExpiresAt(t time.Time) *CompletedJobUpdateOne {
+	cjuo.mutation.SetExpiresAt(t)
+	return cjuo
+}
+
+// Mutation returns the CompletedJobMutation object of the builder.
+func (cjuo *CompletedJobUpdateOne) Mutation() *CompletedJobMutation {
+	return cjuo.mutation
+}
+
+// Where appends a list predicates to the CompletedJobUpdate builder.
+func (cjuo *CompletedJobUpdateOne) Where(ps ...predicate.CompletedJob) *CompletedJobUpdateOne {
+	cjuo.mutation.Where(ps...)
+	return cjuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cjuo *CompletedJobUpdateOne) Select(field string, fields ...string) *CompletedJobUpdateOne {
+	cjuo.fields = append([]string{field}, fields...)
+	return cjuo
+}
+
+// Save executes the query and returns the updated CompletedJob entity.
+func (cjuo *CompletedJobUpdateOne) Save(ctx context.Context) (*CompletedJob, error) {
+	return withHooks[*CompletedJob, CompletedJobMutation](ctx, cjuo.sqlSave, cjuo.mutation, cjuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cjuo *CompletedJobUpdateOne) SaveX(ctx context.Context) *CompletedJob {
+	node, err := cjuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cjuo *CompletedJobUpdateOne) Exec(ctx context.Context) error {
+	_, err := cjuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cjuo *CompletedJobUpdateOne) ExecX(ctx context.Context) {
+	if err := cjuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (cjuo *CompletedJobUpdateOne) sqlSave(ctx context.Context) (_node *CompletedJob, err error) {
+	_spec := sqlgraph.NewUpdateSpec(completedjob.Table, completedjob.Columns, sqlgraph.NewFieldSpec(completedjob.FieldID, field.TypeUUID))
+	id, ok := cjuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`store: missing "CompletedJob.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cjuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, completedjob.FieldID)
+		for _, f := range fields {
+			if !completedjob.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("store: invalid field %q for query", f)}
+			}
+			if f != completedjob.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cjuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cjuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(completedjob.FieldExpiresAt, field.TypeTime, value)
+	}
+	_node = &CompletedJob{config: cjuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cjuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{completedjob.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cjuo.mutation.done = true
+	return _node, nil
+}
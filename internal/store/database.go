@@ -22,17 +22,18 @@ func NewDatabase(client *Client) *Database {
 
 // RunInTx runs the given function f within a transaction.
 // Inspired by https://entgo.io/docs/transactions/#best-practices.
-// If there is already a transaction in the context, then the method uses it.
+// If there is already a transaction in the context, then the method uses it
+// and leaves committing or rolling it back to the caller that opened it.
 func (db *Database) RunInTx(ctx context.Context, f func(context.Context) error) error {
-	tx := TxFromContext(ctx)
-	if tx == nil {
-		var err error
-		tx, err = db.loadClient(ctx).BeginTx(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("beginning transaction %w", err)
-		}
-		ctx = NewTxContext(ctx, tx)
+	if TxFromContext(ctx) != nil {
+		return f(ctx)
+	}
+
+	tx, err := db.loadClient(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction %w", err)
 	}
+	ctx = NewTxContext(ctx, tx)
 	defer func() {
 		if r := recover(); r != nil {
 			if e := tx.Rollback(); e != nil {
@@ -86,6 +87,11 @@ func (db *Database) Chat(ctx context.Context) *ChatClient {
 	return db.loadClient(ctx).Chat
 }
 
+// CompletedJob is the client for interacting with the CompletedJob builders.
+func (db *Database) CompletedJob(ctx context.Context) *CompletedJobClient {
+	return db.loadClient(ctx).CompletedJob
+}
+
 // FailedJob is the client for interacting with the FailedJob builders.
 func (db *Database) FailedJob(ctx context.Context) *FailedJobClient {
 	return db.loadClient(ctx).FailedJob
@@ -96,6 +102,11 @@ func (db *Database) Job(ctx context.Context) *JobClient {
 	return db.loadClient(ctx).Job
 }
 
+// JobSchedule is the client for interacting with the JobSchedule builders.
+func (db *Database) JobSchedule(ctx context.Context) *JobScheduleClient {
+	return db.loadClient(ctx).JobSchedule
+}
+
 // Message is the client for interacting with the Message builders.
 func (db *Database) Message(ctx context.Context) *MessageClient {
 	return db.loadClient(ctx).Message
@@ -13,8 +13,10 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/gerladeno/chat-service/internal/store/chat"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
 	"github.com/gerladeno/chat-service/internal/store/failedjob"
 	"github.com/gerladeno/chat-service/internal/store/job"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
 	"github.com/gerladeno/chat-service/internal/store/message"
 	"github.com/gerladeno/chat-service/internal/store/problem"
 )
@@ -77,11 +79,13 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			chat.Table:      chat.ValidColumn,
-			failedjob.Table: failedjob.ValidColumn,
-			job.Table:       job.ValidColumn,
-			message.Table:   message.ValidColumn,
-			problem.Table:   problem.ValidColumn,
+			chat.Table:         chat.ValidColumn,
+			completedjob.Table: completedjob.ValidColumn,
+			failedjob.Table:    failedjob.ValidColumn,
+			job.Table:          job.ValidColumn,
+			jobschedule.Table:  jobschedule.ValidColumn,
+			message.Table:      message.ValidColumn,
+			problem.Table:      problem.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
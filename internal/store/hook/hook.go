@@ -21,6 +21,18 @@ func (f ChatFunc) Mutate(ctx context.Context, m store.Mutation) (store.Value, er
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *store.ChatMutation", m)
 }
 
+// The CompletedJobFunc type is an adapter to allow the use of ordinary
+// function as CompletedJob mutator.
+type CompletedJobFunc func(context.Context, *store.CompletedJobMutation) (store.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f CompletedJobFunc) Mutate(ctx context.Context, m store.Mutation) (store.Value, error) {
+	if mv, ok := m.(*store.CompletedJobMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *store.CompletedJobMutation", m)
+}
+
 // The FailedJobFunc type is an adapter to allow the use of ordinary
 // function as FailedJob mutator.
 type FailedJobFunc func(context.Context, *store.FailedJobMutation) (store.Value, error)
@@ -45,6 +57,18 @@ func (f JobFunc) Mutate(ctx context.Context, m store.Mutation) (store.Value, err
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *store.JobMutation", m)
 }
 
+// The JobScheduleFunc type is an adapter to allow the use of ordinary
+// function as JobSchedule mutator.
+type JobScheduleFunc func(context.Context, *store.JobScheduleMutation) (store.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f JobScheduleFunc) Mutate(ctx context.Context, m store.Mutation) (store.Value, error) {
+	if mv, ok := m.(*store.JobScheduleMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *store.JobScheduleMutation", m)
+}
+
 // The MessageFunc type is an adapter to allow the use of ordinary
 // function as Message mutator.
 type MessageFunc func(context.Context, *store.MessageMutation) (store.Value, error)
@@ -22,6 +22,8 @@ type Job struct {
 	Name string `json:"name,omitempty"`
 	// Payload holds the value of the "payload" field.
 	Payload string `json:"payload,omitempty"`
+	// DedupKey holds the value of the "dedup_key" field.
+	DedupKey string `json:"dedup_key,omitempty"`
 	// Attempts holds the value of the "attempts" field.
 	Attempts int `json:"attempts,omitempty"`
 	// AvailableAt holds the value of the "available_at" field.
@@ -40,7 +42,7 @@ func (*Job) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case job.FieldAttempts:
 			values[i] = new(sql.NullInt64)
-		case job.FieldName, job.FieldPayload:
+		case job.FieldName, job.FieldPayload, job.FieldDedupKey:
 			values[i] = new(sql.NullString)
 		case job.FieldAvailableAt, job.FieldReservedUntil, job.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -79,6 +81,12 @@ func (j *Job) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				j.Payload = value.String
 			}
+		case job.FieldDedupKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field dedup_key", values[i])
+			} else if value.Valid {
+				j.DedupKey = value.String
+			}
 		case job.FieldAttempts:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field attempts", values[i])
@@ -145,6 +153,9 @@ func (j *Job) String() string {
 	builder.WriteString("payload=")
 	builder.WriteString(j.Payload)
 	builder.WriteString(", ")
+	builder.WriteString("dedup_key=")
+	builder.WriteString(j.DedupKey)
+	builder.WriteString(", ")
 	builder.WriteString("attempts=")
 	builder.WriteString(fmt.Sprintf("%v", j.Attempts))
 	builder.WriteString(", ")
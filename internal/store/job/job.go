@@ -18,6 +18,8 @@ const (
 	FieldName = "name"
 	// FieldPayload holds the string denoting the payload field in the database.
 	FieldPayload = "payload"
+	// FieldDedupKey holds the string denoting the dedup_key field in the database.
+	FieldDedupKey = "dedup_key"
 	// FieldAttempts holds the string denoting the attempts field in the database.
 	FieldAttempts = "attempts"
 	// FieldAvailableAt holds the string denoting the available_at field in the database.
@@ -35,6 +37,7 @@ var Columns = []string{
 	FieldID,
 	FieldName,
 	FieldPayload,
+	FieldDedupKey,
 	FieldAttempts,
 	FieldAvailableAt,
 	FieldReservedUntil,
@@ -82,6 +85,11 @@ func ByPayload(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPayload, opts...).ToFunc()
 }
 
+// ByDedupKey orders the results by the dedup_key field.
+func ByDedupKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDedupKey, opts...).ToFunc()
+}
+
 // ByAttempts orders the results by the attempts field.
 func ByAttempts(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAttempts, opts...).ToFunc()
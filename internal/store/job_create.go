@@ -36,6 +36,20 @@ func (jc *JobCreate) SetPayload(s string) *JobCreate {
 	return jc
 }
 
+// SetDedupKey sets the "dedup_key" field.
+func (jc *JobCreate) SetDedupKey(s string) *JobCreate {
+	jc.mutation.SetDedupKey(s)
+	return jc
+}
+
+// SetNillableDedupKey sets the "dedup_key" field if the given value is not nil.
+func (jc *JobCreate) SetNillableDedupKey(s *string) *JobCreate {
+	if s != nil {
+		jc.SetDedupKey(*s)
+	}
+	return jc
+}
+
 // SetAttempts sets the "attempts" field.
 func (jc *JobCreate) SetAttempts(i int) *JobCreate {
 	jc.mutation.SetAttempts(i)
@@ -225,6 +239,10 @@ func (jc *JobCreate) createSpec() (*Job, *sqlgraph.CreateSpec) {
 		_spec.SetField(job.FieldPayload, field.TypeString, value)
 		_node.Payload = value
 	}
+	if value, ok := jc.mutation.DedupKey(); ok {
+		_spec.SetField(job.FieldDedupKey, field.TypeString, value)
+		_node.DedupKey = value
+	}
 	if value, ok := jc.mutation.Attempts(); ok {
 		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
 		_node.Attempts = value
@@ -346,6 +364,9 @@ func (u *JobUpsertOne) UpdateNewValues() *JobUpsertOne {
 		if _, exists := u.create.mutation.Payload(); exists {
 			s.SetIgnore(job.FieldPayload)
 		}
+		if _, exists := u.create.mutation.DedupKey(); exists {
+			s.SetIgnore(job.FieldDedupKey)
+		}
 		if _, exists := u.create.mutation.AvailableAt(); exists {
 			s.SetIgnore(job.FieldAvailableAt)
 		}
@@ -603,6 +624,9 @@ func (u *JobUpsertBulk) UpdateNewValues() *JobUpsertBulk {
 			if _, exists := b.mutation.Payload(); exists {
 				s.SetIgnore(job.FieldPayload)
 			}
+			if _, exists := b.mutation.DedupKey(); exists {
+				s.SetIgnore(job.FieldDedupKey)
+			}
 			if _, exists := b.mutation.AvailableAt(); exists {
 				s.SetIgnore(job.FieldAvailableAt)
 			}
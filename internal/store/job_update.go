@@ -117,6 +117,9 @@ func (ju *JobUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if ju.mutation.DedupKeyCleared() {
+		_spec.ClearField(job.FieldDedupKey, field.TypeString)
+	}
 	if value, ok := ju.mutation.Attempts(); ok {
 		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
 	}
@@ -265,6 +268,9 @@ func (juo *JobUpdateOne) sqlSave(ctx context.Context) (_node *Job, err error) {
 			}
 		}
 	}
+	if juo.mutation.DedupKeyCleared() {
+		_spec.ClearField(job.FieldDedupKey, field.TypeString)
+	}
 	if value, ok := juo.mutation.Attempts(); ok {
 		_spec.SetField(job.FieldAttempts, field.TypeInt, value)
 	}
@@ -0,0 +1,129 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// JobSchedule is the model entity for the JobSchedule schema.
+type JobSchedule struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID types.JobScheduleID `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// NextRunAt holds the value of the "next_run_at" field.
+	NextRunAt time.Time `json:"next_run_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*JobSchedule) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case jobschedule.FieldName:
+			values[i] = new(sql.NullString)
+		case jobschedule.FieldNextRunAt, jobschedule.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case jobschedule.FieldID:
+			values[i] = new(types.JobScheduleID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the JobSchedule fields.
+func (js *JobSchedule) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case jobschedule.FieldID:
+			if value, ok := values[i].(*types.JobScheduleID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				js.ID = *value
+			}
+		case jobschedule.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				js.Name = value.String
+			}
+		case jobschedule.FieldNextRunAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field next_run_at", values[i])
+			} else if value.Valid {
+				js.NextRunAt = value.Time
+			}
+		case jobschedule.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				js.CreatedAt = value.Time
+			}
+		default:
+			js.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the JobSchedule.
+// This includes values selected through modifiers, order, etc.
+func (js *JobSchedule) Value(name string) (ent.Value, error) {
+	return js.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this JobSchedule.
+// Note that you need to call JobSchedule.Unwrap() before calling this method if this JobSchedule
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (js *JobSchedule) Update() *JobScheduleUpdateOne {
+	return NewJobScheduleClient(js.config).UpdateOne(js)
+}
+
+// Unwrap unwraps the JobSchedule entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (js *JobSchedule) Unwrap() *JobSchedule {
+	_tx, ok := js.config.driver.(*txDriver)
+	if !ok {
+		panic("store: JobSchedule is not a transactional entity")
+	}
+	js.config.driver = _tx.drv
+	return js
+}
+
+// String implements the fmt.Stringer.
+func (js *JobSchedule) String() string {
+	var builder strings.Builder
+	builder.WriteString("JobSchedule(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", js.ID))
+	builder.WriteString("name=")
+	builder.WriteString(js.Name)
+	builder.WriteString(", ")
+	builder.WriteString("next_run_at=")
+	builder.WriteString(js.NextRunAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(js.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// JobSchedules is a parsable slice of JobSchedule.
+type JobSchedules []*JobSchedule
@@ -0,0 +1,557 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// JobScheduleCreate is the builder for creating a JobSchedule entity.
+type JobScheduleCreate struct {
+	config
+	mutation *JobScheduleMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetName sets the "name" field.
+func (jsc *JobScheduleCreate) SetName(s string) *JobScheduleCreate {
+	jsc.mutation.SetName(s)
+	return jsc
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (jsc *JobScheduleCreate) SetNextRunAt(t time.Time) *JobScheduleCreate {
+	jsc.mutation.SetNextRunAt(t)
+	return jsc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (jsc *JobScheduleCreate) SetCreatedAt(t time.Time) *JobScheduleCreate {
+	jsc.mutation.SetCreatedAt(t)
+	return jsc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (jsc *JobScheduleCreate) SetNillableCreatedAt(t *time.Time) *JobScheduleCreate {
+	if t != nil {
+		jsc.SetCreatedAt(*t)
+	}
+	return jsc
+}
+
+// SetID sets the "id" field.
+func (jsc *JobScheduleCreate) SetID(tsi types.JobScheduleID) *JobScheduleCreate {
+	jsc.mutation.SetID(tsi)
+	return jsc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (jsc *JobScheduleCreate) SetNillableID(tsi *types.JobScheduleID) *JobScheduleCreate {
+	if tsi != nil {
+		jsc.SetID(*tsi)
+	}
+	return jsc
+}
+
+// Mutation returns the JobScheduleMutation object of the builder.
+func (jsc *JobScheduleCreate) Mutation() *JobScheduleMutation {
+	return jsc.mutation
+}
+
+// Save creates the JobSchedule in the database.
+func (jsc *JobScheduleCreate) Save(ctx context.Context) (*JobSchedule, error) {
+	jsc.defaults()
+	return withHooks[*JobSchedule, JobScheduleMutation](ctx, jsc.sqlSave, jsc.mutation, jsc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (jsc *JobScheduleCreate) SaveX(ctx context.Context) *JobSchedule {
+	v, err := jsc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (jsc *JobScheduleCreate) Exec(ctx context.Context) error {
+	_, err := jsc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jsc *JobScheduleCreate) ExecX(ctx context.Context) {
+	if err := jsc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (jsc *JobScheduleCreate) defaults() {
+	if _, ok := jsc.mutation.CreatedAt(); !ok {
+		v := jobschedule.DefaultCreatedAt()
+		jsc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := jsc.mutation.ID(); !ok {
+		v := jobschedule.DefaultID()
+		jsc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (jsc *JobScheduleCreate) check() error {
+	if _, ok := jsc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`store: missing required field "JobSchedule.name"`)}
+	}
+	if _, ok := jsc.mutation.NextRunAt(); !ok {
+		return &ValidationError{Name: "next_run_at", err: errors.New(`store: missing required field "JobSchedule.next_run_at"`)}
+	}
+	if _, ok := jsc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`store: missing required field "JobSchedule.created_at"`)}
+	}
+	if v, ok := jsc.mutation.ID(); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`store: validator failed for field "JobSchedule.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (jsc *JobScheduleCreate) sqlSave(ctx context.Context) (*JobSchedule, error) {
+	if err := jsc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := jsc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, jsc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*types.JobScheduleID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	jsc.mutation.id = &_node.ID
+	jsc.mutation.done = true
+	return _node, nil
+}
+
+func (jsc *JobScheduleCreate) createSpec() (*JobSchedule, *sqlgraph.CreateSpec) {
+	var (
+		_node = &JobSchedule{config: jsc.config}
+		_spec = sqlgraph.NewCreateSpec(jobschedule.Table, sqlgraph.NewFieldSpec(jobschedule.FieldID, field.TypeUUID))
+	)
+	_spec.OnConflict = jsc.conflict
+	if id, ok := jsc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := jsc.mutation.Name(); ok {
+		_spec.SetField(jobschedule.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := jsc.mutation.NextRunAt(); ok {
+		_spec.SetField(jobschedule.FieldNextRunAt, field.TypeTime, value)
+		_node.NextRunAt = value
+	}
+	if value, ok := jsc.mutation.CreatedAt(); ok {
+		_spec.SetField(jobschedule.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.JobSchedule.Create().
+//		SetName(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.JobScheduleUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (jsc *JobScheduleCreate) OnConflict(opts ...sql.ConflictOption) *JobScheduleUpsertOne {
+	jsc.conflict = opts
+	return &JobScheduleUpsertOne{
+		create: jsc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.JobSchedule.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (jsc *JobScheduleCreate) OnConflictColumns(columns ...string) *JobScheduleUpsertOne {
+	jsc.conflict = append(jsc.conflict, sql.ConflictColumns(columns...))
+	return &JobScheduleUpsertOne{
+		create: jsc,
+	}
+}
+
+type (
+	// JobScheduleUpsertOne is the builder for "upsert"-ing
+	//  one JobSchedule node.
+	JobScheduleUpsertOne struct {
+		create *JobScheduleCreate
+	}
+
+	// JobScheduleUpsert is the "OnConflict" setter.
+	JobScheduleUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetNextRunAt sets the "next_run_at" field.
+func (u *JobScheduleUpsert) SetNextRunAt(v time.Time) *JobScheduleUpsert {
+	u.Set(jobschedule.FieldNextRunAt, v)
+	return u
+}
+
+// UpdateNextRunAt sets the "next_run_at" field to the value that was provided on create.
+func (u *JobScheduleUpsert) UpdateNextRunAt() *JobScheduleUpsert {
+	u.SetExcluded(jobschedule.FieldNextRunAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.JobSchedule.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(jobschedule.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *JobScheduleUpsertOne) UpdateNewValues() *JobScheduleUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(jobschedule.FieldID)
+		}
+		if _, exists := u.create.mutation.Name(); exists {
+			s.SetIgnore(jobschedule.FieldName)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(jobschedule.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.JobSchedule.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *JobScheduleUpsertOne) Ignore() *JobScheduleUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *JobScheduleUpsertOne) DoNothing() *JobScheduleUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the JobScheduleCreate.OnConflict
+// documentation for more info.
+func (u *JobScheduleUpsertOne) Update(set func(*JobScheduleUpsert)) *JobScheduleUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&JobScheduleUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (u *JobScheduleUpsertOne) SetNextRunAt(v time.Time) *JobScheduleUpsertOne {
+	return u.Update(func(s *JobScheduleUpsert) {
+		s.SetNextRunAt(v)
+	})
+}
+
+// UpdateNextRunAt sets the "next_run_at" field to the value that was provided on create.
+func (u *JobScheduleUpsertOne) UpdateNextRunAt() *JobScheduleUpsertOne {
+	return u.Update(func(s *JobScheduleUpsert) {
+		s.UpdateNextRunAt()
+	})
+}
+
+// Exec executes the query.
+func (u *JobScheduleUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("store: missing options for JobScheduleCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *JobScheduleUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *JobScheduleUpsertOne) ID(ctx context.Context) (id types.JobScheduleID, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("store: JobScheduleUpsertOne.ID is not supported by MySQL driver. Use JobScheduleUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *JobScheduleUpsertOne) IDX(ctx context.Context) types.JobScheduleID {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// JobScheduleCreateBulk is the builder for creating many JobSchedule entities in bulk.
+type JobScheduleCreateBulk struct {
+	config
+	builders []*JobScheduleCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the JobSchedule entities in the database.
+func (jscb *JobScheduleCreateBulk) Save(ctx context.Context) ([]*JobSchedule, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(jscb.builders))
+	nodes := make([]*JobSchedule, len(jscb.builders))
+	mutators := make([]Mutator, len(jscb.builders))
+	for i := range jscb.builders {
+		func(i int, root context.Context) {
+			builder := jscb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*JobScheduleMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, jscb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = jscb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, jscb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, jscb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (jscb *JobScheduleCreateBulk) SaveX(ctx context.Context) []*JobSchedule {
+	v, err := jscb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (jscb *JobScheduleCreateBulk) Exec(ctx context.Context) error {
+	_, err := jscb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jscb *JobScheduleCreateBulk) ExecX(ctx context.Context) {
+	if err := jscb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.JobSchedule.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.JobScheduleUpsert) {
+//			SetName(v+v).
+//		}).
+//		Exec(ctx)
+func (jscb *JobScheduleCreateBulk) OnConflict(opts ...sql.ConflictOption) *JobScheduleUpsertBulk {
+	jscb.conflict = opts
+	return &JobScheduleUpsertBulk{
+		create: jscb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.JobSchedule.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (jscb *JobScheduleCreateBulk) OnConflictColumns(columns ...string) *JobScheduleUpsertBulk {
+	jscb.conflict = append(jscb.conflict, sql.ConflictColumns(columns...))
+	return &JobScheduleUpsertBulk{
+		create: jscb,
+	}
+}
+
+// JobScheduleUpsertBulk is the builder for "upsert"-ing
+// a bulk of JobSchedule nodes.
+type JobScheduleUpsertBulk struct {
+	create *JobScheduleCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.JobSchedule.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(jobschedule.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *JobScheduleUpsertBulk) UpdateNewValues() *JobScheduleUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(jobschedule.FieldID)
+			}
+			if _, exists := b.mutation.Name(); exists {
+				s.SetIgnore(jobschedule.FieldName)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(jobschedule.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.JobSchedule.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *JobScheduleUpsertBulk) Ignore() *JobScheduleUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *JobScheduleUpsertBulk) DoNothing() *JobScheduleUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the JobScheduleCreateBulk.OnConflict
+// documentation for more info.
+func (u *JobScheduleUpsertBulk) Update(set func(*JobScheduleUpsert)) *JobScheduleUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&JobScheduleUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (u *JobScheduleUpsertBulk) SetNextRunAt(v time.Time) *JobScheduleUpsertBulk {
+	return u.Update(func(s *JobScheduleUpsert) {
+		s.SetNextRunAt(v)
+	})
+}
+
+// UpdateNextRunAt sets the "next_run_at" field to the value that was provided on create.
+func (u *JobScheduleUpsertBulk) UpdateNextRunAt() *JobScheduleUpsertBulk {
+	return u.Update(func(s *JobScheduleUpsert) {
+		s.UpdateNextRunAt()
+	})
+}
+
+// Exec executes the query.
+func (u *JobScheduleUpsertBulk) Exec(ctx context.Context) error {
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("store: OnConflict was set for builder %d. Set it on the JobScheduleCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("store: missing options for JobScheduleCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *JobScheduleUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
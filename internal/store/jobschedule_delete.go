@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
+)
+
+// JobScheduleDelete is the builder for deleting a JobSchedule entity.
+type JobScheduleDelete struct {
+	config
+	hooks    []Hook
+	mutation *JobScheduleMutation
+}
+
+// Where appends a list predicates to the JobScheduleDelete builder.
+func (jsd *JobScheduleDelete) Where(ps ...predicate.JobSchedule) *JobScheduleDelete {
+	jsd.mutation.Where(ps...)
+	return jsd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (jsd *JobScheduleDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks[int, JobScheduleMutation](ctx, jsd.sqlExec, jsd.mutation, jsd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jsd *JobScheduleDelete) ExecX(ctx context.Context) int {
+	n, err := jsd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (jsd *JobScheduleDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(jobschedule.Table, sqlgraph.NewFieldSpec(jobschedule.FieldID, field.TypeUUID))
+	if ps := jsd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, jsd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	jsd.mutation.done = true
+	return affected, err
+}
+
+// JobScheduleDeleteOne is the builder for deleting a single JobSchedule entity.
+type JobScheduleDeleteOne struct {
+	jsd *JobScheduleDelete
+}
+
+// Where appends a list predicates to the JobScheduleDelete builder.
+func (jsdo *JobScheduleDeleteOne) Where(ps ...predicate.JobSchedule) *JobScheduleDeleteOne {
+	jsdo.jsd.mutation.Where(ps...)
+	return jsdo
+}
+
+// Exec executes the deletion query.
+func (jsdo *JobScheduleDeleteOne) Exec(ctx context.Context) error {
+	n, err := jsdo.jsd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{jobschedule.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jsdo *JobScheduleDeleteOne) ExecX(ctx context.Context) {
+	if err := jsdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
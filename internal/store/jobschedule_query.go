@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// JobScheduleQuery is the builder for querying JobSchedule entities.
+type JobScheduleQuery struct {
+	config
+	ctx        *QueryContext
+	order      []jobschedule.OrderOption
+	inters     []Interceptor
+	predicates []predicate.JobSchedule
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the JobScheduleQuery builder.
+func (jsq *JobScheduleQuery) Where(ps ...predicate.JobSchedule) *JobScheduleQuery {
+	jsq.predicates = append(jsq.predicates, ps...)
+	return jsq
+}
+
+// Limit the number of records to be returned by this query.
+func (jsq *JobScheduleQuery) Limit(limit int) *JobScheduleQuery {
+	jsq.ctx.Limit = &limit
+	return jsq
+}
+
+// Offset to start from.
+func (jsq *JobScheduleQuery) Offset(offset int) *JobScheduleQuery {
+	jsq.ctx.Offset = &offset
+	return jsq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (jsq *JobScheduleQuery) Unique(unique bool) *JobScheduleQuery {
+	jsq.ctx.Unique = &unique
+	return jsq
+}
+
+// Order specifies how the records should be ordered.
+func (jsq *JobScheduleQuery) Order(o ...jobschedule.OrderOption) *JobScheduleQuery {
+	jsq.order = append(jsq.order, o...)
+	return jsq
+}
+
+// First returns the first JobSchedule entity from the query.
+// Returns a *NotFoundError when no JobSchedule was found.
+func (jsq *JobScheduleQuery) First(ctx context.Context) (*JobSchedule, error) {
+	nodes, err := jsq.Limit(1).All(setContextOp(ctx, jsq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{jobschedule.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (jsq *JobScheduleQuery) FirstX(ctx context.Context) *JobSchedule {
+	node, err := jsq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first JobSchedule ID from the query.
+// Returns a *NotFoundError when no JobSchedule ID was found.
+func (jsq *JobScheduleQuery) FirstID(ctx context.Context) (id types.JobScheduleID, err error) {
+	var ids []types.JobScheduleID
+	if ids, err = jsq.Limit(1).IDs(setContextOp(ctx, jsq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{jobschedule.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (jsq *JobScheduleQuery) FirstIDX(ctx context.Context) types.JobScheduleID {
+	id, err := jsq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single JobSchedule entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one JobSchedule entity is found.
+// Returns a *NotFoundError when no JobSchedule entities are found.
+func (jsq *JobScheduleQuery) Only(ctx context.Context) (*JobSchedule, error) {
+	nodes, err := jsq.Limit(2).All(setContextOp(ctx, jsq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{jobschedule.Label}
+	default:
+		return nil, &NotSingularError{jobschedule.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (jsq *JobScheduleQuery) OnlyX(ctx context.Context) *JobSchedule {
+	node, err := jsq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only JobSchedule ID in the query.
+// Returns a *NotSingularError when more than one JobSchedule ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (jsq *JobScheduleQuery) OnlyID(ctx context.Context) (id types.JobScheduleID, err error) {
+	var ids []types.JobScheduleID
+	if ids, err = jsq.Limit(2).IDs(setContextOp(ctx, jsq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{jobschedule.Label}
+	default:
+		err = &NotSingularError{jobschedule.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (jsq *JobScheduleQuery) OnlyIDX(ctx context.Context) types.JobScheduleID {
+	id, err := jsq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of JobSchedules.
+func (jsq *JobScheduleQuery) All(ctx context.Context) ([]*JobSchedule, error) {
+	ctx = setContextOp(ctx, jsq.ctx, "All")
+	if err := jsq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*JobSchedule, *JobScheduleQuery]()
+	return withInterceptors[[]*JobSchedule](ctx, jsq, qr, jsq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (jsq *JobScheduleQuery) AllX(ctx context.Context) []*JobSchedule {
+	nodes, err := jsq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of JobSchedule IDs.
+func (jsq *JobScheduleQuery) IDs(ctx context.Context) (ids []types.JobScheduleID, err error) {
+	if jsq.ctx.Unique == nil && jsq.path != nil {
+		jsq.Unique(true)
+	}
+	ctx = setContextOp(ctx, jsq.ctx, "IDs")
+	if err = jsq.Select(jobschedule.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (jsq *JobScheduleQuery) IDsX(ctx context.Context) []types.JobScheduleID {
+	ids, err := jsq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (jsq *JobScheduleQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, jsq.ctx, "Count")
+	if err := jsq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, jsq, querierCount[*JobScheduleQuery](), jsq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (jsq *JobScheduleQuery) CountX(ctx context.Context) int {
+	count, err := jsq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (jsq *JobScheduleQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, jsq.ctx, "Exist")
+	switch _, err := jsq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("store: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (jsq *JobScheduleQuery) ExistX(ctx context.Context) bool {
+	exist, err := jsq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the JobScheduleQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (jsq *JobScheduleQuery) Clone() *JobScheduleQuery {
+	if jsq == nil {
+		return nil
+	}
+	return &JobScheduleQuery{
+		config:     jsq.config,
+		ctx:        jsq.ctx.Clone(),
+		order:      append([]jobschedule.OrderOption{}, jsq.order...),
+		inters:     append([]Interceptor{}, jsq.inters...),
+		predicates: append([]predicate.JobSchedule{}, jsq.predicates...),
+		// clone intermediate query.
+		sql:  jsq.sql.Clone(),
+		path: jsq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.JobSchedule.Query().
+//		GroupBy(jobschedule.FieldName).
+//		Aggregate(store.Count()).
+//		Scan(ctx, &v)
+func (jsq *JobScheduleQuery) GroupBy(field string, fields ...string) *JobScheduleGroupBy {
+	jsq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &JobScheduleGroupBy{build: jsq}
+	grbuild.flds = &jsq.ctx.Fields
+	grbuild.label = jobschedule.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	client.JobSchedule.Query().
+//		Select(jobschedule.FieldName).
+//		Scan(ctx, &v)
+func (jsq *JobScheduleQuery) Select(fields ...string) *JobScheduleSelect {
+	jsq.ctx.Fields = append(jsq.ctx.Fields, fields...)
+	sbuild := &JobScheduleSelect{JobScheduleQuery: jsq}
+	sbuild.label = jobschedule.Label
+	sbuild.flds, sbuild.scan = &jsq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a JobScheduleSelect configured with the given aggregations.
+func (jsq *JobScheduleQuery) Aggregate(fns ...AggregateFunc) *JobScheduleSelect {
+	return jsq.Select().Aggregate(fns...)
+}
+
+func (jsq *JobScheduleQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range jsq.inters {
+		if inter == nil {
+			return fmt.Errorf("store: uninitialized interceptor (forgotten import store/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, jsq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range jsq.ctx.Fields {
+		if !jobschedule.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("store: invalid field %q for query", f)}
+		}
+	}
+	if jsq.path != nil {
+		prev, err := jsq.path(ctx)
+		if err != nil {
+			return err
+		}
+		jsq.sql = prev
+	}
+	return nil
+}
+
+func (jsq *JobScheduleQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*JobSchedule, error) {
+	var (
+		nodes = []*JobSchedule{}
+		_spec = jsq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*JobSchedule).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &JobSchedule{config: jsq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(jsq.modifiers) > 0 {
+		_spec.Modifiers = jsq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, jsq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (jsq *JobScheduleQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := jsq.querySpec()
+	if len(jsq.modifiers) > 0 {
+		_spec.Modifiers = jsq.modifiers
+	}
+	_spec.Node.Columns = jsq.ctx.Fields
+	if len(jsq.ctx.Fields) > 0 {
+		_spec.Unique = jsq.ctx.Unique != nil && *jsq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, jsq.driver, _spec)
+}
+
+func (jsq *JobScheduleQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(jobschedule.Table, jobschedule.Columns, sqlgraph.NewFieldSpec(jobschedule.FieldID, field.TypeUUID))
+	_spec.From = jsq.sql
+	if unique := jsq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if jsq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := jsq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, jobschedule.FieldID)
+		for i := range fields {
+			if fields[i] != jobschedule.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := jsq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := jsq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := jsq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := jsq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (jsq *JobScheduleQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(jsq.driver.Dialect())
+	t1 := builder.Table(jobschedule.Table)
+	columns := jsq.ctx.Fields
+	if len(columns) == 0 {
+		columns = jobschedule.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if jsq.sql != nil {
+		selector = jsq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if jsq.ctx.Unique != nil && *jsq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range jsq.modifiers {
+		m(selector)
+	}
+	for _, p := range jsq.predicates {
+		p(selector)
+	}
+	for _, p := range jsq.order {
+		p(selector)
+	}
+	if offset := jsq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := jsq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (jsq *JobScheduleQuery) ForUpdate(opts ...sql.LockOption) *JobScheduleQuery {
+	if jsq.driver.Dialect() == dialect.Postgres {
+		jsq.Unique(false)
+	}
+	jsq.modifiers = append(jsq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return jsq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (jsq *JobScheduleQuery) ForShare(opts ...sql.LockOption) *JobScheduleQuery {
+	if jsq.driver.Dialect() == dialect.Postgres {
+		jsq.Unique(false)
+	}
+	jsq.modifiers = append(jsq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return jsq
+}
+
+// JobScheduleGroupBy is the group-by builder for JobSchedule entities.
+type JobScheduleGroupBy struct {
+	selector
+	build *JobScheduleQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (jsgb *JobScheduleGroupBy) Aggregate(fns ...AggregateFunc) *JobScheduleGroupBy {
+	jsgb.fns = append(jsgb.fns, fns...)
+	return jsgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (jsgb *JobScheduleGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, jsgb.build.ctx, "GroupBy")
+	if err := jsgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*JobScheduleQuery, *JobScheduleGroupBy](ctx, jsgb.build, jsgb, jsgb.build.inters, v)
+}
+
+func (jsgb *JobScheduleGroupBy) sqlScan(ctx context.Context, root *JobScheduleQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(jsgb.fns))
+	for _, fn := range jsgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*jsgb.flds)+len(jsgb.fns))
+		for _, f := range *jsgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*jsgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := jsgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// JobScheduleSelect is the builder for selecting fields of JobSchedule entities.
+type JobScheduleSelect struct {
+	*JobScheduleQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (jss *JobScheduleSelect) Aggregate(fns ...AggregateFunc) *JobScheduleSelect {
+	jss.fns = append(jss.fns, fns...)
+	return jss
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (jss *JobScheduleSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, jss.ctx, "Select")
+	if err := jss.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*JobScheduleQuery, *JobScheduleSelect](ctx, jss.JobScheduleQuery, jss, jss.inters, v)
+}
+
+func (jss *JobScheduleSelect) sqlScan(ctx context.Context, root *JobScheduleQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(jss.fns))
+	for _, fn := range jss.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*jss.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := jss.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,194 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
+	"github.com/gerladeno/chat-service/internal/store/predicate"
+)
+
+// JobScheduleUpdate is the builder for updating JobSchedule entities.
+type JobScheduleUpdate struct {
+	config
+	hooks    []Hook
+	mutation *JobScheduleMutation
+}
+
+// Where appends a list predicates to the JobScheduleUpdate builder.
+func (jsu *JobScheduleUpdate) Where(ps ...predicate.JobSchedule) *JobScheduleUpdate {
+	jsu.mutation.Where(ps...)
+	return jsu
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (jsu *JobScheduleUpdate) SetNextRunAt(t time.Time) *JobScheduleUpdate {
+	jsu.mutation.SetNextRunAt(t)
+	return jsu
+}
+
+// Mutation returns the JobScheduleMutation object of the builder.
+func (jsu *JobScheduleUpdate) Mutation() *JobScheduleMutation {
+	return jsu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (jsu *JobScheduleUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks[int, JobScheduleMutation](ctx, jsu.sqlSave, jsu.mutation, jsu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (jsu *JobScheduleUpdate) SaveX(ctx context.Context) int {
+	affected, err := jsu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (jsu *JobScheduleUpdate) Exec(ctx context.Context) error {
+	_, err := jsu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jsu *JobScheduleUpdate) ExecX(ctx context.Context) {
+	if err := jsu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (jsu *JobScheduleUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(jobschedule.Table, jobschedule.Columns, sqlgraph.NewFieldSpec(jobschedule.FieldID, field.TypeUUID))
+	if ps := jsu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := jsu.mutation.NextRunAt(); ok {
+		_spec.SetField(jobschedule.FieldNextRunAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, jsu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{jobschedule.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	jsu.mutation.done = true
+	return n, nil
+}
+
+// JobScheduleUpdateOne is the builder for updating a single JobSchedule entity.
+type JobScheduleUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *JobScheduleMutation
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (jsuo *JobScheduleUpdateOne) SetNextRunAt(t time.Time) *JobScheduleUpdateOne {
+	jsuo.mutation.SetNextRunAt(t)
+	return jsuo
+}
+
+// Mutation returns the JobScheduleMutation object of the builder.
+func (jsuo *JobScheduleUpdateOne) Mutation() *JobScheduleMutation {
+	return jsuo.mutation
+}
+
+// Where appends a list predicates to the JobScheduleUpdate builder.
+func (jsuo *JobScheduleUpdateOne) Where(ps ...predicate.JobSchedule) *JobScheduleUpdateOne {
+	jsuo.mutation.Where(ps...)
+	return jsuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (jsuo *JobScheduleUpdateOne) Select(field string, fields ...string) *JobScheduleUpdateOne {
+	jsuo.fields = append([]string{field}, fields...)
+	return jsuo
+}
+
+// Save executes the query and returns the updated JobSchedule entity.
+func (jsuo *JobScheduleUpdateOne) Save(ctx context.Context) (*JobSchedule, error) {
+	return withHooks[*JobSchedule, JobScheduleMutation](ctx, jsuo.sqlSave, jsuo.mutation, jsuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (jsuo *JobScheduleUpdateOne) SaveX(ctx context.Context) *JobSchedule {
+	node, err := jsuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (jsuo *JobScheduleUpdateOne) Exec(ctx context.Context) error {
+	_, err := jsuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (jsuo *JobScheduleUpdateOne) ExecX(ctx context.Context) {
+	if err := jsuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (jsuo *JobScheduleUpdateOne) sqlSave(ctx context.Context) (_node *JobSchedule, err error) {
+	_spec := sqlgraph.NewUpdateSpec(jobschedule.Table, jobschedule.Columns, sqlgraph.NewFieldSpec(jobschedule.FieldID, field.TypeUUID))
+	id, ok := jsuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`store: missing "JobSchedule.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := jsuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, jobschedule.FieldID)
+		for _, f := range fields {
+			if !jobschedule.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("store: invalid field %q for query", f)}
+			}
+			if f != jobschedule.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := jsuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := jsuo.mutation.NextRunAt(); ok {
+		_spec.SetField(jobschedule.FieldNextRunAt, field.TypeTime, value)
+	}
+	_node = &JobSchedule{config: jsuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, jsuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{jobschedule.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	jsuo.mutation.done = true
+	return _node, nil
+}
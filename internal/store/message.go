@@ -34,8 +34,16 @@ type Message struct {
 	IsVisibleForManager bool `json:"is_visible_for_manager,omitempty"`
 	// Body holds the value of the "body" field.
 	Body string `json:"body,omitempty"`
+	// SealedBody holds the value of the "sealed_body" field.
+	SealedBody []byte `json:"-"`
 	// CheckedAt holds the value of the "checked_at" field.
 	CheckedAt time.Time `json:"checked_at,omitempty"`
+	// VerdictStatus holds the value of the "verdict_status" field.
+	VerdictStatus message.VerdictStatus `json:"verdict_status,omitempty"`
+	// VerdictSource holds the value of the "verdict_source" field.
+	VerdictSource string `json:"verdict_source,omitempty"`
+	// VerdictAt holds the value of the "verdict_at" field.
+	VerdictAt time.Time `json:"verdict_at,omitempty"`
 	// IsBlocked holds the value of the "is_blocked" field.
 	IsBlocked bool `json:"is_blocked,omitempty"`
 	// IsService holds the value of the "is_service" field.
@@ -90,11 +98,13 @@ func (*Message) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case message.FieldSealedBody:
+			values[i] = new([]byte)
 		case message.FieldIsVisibleForClient, message.FieldIsVisibleForManager, message.FieldIsBlocked, message.FieldIsService:
 			values[i] = new(sql.NullBool)
-		case message.FieldBody:
+		case message.FieldBody, message.FieldVerdictStatus, message.FieldVerdictSource:
 			values[i] = new(sql.NullString)
-		case message.FieldCheckedAt, message.FieldCreatedAt:
+		case message.FieldCheckedAt, message.FieldVerdictAt, message.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
 		case message.FieldChatID:
 			values[i] = new(types.ChatID)
@@ -169,12 +179,36 @@ func (m *Message) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				m.Body = value.String
 			}
+		case message.FieldSealedBody:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field sealed_body", values[i])
+			} else if value != nil {
+				m.SealedBody = *value
+			}
 		case message.FieldCheckedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field checked_at", values[i])
 			} else if value.Valid {
 				m.CheckedAt = value.Time
 			}
+		case message.FieldVerdictStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field verdict_status", values[i])
+			} else if value.Valid {
+				m.VerdictStatus = message.VerdictStatus(value.String)
+			}
+		case message.FieldVerdictSource:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field verdict_source", values[i])
+			} else if value.Valid {
+				m.VerdictSource = value.String
+			}
+		case message.FieldVerdictAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field verdict_at", values[i])
+			} else if value.Valid {
+				m.VerdictAt = value.Time
+			}
 		case message.FieldIsBlocked:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field is_blocked", values[i])
@@ -260,9 +294,20 @@ func (m *Message) String() string {
 	builder.WriteString("body=")
 	builder.WriteString(m.Body)
 	builder.WriteString(", ")
+	builder.WriteString("sealed_body=<sensitive>")
+	builder.WriteString(", ")
 	builder.WriteString("checked_at=")
 	builder.WriteString(m.CheckedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
+	builder.WriteString("verdict_status=")
+	builder.WriteString(fmt.Sprintf("%v", m.VerdictStatus))
+	builder.WriteString(", ")
+	builder.WriteString("verdict_source=")
+	builder.WriteString(m.VerdictSource)
+	builder.WriteString(", ")
+	builder.WriteString("verdict_at=")
+	builder.WriteString(m.VerdictAt.Format(time.ANSIC))
+	builder.WriteString(", ")
 	builder.WriteString("is_blocked=")
 	builder.WriteString(fmt.Sprintf("%v", m.IsBlocked))
 	builder.WriteString(", ")
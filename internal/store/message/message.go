@@ -3,6 +3,7 @@
 package message
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -29,8 +30,16 @@ const (
 	FieldIsVisibleForManager = "is_visible_for_manager"
 	// FieldBody holds the string denoting the body field in the database.
 	FieldBody = "body"
+	// FieldSealedBody holds the string denoting the sealed_body field in the database.
+	FieldSealedBody = "sealed_body"
 	// FieldCheckedAt holds the string denoting the checked_at field in the database.
 	FieldCheckedAt = "checked_at"
+	// FieldVerdictStatus holds the string denoting the verdict_status field in the database.
+	FieldVerdictStatus = "verdict_status"
+	// FieldVerdictSource holds the string denoting the verdict_source field in the database.
+	FieldVerdictSource = "verdict_source"
+	// FieldVerdictAt holds the string denoting the verdict_at field in the database.
+	FieldVerdictAt = "verdict_at"
 	// FieldIsBlocked holds the string denoting the is_blocked field in the database.
 	FieldIsBlocked = "is_blocked"
 	// FieldIsService holds the string denoting the is_service field in the database.
@@ -69,7 +78,11 @@ var Columns = []string{
 	FieldIsVisibleForClient,
 	FieldIsVisibleForManager,
 	FieldBody,
+	FieldSealedBody,
 	FieldCheckedAt,
+	FieldVerdictStatus,
+	FieldVerdictSource,
+	FieldVerdictAt,
 	FieldIsBlocked,
 	FieldIsService,
 	FieldCreatedAt,
@@ -102,6 +115,29 @@ var (
 	DefaultID func() types.MessageID
 )
 
+// VerdictStatus defines the type for the "verdict_status" enum field.
+type VerdictStatus string
+
+// VerdictStatus values.
+const (
+	VerdictStatusOk         VerdictStatus = "ok"
+	VerdictStatusSuspicious VerdictStatus = "suspicious"
+)
+
+func (vs VerdictStatus) String() string {
+	return string(vs)
+}
+
+// VerdictStatusValidator is a validator for the "verdict_status" field enum values. It is called by the builders before save.
+func VerdictStatusValidator(vs VerdictStatus) error {
+	switch vs {
+	case VerdictStatusOk, VerdictStatusSuspicious:
+		return nil
+	default:
+		return fmt.Errorf("message: invalid enum value for verdict_status field: %q", vs)
+	}
+}
+
 // OrderOption defines the ordering options for the Message queries.
 type OrderOption func(*sql.Selector)
 
@@ -150,6 +186,21 @@ func ByCheckedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCheckedAt, opts...).ToFunc()
 }
 
+// ByVerdictStatus orders the results by the verdict_status field.
+func ByVerdictStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVerdictStatus, opts...).ToFunc()
+}
+
+// ByVerdictSource orders the results by the verdict_source field.
+func ByVerdictSource(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVerdictSource, opts...).ToFunc()
+}
+
+// ByVerdictAt orders the results by the verdict_at field.
+func ByVerdictAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVerdictAt, opts...).ToFunc()
+}
+
 // ByIsBlocked orders the results by the is_blocked field.
 func ByIsBlocked(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldIsBlocked, opts...).ToFunc()
@@ -91,11 +91,26 @@ func Body(v string) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldBody, v))
 }
 
+// SealedBody applies equality check predicate on the "sealed_body" field. It's identical to SealedBodyEQ.
+func SealedBody(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldSealedBody, v))
+}
+
 // CheckedAt applies equality check predicate on the "checked_at" field. It's identical to CheckedAtEQ.
 func CheckedAt(v time.Time) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldCheckedAt, v))
 }
 
+// VerdictSource applies equality check predicate on the "verdict_source" field. It's identical to VerdictSourceEQ.
+func VerdictSource(v string) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldVerdictSource, v))
+}
+
+// VerdictAt applies equality check predicate on the "verdict_at" field. It's identical to VerdictAtEQ.
+func VerdictAt(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldVerdictAt, v))
+}
+
 // IsBlocked applies equality check predicate on the "is_blocked" field. It's identical to IsBlockedEQ.
 func IsBlocked(v bool) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldIsBlocked, v))
@@ -326,6 +341,56 @@ func BodyContainsFold(v string) predicate.Message {
 	return predicate.Message(sql.FieldContainsFold(FieldBody, v))
 }
 
+// SealedBodyEQ applies the EQ predicate on the "sealed_body" field.
+func SealedBodyEQ(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldSealedBody, v))
+}
+
+// SealedBodyNEQ applies the NEQ predicate on the "sealed_body" field.
+func SealedBodyNEQ(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldSealedBody, v))
+}
+
+// SealedBodyIn applies the In predicate on the "sealed_body" field.
+func SealedBodyIn(vs ...[]byte) predicate.Message {
+	return predicate.Message(sql.FieldIn(FieldSealedBody, vs...))
+}
+
+// SealedBodyNotIn applies the NotIn predicate on the "sealed_body" field.
+func SealedBodyNotIn(vs ...[]byte) predicate.Message {
+	return predicate.Message(sql.FieldNotIn(FieldSealedBody, vs...))
+}
+
+// SealedBodyGT applies the GT predicate on the "sealed_body" field.
+func SealedBodyGT(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldGT(FieldSealedBody, v))
+}
+
+// SealedBodyGTE applies the GTE predicate on the "sealed_body" field.
+func SealedBodyGTE(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldGTE(FieldSealedBody, v))
+}
+
+// SealedBodyLT applies the LT predicate on the "sealed_body" field.
+func SealedBodyLT(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldLT(FieldSealedBody, v))
+}
+
+// SealedBodyLTE applies the LTE predicate on the "sealed_body" field.
+func SealedBodyLTE(v []byte) predicate.Message {
+	return predicate.Message(sql.FieldLTE(FieldSealedBody, v))
+}
+
+// SealedBodyIsNil applies the IsNil predicate on the "sealed_body" field.
+func SealedBodyIsNil() predicate.Message {
+	return predicate.Message(sql.FieldIsNull(FieldSealedBody))
+}
+
+// SealedBodyNotNil applies the NotNil predicate on the "sealed_body" field.
+func SealedBodyNotNil() predicate.Message {
+	return predicate.Message(sql.FieldNotNull(FieldSealedBody))
+}
+
 // CheckedAtEQ applies the EQ predicate on the "checked_at" field.
 func CheckedAtEQ(v time.Time) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldCheckedAt, v))
@@ -376,6 +441,161 @@ func CheckedAtNotNil() predicate.Message {
 	return predicate.Message(sql.FieldNotNull(FieldCheckedAt))
 }
 
+// VerdictStatusEQ applies the EQ predicate on the "verdict_status" field.
+func VerdictStatusEQ(v VerdictStatus) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldVerdictStatus, v))
+}
+
+// VerdictStatusNEQ applies the NEQ predicate on the "verdict_status" field.
+func VerdictStatusNEQ(v VerdictStatus) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldVerdictStatus, v))
+}
+
+// VerdictStatusIn applies the In predicate on the "verdict_status" field.
+func VerdictStatusIn(vs ...VerdictStatus) predicate.Message {
+	return predicate.Message(sql.FieldIn(FieldVerdictStatus, vs...))
+}
+
+// VerdictStatusNotIn applies the NotIn predicate on the "verdict_status" field.
+func VerdictStatusNotIn(vs ...VerdictStatus) predicate.Message {
+	return predicate.Message(sql.FieldNotIn(FieldVerdictStatus, vs...))
+}
+
+// VerdictStatusIsNil applies the IsNil predicate on the "verdict_status" field.
+func VerdictStatusIsNil() predicate.Message {
+	return predicate.Message(sql.FieldIsNull(FieldVerdictStatus))
+}
+
+// VerdictStatusNotNil applies the NotNil predicate on the "verdict_status" field.
+func VerdictStatusNotNil() predicate.Message {
+	return predicate.Message(sql.FieldNotNull(FieldVerdictStatus))
+}
+
+// VerdictSourceEQ applies the EQ predicate on the "verdict_source" field.
+func VerdictSourceEQ(v string) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldVerdictSource, v))
+}
+
+// VerdictSourceNEQ applies the NEQ predicate on the "verdict_source" field.
+func VerdictSourceNEQ(v string) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldVerdictSource, v))
+}
+
+// VerdictSourceIn applies the In predicate on the "verdict_source" field.
+func VerdictSourceIn(vs ...string) predicate.Message {
+	return predicate.Message(sql.FieldIn(FieldVerdictSource, vs...))
+}
+
+// VerdictSourceNotIn applies the NotIn predicate on the "verdict_source" field.
+func VerdictSourceNotIn(vs ...string) predicate.Message {
+	return predicate.Message(sql.FieldNotIn(FieldVerdictSource, vs...))
+}
+
+// VerdictSourceGT applies the GT predicate on the "verdict_source" field.
+func VerdictSourceGT(v string) predicate.Message {
+	return predicate.Message(sql.FieldGT(FieldVerdictSource, v))
+}
+
+// VerdictSourceGTE applies the GTE predicate on the "verdict_source" field.
+func VerdictSourceGTE(v string) predicate.Message {
+	return predicate.Message(sql.FieldGTE(FieldVerdictSource, v))
+}
+
+// VerdictSourceLT applies the LT predicate on the "verdict_source" field.
+func VerdictSourceLT(v string) predicate.Message {
+	return predicate.Message(sql.FieldLT(FieldVerdictSource, v))
+}
+
+// VerdictSourceLTE applies the LTE predicate on the "verdict_source" field.
+func VerdictSourceLTE(v string) predicate.Message {
+	return predicate.Message(sql.FieldLTE(FieldVerdictSource, v))
+}
+
+// VerdictSourceContains applies the Contains predicate on the "verdict_source" field.
+func VerdictSourceContains(v string) predicate.Message {
+	return predicate.Message(sql.FieldContains(FieldVerdictSource, v))
+}
+
+// VerdictSourceHasPrefix applies the HasPrefix predicate on the "verdict_source" field.
+func VerdictSourceHasPrefix(v string) predicate.Message {
+	return predicate.Message(sql.FieldHasPrefix(FieldVerdictSource, v))
+}
+
+// VerdictSourceHasSuffix applies the HasSuffix predicate on the "verdict_source" field.
+func VerdictSourceHasSuffix(v string) predicate.Message {
+	return predicate.Message(sql.FieldHasSuffix(FieldVerdictSource, v))
+}
+
+// VerdictSourceIsNil applies the IsNil predicate on the "verdict_source" field.
+func VerdictSourceIsNil() predicate.Message {
+	return predicate.Message(sql.FieldIsNull(FieldVerdictSource))
+}
+
+// VerdictSourceNotNil applies the NotNil predicate on the "verdict_source" field.
+func VerdictSourceNotNil() predicate.Message {
+	return predicate.Message(sql.FieldNotNull(FieldVerdictSource))
+}
+
+// VerdictSourceEqualFold applies the EqualFold predicate on the "verdict_source" field.
+func VerdictSourceEqualFold(v string) predicate.Message {
+	return predicate.Message(sql.FieldEqualFold(FieldVerdictSource, v))
+}
+
+// VerdictSourceContainsFold applies the ContainsFold predicate on the "verdict_source" field.
+func VerdictSourceContainsFold(v string) predicate.Message {
+	return predicate.Message(sql.FieldContainsFold(FieldVerdictSource, v))
+}
+
+// VerdictAtEQ applies the EQ predicate on the "verdict_at" field.
+func VerdictAtEQ(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldVerdictAt, v))
+}
+
+// VerdictAtNEQ applies the NEQ predicate on the "verdict_at" field.
+func VerdictAtNEQ(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldVerdictAt, v))
+}
+
+// VerdictAtIn applies the In predicate on the "verdict_at" field.
+func VerdictAtIn(vs ...time.Time) predicate.Message {
+	return predicate.Message(sql.FieldIn(FieldVerdictAt, vs...))
+}
+
+// VerdictAtNotIn applies the NotIn predicate on the "verdict_at" field.
+func VerdictAtNotIn(vs ...time.Time) predicate.Message {
+	return predicate.Message(sql.FieldNotIn(FieldVerdictAt, vs...))
+}
+
+// VerdictAtGT applies the GT predicate on the "verdict_at" field.
+func VerdictAtGT(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldGT(FieldVerdictAt, v))
+}
+
+// VerdictAtGTE applies the GTE predicate on the "verdict_at" field.
+func VerdictAtGTE(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldGTE(FieldVerdictAt, v))
+}
+
+// VerdictAtLT applies the LT predicate on the "verdict_at" field.
+func VerdictAtLT(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldLT(FieldVerdictAt, v))
+}
+
+// VerdictAtLTE applies the LTE predicate on the "verdict_at" field.
+func VerdictAtLTE(v time.Time) predicate.Message {
+	return predicate.Message(sql.FieldLTE(FieldVerdictAt, v))
+}
+
+// VerdictAtIsNil applies the IsNil predicate on the "verdict_at" field.
+func VerdictAtIsNil() predicate.Message {
+	return predicate.Message(sql.FieldIsNull(FieldVerdictAt))
+}
+
+// VerdictAtNotNil applies the NotNil predicate on the "verdict_at" field.
+func VerdictAtNotNil() predicate.Message {
+	return predicate.Message(sql.FieldNotNull(FieldVerdictAt))
+}
+
 // IsBlockedEQ applies the EQ predicate on the "is_blocked" field.
 func IsBlockedEQ(v bool) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldIsBlocked, v))
@@ -92,6 +92,12 @@ func (mc *MessageCreate) SetBody(s string) *MessageCreate {
 	return mc
 }
 
+// SetSealedBody sets the "sealed_body" field.
+func (mc *MessageCreate) SetSealedBody(b []byte) *MessageCreate {
+	mc.mutation.SetSealedBody(b)
+	return mc
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (mc *MessageCreate) SetCheckedAt(t time.Time) *MessageCreate {
 	mc.mutation.SetCheckedAt(t)
@@ -106,6 +112,48 @@ func (mc *MessageCreate) SetNillableCheckedAt(t *time.Time) *MessageCreate {
 	return mc
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (mc *MessageCreate) SetVerdictStatus(ms message.VerdictStatus) *MessageCreate {
+	mc.mutation.SetVerdictStatus(ms)
+	return mc
+}
+
+// SetNillableVerdictStatus sets the "verdict_status" field if the given value is not nil.
+func (mc *MessageCreate) SetNillableVerdictStatus(ms *message.VerdictStatus) *MessageCreate {
+	if ms != nil {
+		mc.SetVerdictStatus(*ms)
+	}
+	return mc
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (mc *MessageCreate) SetVerdictSource(s string) *MessageCreate {
+	mc.mutation.SetVerdictSource(s)
+	return mc
+}
+
+// SetNillableVerdictSource sets the "verdict_source" field if the given value is not nil.
+func (mc *MessageCreate) SetNillableVerdictSource(s *string) *MessageCreate {
+	if s != nil {
+		mc.SetVerdictSource(*s)
+	}
+	return mc
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (mc *MessageCreate) SetVerdictAt(t time.Time) *MessageCreate {
+	mc.mutation.SetVerdictAt(t)
+	return mc
+}
+
+// SetNillableVerdictAt sets the "verdict_at" field if the given value is not nil.
+func (mc *MessageCreate) SetNillableVerdictAt(t *time.Time) *MessageCreate {
+	if t != nil {
+		mc.SetVerdictAt(*t)
+	}
+	return mc
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (mc *MessageCreate) SetIsBlocked(b bool) *MessageCreate {
 	mc.mutation.SetIsBlocked(b)
@@ -278,6 +326,11 @@ func (mc *MessageCreate) check() error {
 			return &ValidationError{Name: "body", err: fmt.Errorf(`store: validator failed for field "Message.body": %w`, err)}
 		}
 	}
+	if v, ok := mc.mutation.VerdictStatus(); ok {
+		if err := message.VerdictStatusValidator(v); err != nil {
+			return &ValidationError{Name: "verdict_status", err: fmt.Errorf(`store: validator failed for field "Message.verdict_status": %w`, err)}
+		}
+	}
 	if _, ok := mc.mutation.IsBlocked(); !ok {
 		return &ValidationError{Name: "is_blocked", err: errors.New(`store: missing required field "Message.is_blocked"`)}
 	}
@@ -354,10 +407,26 @@ func (mc *MessageCreate) createSpec() (*Message, *sqlgraph.CreateSpec) {
 		_spec.SetField(message.FieldBody, field.TypeString, value)
 		_node.Body = value
 	}
+	if value, ok := mc.mutation.SealedBody(); ok {
+		_spec.SetField(message.FieldSealedBody, field.TypeBytes, value)
+		_node.SealedBody = value
+	}
 	if value, ok := mc.mutation.CheckedAt(); ok {
 		_spec.SetField(message.FieldCheckedAt, field.TypeTime, value)
 		_node.CheckedAt = value
 	}
+	if value, ok := mc.mutation.VerdictStatus(); ok {
+		_spec.SetField(message.FieldVerdictStatus, field.TypeEnum, value)
+		_node.VerdictStatus = value
+	}
+	if value, ok := mc.mutation.VerdictSource(); ok {
+		_spec.SetField(message.FieldVerdictSource, field.TypeString, value)
+		_node.VerdictSource = value
+	}
+	if value, ok := mc.mutation.VerdictAt(); ok {
+		_spec.SetField(message.FieldVerdictAt, field.TypeTime, value)
+		_node.VerdictAt = value
+	}
 	if value, ok := mc.mutation.IsBlocked(); ok {
 		_spec.SetField(message.FieldIsBlocked, field.TypeBool, value)
 		_node.IsBlocked = value
@@ -534,6 +603,36 @@ func (u *MessageUpsert) UpdateIsVisibleForManager() *MessageUpsert {
 	return u
 }
 
+// SetBody sets the "body" field.
+func (u *MessageUpsert) SetBody(v string) *MessageUpsert {
+	u.Set(message.FieldBody, v)
+	return u
+}
+
+// UpdateBody sets the "body" field to the value that was provided on create.
+func (u *MessageUpsert) UpdateBody() *MessageUpsert {
+	u.SetExcluded(message.FieldBody)
+	return u
+}
+
+// SetSealedBody sets the "sealed_body" field.
+func (u *MessageUpsert) SetSealedBody(v []byte) *MessageUpsert {
+	u.Set(message.FieldSealedBody, v)
+	return u
+}
+
+// UpdateSealedBody sets the "sealed_body" field to the value that was provided on create.
+func (u *MessageUpsert) UpdateSealedBody() *MessageUpsert {
+	u.SetExcluded(message.FieldSealedBody)
+	return u
+}
+
+// ClearSealedBody clears the value of the "sealed_body" field.
+func (u *MessageUpsert) ClearSealedBody() *MessageUpsert {
+	u.SetNull(message.FieldSealedBody)
+	return u
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (u *MessageUpsert) SetCheckedAt(v time.Time) *MessageUpsert {
 	u.Set(message.FieldCheckedAt, v)
@@ -552,6 +651,60 @@ func (u *MessageUpsert) ClearCheckedAt() *MessageUpsert {
 	return u
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (u *MessageUpsert) SetVerdictStatus(v message.VerdictStatus) *MessageUpsert {
+	u.Set(message.FieldVerdictStatus, v)
+	return u
+}
+
+// UpdateVerdictStatus sets the "verdict_status" field to the value that was provided on create.
+func (u *MessageUpsert) UpdateVerdictStatus() *MessageUpsert {
+	u.SetExcluded(message.FieldVerdictStatus)
+	return u
+}
+
+// ClearVerdictStatus clears the value of the "verdict_status" field.
+func (u *MessageUpsert) ClearVerdictStatus() *MessageUpsert {
+	u.SetNull(message.FieldVerdictStatus)
+	return u
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (u *MessageUpsert) SetVerdictSource(v string) *MessageUpsert {
+	u.Set(message.FieldVerdictSource, v)
+	return u
+}
+
+// UpdateVerdictSource sets the "verdict_source" field to the value that was provided on create.
+func (u *MessageUpsert) UpdateVerdictSource() *MessageUpsert {
+	u.SetExcluded(message.FieldVerdictSource)
+	return u
+}
+
+// ClearVerdictSource clears the value of the "verdict_source" field.
+func (u *MessageUpsert) ClearVerdictSource() *MessageUpsert {
+	u.SetNull(message.FieldVerdictSource)
+	return u
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (u *MessageUpsert) SetVerdictAt(v time.Time) *MessageUpsert {
+	u.Set(message.FieldVerdictAt, v)
+	return u
+}
+
+// UpdateVerdictAt sets the "verdict_at" field to the value that was provided on create.
+func (u *MessageUpsert) UpdateVerdictAt() *MessageUpsert {
+	u.SetExcluded(message.FieldVerdictAt)
+	return u
+}
+
+// ClearVerdictAt clears the value of the "verdict_at" field.
+func (u *MessageUpsert) ClearVerdictAt() *MessageUpsert {
+	u.SetNull(message.FieldVerdictAt)
+	return u
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (u *MessageUpsert) SetIsBlocked(v bool) *MessageUpsert {
 	u.Set(message.FieldIsBlocked, v)
@@ -581,9 +734,6 @@ func (u *MessageUpsertOne) UpdateNewValues() *MessageUpsertOne {
 		if _, exists := u.create.mutation.ID(); exists {
 			s.SetIgnore(message.FieldID)
 		}
-		if _, exists := u.create.mutation.Body(); exists {
-			s.SetIgnore(message.FieldBody)
-		}
 		if _, exists := u.create.mutation.IsService(); exists {
 			s.SetIgnore(message.FieldIsService)
 		}
@@ -712,6 +862,41 @@ func (u *MessageUpsertOne) UpdateIsVisibleForManager() *MessageUpsertOne {
 	})
 }
 
+// SetBody sets the "body" field.
+func (u *MessageUpsertOne) SetBody(v string) *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetBody(v)
+	})
+}
+
+// UpdateBody sets the "body" field to the value that was provided on create.
+func (u *MessageUpsertOne) UpdateBody() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateBody()
+	})
+}
+
+// SetSealedBody sets the "sealed_body" field.
+func (u *MessageUpsertOne) SetSealedBody(v []byte) *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetSealedBody(v)
+	})
+}
+
+// UpdateSealedBody sets the "sealed_body" field to the value that was provided on create.
+func (u *MessageUpsertOne) UpdateSealedBody() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateSealedBody()
+	})
+}
+
+// ClearSealedBody clears the value of the "sealed_body" field.
+func (u *MessageUpsertOne) ClearSealedBody() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearSealedBody()
+	})
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (u *MessageUpsertOne) SetCheckedAt(v time.Time) *MessageUpsertOne {
 	return u.Update(func(s *MessageUpsert) {
@@ -733,6 +918,69 @@ func (u *MessageUpsertOne) ClearCheckedAt() *MessageUpsertOne {
 	})
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (u *MessageUpsertOne) SetVerdictStatus(v message.VerdictStatus) *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetVerdictStatus(v)
+	})
+}
+
+// UpdateVerdictStatus sets the "verdict_status" field to the value that was provided on create.
+func (u *MessageUpsertOne) UpdateVerdictStatus() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateVerdictStatus()
+	})
+}
+
+// ClearVerdictStatus clears the value of the "verdict_status" field.
+func (u *MessageUpsertOne) ClearVerdictStatus() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearVerdictStatus()
+	})
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (u *MessageUpsertOne) SetVerdictSource(v string) *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetVerdictSource(v)
+	})
+}
+
+// UpdateVerdictSource sets the "verdict_source" field to the value that was provided on create.
+func (u *MessageUpsertOne) UpdateVerdictSource() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateVerdictSource()
+	})
+}
+
+// ClearVerdictSource clears the value of the "verdict_source" field.
+func (u *MessageUpsertOne) ClearVerdictSource() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearVerdictSource()
+	})
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (u *MessageUpsertOne) SetVerdictAt(v time.Time) *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetVerdictAt(v)
+	})
+}
+
+// UpdateVerdictAt sets the "verdict_at" field to the value that was provided on create.
+func (u *MessageUpsertOne) UpdateVerdictAt() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateVerdictAt()
+	})
+}
+
+// ClearVerdictAt clears the value of the "verdict_at" field.
+func (u *MessageUpsertOne) ClearVerdictAt() *MessageUpsertOne {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearVerdictAt()
+	})
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (u *MessageUpsertOne) SetIsBlocked(v bool) *MessageUpsertOne {
 	return u.Update(func(s *MessageUpsert) {
@@ -926,9 +1174,6 @@ func (u *MessageUpsertBulk) UpdateNewValues() *MessageUpsertBulk {
 			if _, exists := b.mutation.ID(); exists {
 				s.SetIgnore(message.FieldID)
 			}
-			if _, exists := b.mutation.Body(); exists {
-				s.SetIgnore(message.FieldBody)
-			}
 			if _, exists := b.mutation.IsService(); exists {
 				s.SetIgnore(message.FieldIsService)
 			}
@@ -1058,6 +1303,41 @@ func (u *MessageUpsertBulk) UpdateIsVisibleForManager() *MessageUpsertBulk {
 	})
 }
 
+// SetBody sets the "body" field.
+func (u *MessageUpsertBulk) SetBody(v string) *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetBody(v)
+	})
+}
+
+// UpdateBody sets the "body" field to the value that was provided on create.
+func (u *MessageUpsertBulk) UpdateBody() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateBody()
+	})
+}
+
+// SetSealedBody sets the "sealed_body" field.
+func (u *MessageUpsertBulk) SetSealedBody(v []byte) *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetSealedBody(v)
+	})
+}
+
+// UpdateSealedBody sets the "sealed_body" field to the value that was provided on create.
+func (u *MessageUpsertBulk) UpdateSealedBody() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateSealedBody()
+	})
+}
+
+// ClearSealedBody clears the value of the "sealed_body" field.
+func (u *MessageUpsertBulk) ClearSealedBody() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearSealedBody()
+	})
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (u *MessageUpsertBulk) SetCheckedAt(v time.Time) *MessageUpsertBulk {
 	return u.Update(func(s *MessageUpsert) {
@@ -1079,6 +1359,69 @@ func (u *MessageUpsertBulk) ClearCheckedAt() *MessageUpsertBulk {
 	})
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (u *MessageUpsertBulk) SetVerdictStatus(v message.VerdictStatus) *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetVerdictStatus(v)
+	})
+}
+
+// UpdateVerdictStatus sets the "verdict_status" field to the value that was provided on create.
+func (u *MessageUpsertBulk) UpdateVerdictStatus() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateVerdictStatus()
+	})
+}
+
+// ClearVerdictStatus clears the value of the "verdict_status" field.
+func (u *MessageUpsertBulk) ClearVerdictStatus() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearVerdictStatus()
+	})
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (u *MessageUpsertBulk) SetVerdictSource(v string) *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetVerdictSource(v)
+	})
+}
+
+// UpdateVerdictSource sets the "verdict_source" field to the value that was provided on create.
+func (u *MessageUpsertBulk) UpdateVerdictSource() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateVerdictSource()
+	})
+}
+
+// ClearVerdictSource clears the value of the "verdict_source" field.
+func (u *MessageUpsertBulk) ClearVerdictSource() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearVerdictSource()
+	})
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (u *MessageUpsertBulk) SetVerdictAt(v time.Time) *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.SetVerdictAt(v)
+	})
+}
+
+// UpdateVerdictAt sets the "verdict_at" field to the value that was provided on create.
+func (u *MessageUpsertBulk) UpdateVerdictAt() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.UpdateVerdictAt()
+	})
+}
+
+// ClearVerdictAt clears the value of the "verdict_at" field.
+func (u *MessageUpsertBulk) ClearVerdictAt() *MessageUpsertBulk {
+	return u.Update(func(s *MessageUpsert) {
+		s.ClearVerdictAt()
+	})
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (u *MessageUpsertBulk) SetIsBlocked(v bool) *MessageUpsertBulk {
 	return u.Update(func(s *MessageUpsert) {
@@ -97,6 +97,24 @@ func (mu *MessageUpdate) SetNillableIsVisibleForManager(b *bool) *MessageUpdate
 	return mu
 }
 
+// SetBody sets the "body" field.
+func (mu *MessageUpdate) SetBody(s string) *MessageUpdate {
+	mu.mutation.SetBody(s)
+	return mu
+}
+
+// SetSealedBody sets the "sealed_body" field.
+func (mu *MessageUpdate) SetSealedBody(b []byte) *MessageUpdate {
+	mu.mutation.SetSealedBody(b)
+	return mu
+}
+
+// ClearSealedBody clears the value of the "sealed_body" field.
+func (mu *MessageUpdate) ClearSealedBody() *MessageUpdate {
+	mu.mutation.ClearSealedBody()
+	return mu
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (mu *MessageUpdate) SetCheckedAt(t time.Time) *MessageUpdate {
 	mu.mutation.SetCheckedAt(t)
@@ -117,6 +135,66 @@ func (mu *MessageUpdate) ClearCheckedAt() *MessageUpdate {
 	return mu
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (mu *MessageUpdate) SetVerdictStatus(ms message.VerdictStatus) *MessageUpdate {
+	mu.mutation.SetVerdictStatus(ms)
+	return mu
+}
+
+// SetNillableVerdictStatus sets the "verdict_status" field if the given value is not nil.
+func (mu *MessageUpdate) SetNillableVerdictStatus(ms *message.VerdictStatus) *MessageUpdate {
+	if ms != nil {
+		mu.SetVerdictStatus(*ms)
+	}
+	return mu
+}
+
+// ClearVerdictStatus clears the value of the "verdict_status" field.
+func (mu *MessageUpdate) ClearVerdictStatus() *MessageUpdate {
+	mu.mutation.ClearVerdictStatus()
+	return mu
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (mu *MessageUpdate) SetVerdictSource(s string) *MessageUpdate {
+	mu.mutation.SetVerdictSource(s)
+	return mu
+}
+
+// SetNillableVerdictSource sets the "verdict_source" field if the given value is not nil.
+func (mu *MessageUpdate) SetNillableVerdictSource(s *string) *MessageUpdate {
+	if s != nil {
+		mu.SetVerdictSource(*s)
+	}
+	return mu
+}
+
+// ClearVerdictSource clears the value of the "verdict_source" field.
+func (mu *MessageUpdate) ClearVerdictSource() *MessageUpdate {
+	mu.mutation.ClearVerdictSource()
+	return mu
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (mu *MessageUpdate) SetVerdictAt(t time.Time) *MessageUpdate {
+	mu.mutation.SetVerdictAt(t)
+	return mu
+}
+
+// SetNillableVerdictAt sets the "verdict_at" field if the given value is not nil.
+func (mu *MessageUpdate) SetNillableVerdictAt(t *time.Time) *MessageUpdate {
+	if t != nil {
+		mu.SetVerdictAt(*t)
+	}
+	return mu
+}
+
+// ClearVerdictAt clears the value of the "verdict_at" field.
+func (mu *MessageUpdate) ClearVerdictAt() *MessageUpdate {
+	mu.mutation.ClearVerdictAt()
+	return mu
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (mu *MessageUpdate) SetIsBlocked(b bool) *MessageUpdate {
 	mu.mutation.SetIsBlocked(b)
@@ -207,6 +285,16 @@ func (mu *MessageUpdate) check() error {
 			return &ValidationError{Name: "problem_id", err: fmt.Errorf(`store: validator failed for field "Message.problem_id": %w`, err)}
 		}
 	}
+	if v, ok := mu.mutation.Body(); ok {
+		if err := message.BodyValidator(v); err != nil {
+			return &ValidationError{Name: "body", err: fmt.Errorf(`store: validator failed for field "Message.body": %w`, err)}
+		}
+	}
+	if v, ok := mu.mutation.VerdictStatus(); ok {
+		if err := message.VerdictStatusValidator(v); err != nil {
+			return &ValidationError{Name: "verdict_status", err: fmt.Errorf(`store: validator failed for field "Message.verdict_status": %w`, err)}
+		}
+	}
 	if _, ok := mu.mutation.ProblemID(); mu.mutation.ProblemCleared() && !ok {
 		return errors.New(`store: clearing a required unique edge "Message.problem"`)
 	}
@@ -243,12 +331,39 @@ func (mu *MessageUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := mu.mutation.IsVisibleForManager(); ok {
 		_spec.SetField(message.FieldIsVisibleForManager, field.TypeBool, value)
 	}
+	if value, ok := mu.mutation.Body(); ok {
+		_spec.SetField(message.FieldBody, field.TypeString, value)
+	}
+	if value, ok := mu.mutation.SealedBody(); ok {
+		_spec.SetField(message.FieldSealedBody, field.TypeBytes, value)
+	}
+	if mu.mutation.SealedBodyCleared() {
+		_spec.ClearField(message.FieldSealedBody, field.TypeBytes)
+	}
 	if value, ok := mu.mutation.CheckedAt(); ok {
 		_spec.SetField(message.FieldCheckedAt, field.TypeTime, value)
 	}
 	if mu.mutation.CheckedAtCleared() {
 		_spec.ClearField(message.FieldCheckedAt, field.TypeTime)
 	}
+	if value, ok := mu.mutation.VerdictStatus(); ok {
+		_spec.SetField(message.FieldVerdictStatus, field.TypeEnum, value)
+	}
+	if mu.mutation.VerdictStatusCleared() {
+		_spec.ClearField(message.FieldVerdictStatus, field.TypeEnum)
+	}
+	if value, ok := mu.mutation.VerdictSource(); ok {
+		_spec.SetField(message.FieldVerdictSource, field.TypeString, value)
+	}
+	if mu.mutation.VerdictSourceCleared() {
+		_spec.ClearField(message.FieldVerdictSource, field.TypeString)
+	}
+	if value, ok := mu.mutation.VerdictAt(); ok {
+		_spec.SetField(message.FieldVerdictAt, field.TypeTime, value)
+	}
+	if mu.mutation.VerdictAtCleared() {
+		_spec.ClearField(message.FieldVerdictAt, field.TypeTime)
+	}
 	if value, ok := mu.mutation.IsBlocked(); ok {
 		_spec.SetField(message.FieldIsBlocked, field.TypeBool, value)
 	}
@@ -396,6 +511,24 @@ func (muo *MessageUpdateOne) SetNillableIsVisibleForManager(b *bool) *MessageUpd
 	return muo
 }
 
+// SetBody sets the "body" field.
+func (muo *MessageUpdateOne) SetBody(s string) *MessageUpdateOne {
+	muo.mutation.SetBody(s)
+	return muo
+}
+
+// SetSealedBody sets the "sealed_body" field.
+func (muo *MessageUpdateOne) SetSealedBody(b []byte) *MessageUpdateOne {
+	muo.mutation.SetSealedBody(b)
+	return muo
+}
+
+// ClearSealedBody clears the value of the "sealed_body" field.
+func (muo *MessageUpdateOne) ClearSealedBody() *MessageUpdateOne {
+	muo.mutation.ClearSealedBody()
+	return muo
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (muo *MessageUpdateOne) SetCheckedAt(t time.Time) *MessageUpdateOne {
 	muo.mutation.SetCheckedAt(t)
@@ -416,6 +549,66 @@ func (muo *MessageUpdateOne) ClearCheckedAt() *MessageUpdateOne {
 	return muo
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (muo *MessageUpdateOne) SetVerdictStatus(ms message.VerdictStatus) *MessageUpdateOne {
+	muo.mutation.SetVerdictStatus(ms)
+	return muo
+}
+
+// SetNillableVerdictStatus sets the "verdict_status" field if the given value is not nil.
+func (muo *MessageUpdateOne) SetNillableVerdictStatus(ms *message.VerdictStatus) *MessageUpdateOne {
+	if ms != nil {
+		muo.SetVerdictStatus(*ms)
+	}
+	return muo
+}
+
+// ClearVerdictStatus clears the value of the "verdict_status" field.
+func (muo *MessageUpdateOne) ClearVerdictStatus() *MessageUpdateOne {
+	muo.mutation.ClearVerdictStatus()
+	return muo
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (muo *MessageUpdateOne) SetVerdictSource(s string) *MessageUpdateOne {
+	muo.mutation.SetVerdictSource(s)
+	return muo
+}
+
+// SetNillableVerdictSource sets the "verdict_source" field if the given value is not nil.
+func (muo *MessageUpdateOne) SetNillableVerdictSource(s *string) *MessageUpdateOne {
+	if s != nil {
+		muo.SetVerdictSource(*s)
+	}
+	return muo
+}
+
+// ClearVerdictSource clears the value of the "verdict_source" field.
+func (muo *MessageUpdateOne) ClearVerdictSource() *MessageUpdateOne {
+	muo.mutation.ClearVerdictSource()
+	return muo
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (muo *MessageUpdateOne) SetVerdictAt(t time.Time) *MessageUpdateOne {
+	muo.mutation.SetVerdictAt(t)
+	return muo
+}
+
+// SetNillableVerdictAt sets the "verdict_at" field if the given value is not nil.
+func (muo *MessageUpdateOne) SetNillableVerdictAt(t *time.Time) *MessageUpdateOne {
+	if t != nil {
+		muo.SetVerdictAt(*t)
+	}
+	return muo
+}
+
+// ClearVerdictAt clears the value of the "verdict_at" field.
+func (muo *MessageUpdateOne) ClearVerdictAt() *MessageUpdateOne {
+	muo.mutation.ClearVerdictAt()
+	return muo
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (muo *MessageUpdateOne) SetIsBlocked(b bool) *MessageUpdateOne {
 	muo.mutation.SetIsBlocked(b)
@@ -519,6 +712,16 @@ func (muo *MessageUpdateOne) check() error {
 			return &ValidationError{Name: "problem_id", err: fmt.Errorf(`store: validator failed for field "Message.problem_id": %w`, err)}
 		}
 	}
+	if v, ok := muo.mutation.Body(); ok {
+		if err := message.BodyValidator(v); err != nil {
+			return &ValidationError{Name: "body", err: fmt.Errorf(`store: validator failed for field "Message.body": %w`, err)}
+		}
+	}
+	if v, ok := muo.mutation.VerdictStatus(); ok {
+		if err := message.VerdictStatusValidator(v); err != nil {
+			return &ValidationError{Name: "verdict_status", err: fmt.Errorf(`store: validator failed for field "Message.verdict_status": %w`, err)}
+		}
+	}
 	if _, ok := muo.mutation.ProblemID(); muo.mutation.ProblemCleared() && !ok {
 		return errors.New(`store: clearing a required unique edge "Message.problem"`)
 	}
@@ -572,12 +775,39 @@ func (muo *MessageUpdateOne) sqlSave(ctx context.Context) (_node *Message, err e
 	if value, ok := muo.mutation.IsVisibleForManager(); ok {
 		_spec.SetField(message.FieldIsVisibleForManager, field.TypeBool, value)
 	}
+	if value, ok := muo.mutation.Body(); ok {
+		_spec.SetField(message.FieldBody, field.TypeString, value)
+	}
+	if value, ok := muo.mutation.SealedBody(); ok {
+		_spec.SetField(message.FieldSealedBody, field.TypeBytes, value)
+	}
+	if muo.mutation.SealedBodyCleared() {
+		_spec.ClearField(message.FieldSealedBody, field.TypeBytes)
+	}
 	if value, ok := muo.mutation.CheckedAt(); ok {
 		_spec.SetField(message.FieldCheckedAt, field.TypeTime, value)
 	}
 	if muo.mutation.CheckedAtCleared() {
 		_spec.ClearField(message.FieldCheckedAt, field.TypeTime)
 	}
+	if value, ok := muo.mutation.VerdictStatus(); ok {
+		_spec.SetField(message.FieldVerdictStatus, field.TypeEnum, value)
+	}
+	if muo.mutation.VerdictStatusCleared() {
+		_spec.ClearField(message.FieldVerdictStatus, field.TypeEnum)
+	}
+	if value, ok := muo.mutation.VerdictSource(); ok {
+		_spec.SetField(message.FieldVerdictSource, field.TypeString, value)
+	}
+	if muo.mutation.VerdictSourceCleared() {
+		_spec.ClearField(message.FieldVerdictSource, field.TypeString)
+	}
+	if value, ok := muo.mutation.VerdictAt(); ok {
+		_spec.SetField(message.FieldVerdictAt, field.TypeTime, value)
+	}
+	if muo.mutation.VerdictAtCleared() {
+		_spec.ClearField(message.FieldVerdictAt, field.TypeTime)
+	}
 	if value, ok := muo.mutation.IsBlocked(); ok {
 		_spec.SetField(message.FieldIsBlocked, field.TypeBool, value)
 	}
@@ -21,6 +21,27 @@ var (
 		Columns:    ChatsColumns,
 		PrimaryKey: []*schema.Column{ChatsColumns[0]},
 	}
+	// CompletedJobsColumns holds the columns for the "completed_jobs" table.
+	CompletedJobsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "name", Type: field.TypeString, Size: 2147483647},
+		{Name: "dedup_key", Type: field.TypeString, Unique: true, Size: 2147483647},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// CompletedJobsTable holds the schema information for the "completed_jobs" table.
+	CompletedJobsTable = &schema.Table{
+		Name:       "completed_jobs",
+		Columns:    CompletedJobsColumns,
+		PrimaryKey: []*schema.Column{CompletedJobsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "completedjob_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{CompletedJobsColumns[3]},
+			},
+		},
+	}
 	// FailedJobsColumns holds the columns for the "failed_jobs" table.
 	FailedJobsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID, Unique: true},
@@ -40,6 +61,7 @@ var (
 		{Name: "id", Type: field.TypeUUID, Unique: true},
 		{Name: "name", Type: field.TypeString, Size: 2147483647},
 		{Name: "payload", Type: field.TypeString, Size: 2147483647},
+		{Name: "dedup_key", Type: field.TypeString, Unique: true, Nullable: true, Size: 2147483647},
 		{Name: "attempts", Type: field.TypeInt, Default: 0},
 		{Name: "available_at", Type: field.TypeTime},
 		{Name: "reserved_until", Type: field.TypeTime},
@@ -51,6 +73,19 @@ var (
 		Columns:    JobsColumns,
 		PrimaryKey: []*schema.Column{JobsColumns[0]},
 	}
+	// JobSchedulesColumns holds the columns for the "job_schedules" table.
+	JobSchedulesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "name", Type: field.TypeString, Unique: true, Size: 2147483647},
+		{Name: "next_run_at", Type: field.TypeTime},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// JobSchedulesTable holds the schema information for the "job_schedules" table.
+	JobSchedulesTable = &schema.Table{
+		Name:       "job_schedules",
+		Columns:    JobSchedulesColumns,
+		PrimaryKey: []*schema.Column{JobSchedulesColumns[0]},
+	}
 	// MessagesColumns holds the columns for the "messages" table.
 	MessagesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID, Unique: true},
@@ -59,7 +94,11 @@ var (
 		{Name: "is_visible_for_client", Type: field.TypeBool, Default: false},
 		{Name: "is_visible_for_manager", Type: field.TypeBool, Default: false},
 		{Name: "body", Type: field.TypeString, Size: 3000},
+		{Name: "sealed_body", Type: field.TypeBytes, Nullable: true},
 		{Name: "checked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "verdict_status", Type: field.TypeEnum, Nullable: true, Enums: []string{"ok", "suspicious"}},
+		{Name: "verdict_source", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "verdict_at", Type: field.TypeTime, Nullable: true},
 		{Name: "is_blocked", Type: field.TypeBool, Default: false},
 		{Name: "is_service", Type: field.TypeBool, Default: false},
 		{Name: "created_at", Type: field.TypeTime},
@@ -74,13 +113,13 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "messages_chats_messages",
-				Columns:    []*schema.Column{MessagesColumns[10]},
+				Columns:    []*schema.Column{MessagesColumns[14]},
 				RefColumns: []*schema.Column{ChatsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "messages_problems_messages",
-				Columns:    []*schema.Column{MessagesColumns[11]},
+				Columns:    []*schema.Column{MessagesColumns[15]},
 				RefColumns: []*schema.Column{ProblemsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -89,12 +128,12 @@ var (
 			{
 				Name:    "message_chat_id",
 				Unique:  false,
-				Columns: []*schema.Column{MessagesColumns[10]},
+				Columns: []*schema.Column{MessagesColumns[14]},
 			},
 			{
 				Name:    "message_created_at_is_visible_for_client",
 				Unique:  false,
-				Columns: []*schema.Column{MessagesColumns[9], MessagesColumns[3]},
+				Columns: []*schema.Column{MessagesColumns[13], MessagesColumns[3]},
 			},
 		},
 	}
@@ -133,8 +172,10 @@ var (
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		ChatsTable,
+		CompletedJobsTable,
 		FailedJobsTable,
 		JobsTable,
+		JobSchedulesTable,
 		MessagesTable,
 		ProblemsTable,
 	}
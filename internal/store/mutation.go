@@ -12,8 +12,10 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/gerladeno/chat-service/internal/store/chat"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
 	"github.com/gerladeno/chat-service/internal/store/failedjob"
 	"github.com/gerladeno/chat-service/internal/store/job"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
 	"github.com/gerladeno/chat-service/internal/store/message"
 	"github.com/gerladeno/chat-service/internal/store/predicate"
 	"github.com/gerladeno/chat-service/internal/store/problem"
@@ -29,11 +31,13 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeChat      = "Chat"
-	TypeFailedJob = "FailedJob"
-	TypeJob       = "Job"
-	TypeMessage   = "Message"
-	TypeProblem   = "Problem"
+	TypeChat         = "Chat"
+	TypeCompletedJob = "CompletedJob"
+	TypeFailedJob    = "FailedJob"
+	TypeJob          = "Job"
+	TypeJobSchedule  = "JobSchedule"
+	TypeMessage      = "Message"
+	TypeProblem      = "Problem"
 )
 
 // ChatMutation represents an operation that mutates the Chat nodes in the graph.
@@ -598,33 +602,33 @@ func (m *ChatMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Chat edge %s", name)
 }
 
-// FailedJobMutation represents an operation that mutates the FailedJob nodes in the graph.
-type FailedJobMutation struct {
+// CompletedJobMutation represents an operation that mutates the CompletedJob nodes in the graph.
+type CompletedJobMutation struct {
 	config
 	op            Op
 	typ           string
-	id            *types.FailedJobID
+	id            *types.CompletedJobID
 	name          *string
-	payload       *string
-	reason        *string
+	dedup_key     *string
+	expires_at    *time.Time
 	created_at    *time.Time
 	clearedFields map[string]struct{}
 	done          bool
-	oldValue      func(context.Context) (*FailedJob, error)
-	predicates    []predicate.FailedJob
+	oldValue      func(context.Context) (*CompletedJob, error)
+	predicates    []predicate.CompletedJob
 }
 
-var _ ent.Mutation = (*FailedJobMutation)(nil)
+var _ ent.Mutation = (*CompletedJobMutation)(nil)
 
-// failedjobOption allows management of the mutation configuration using functional options.
-type failedjobOption func(*FailedJobMutation)
+// completedjobOption allows management of the mutation configuration using functional options.
+type completedjobOption func(*CompletedJobMutation)
 
-// newFailedJobMutation creates new mutation for the FailedJob entity.
-func newFailedJobMutation(c config, op Op, opts ...failedjobOption) *FailedJobMutation {
-	m := &FailedJobMutation{
+// newCompletedJobMutation creates new mutation for the CompletedJob entity.
+func newCompletedJobMutation(c config, op Op, opts ...completedjobOption) *CompletedJobMutation {
+	m := &CompletedJobMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeFailedJob,
+		typ:           TypeCompletedJob,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -633,20 +637,20 @@ func newFailedJobMutation(c config, op Op, opts ...failedjobOption) *FailedJobMu
 	return m
 }
 
-// withFailedJobID sets the ID field of the mutation.
-func withFailedJobID(id types.FailedJobID) failedjobOption {
-	return func(m *FailedJobMutation) {
+// withCompletedJobID sets the ID field of the mutation.
+func withCompletedJobID(id types.CompletedJobID) completedjobOption {
+	return func(m *CompletedJobMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *FailedJob
+			value *CompletedJob
 		)
-		m.oldValue = func(ctx context.Context) (*FailedJob, error) {
+		m.oldValue = func(ctx context.Context) (*CompletedJob, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().FailedJob.Get(ctx, id)
+					value, err = m.Client().CompletedJob.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -655,10 +659,10 @@ func withFailedJobID(id types.FailedJobID) failedjobOption {
 	}
 }
 
-// withFailedJob sets the old FailedJob of the mutation.
-func withFailedJob(node *FailedJob) failedjobOption {
-	return func(m *FailedJobMutation) {
-		m.oldValue = func(context.Context) (*FailedJob, error) {
+// withCompletedJob sets the old CompletedJob of the mutation.
+func withCompletedJob(node *CompletedJob) completedjobOption {
+	return func(m *CompletedJobMutation) {
+		m.oldValue = func(context.Context) (*CompletedJob, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -667,7 +671,7 @@ func withFailedJob(node *FailedJob) failedjobOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m FailedJobMutation) Client() *Client {
+func (m CompletedJobMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -675,7 +679,7 @@ func (m FailedJobMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m FailedJobMutation) Tx() (*Tx, error) {
+func (m CompletedJobMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("store: mutation is not running in a transaction")
 	}
@@ -685,14 +689,14 @@ func (m FailedJobMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of FailedJob entities.
-func (m *FailedJobMutation) SetID(id types.FailedJobID) {
+// operation is only accepted on creation of CompletedJob entities.
+func (m *CompletedJobMutation) SetID(id types.CompletedJobID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *FailedJobMutation) ID() (id types.FailedJobID, exists bool) {
+func (m *CompletedJobMutation) ID() (id types.CompletedJobID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -703,28 +707,28 @@ func (m *FailedJobMutation) ID() (id types.FailedJobID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *FailedJobMutation) IDs(ctx context.Context) ([]types.FailedJobID, error) {
+func (m *CompletedJobMutation) IDs(ctx context.Context) ([]types.CompletedJobID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []types.FailedJobID{id}, nil
+			return []types.CompletedJobID{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().FailedJob.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().CompletedJob.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
 // SetName sets the "name" field.
-func (m *FailedJobMutation) SetName(s string) {
+func (m *CompletedJobMutation) SetName(s string) {
 	m.name = &s
 }
 
 // Name returns the value of the "name" field in the mutation.
-func (m *FailedJobMutation) Name() (r string, exists bool) {
+func (m *CompletedJobMutation) Name() (r string, exists bool) {
 	v := m.name
 	if v == nil {
 		return
@@ -732,10 +736,10 @@ func (m *FailedJobMutation) Name() (r string, exists bool) {
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the FailedJob entity.
-// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the CompletedJob entity.
+// If the CompletedJob object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FailedJobMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *CompletedJobMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
@@ -750,89 +754,89 @@ func (m *FailedJobMutation) OldName(ctx context.Context) (v string, err error) {
 }
 
 // ResetName resets all changes to the "name" field.
-func (m *FailedJobMutation) ResetName() {
+func (m *CompletedJobMutation) ResetName() {
 	m.name = nil
 }
 
-// SetPayload sets the "payload" field.
-func (m *FailedJobMutation) SetPayload(s string) {
-	m.payload = &s
+// SetDedupKey sets the "dedup_key" field.
+func (m *CompletedJobMutation) SetDedupKey(s string) {
+	m.dedup_key = &s
 }
 
-// Payload returns the value of the "payload" field in the mutation.
-func (m *FailedJobMutation) Payload() (r string, exists bool) {
-	v := m.payload
+// DedupKey returns the value of the "dedup_key" field in the mutation.
+func (m *CompletedJobMutation) DedupKey() (r string, exists bool) {
+	v := m.dedup_key
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPayload returns the old "payload" field's value of the FailedJob entity.
-// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
+// OldDedupKey returns the old "dedup_key" field's value of the CompletedJob entity.
+// If the CompletedJob object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FailedJobMutation) OldPayload(ctx context.Context) (v string, err error) {
+func (m *CompletedJobMutation) OldDedupKey(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
+		return v, errors.New("OldDedupKey is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPayload requires an ID field in the mutation")
+		return v, errors.New("OldDedupKey requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+		return v, fmt.Errorf("querying old value for OldDedupKey: %w", err)
 	}
-	return oldValue.Payload, nil
+	return oldValue.DedupKey, nil
 }
 
-// ResetPayload resets all changes to the "payload" field.
-func (m *FailedJobMutation) ResetPayload() {
-	m.payload = nil
+// ResetDedupKey resets all changes to the "dedup_key" field.
+func (m *CompletedJobMutation) ResetDedupKey() {
+	m.dedup_key = nil
 }
 
-// SetReason sets the "reason" field.
-func (m *FailedJobMutation) SetReason(s string) {
-	m.reason = &s
+// SetExpiresAt sets the "expires_at" field.
+func (m *CompletedJobMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// Reason returns the value of the "reason" field in the mutation.
-func (m *FailedJobMutation) Reason() (r string, exists bool) {
-	v := m.reason
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *CompletedJobMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldReason returns the old "reason" field's value of the FailedJob entity.
-// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
+// OldExpiresAt returns the old "expires_at" field's value of the CompletedJob entity.
+// If the CompletedJob object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FailedJobMutation) OldReason(ctx context.Context) (v string, err error) {
+func (m *CompletedJobMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldReason is only allowed on UpdateOne operations")
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldReason requires an ID field in the mutation")
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldReason: %w", err)
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
 	}
-	return oldValue.Reason, nil
+	return oldValue.ExpiresAt, nil
 }
 
-// ResetReason resets all changes to the "reason" field.
-func (m *FailedJobMutation) ResetReason() {
-	m.reason = nil
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *CompletedJobMutation) ResetExpiresAt() {
+	m.expires_at = nil
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *FailedJobMutation) SetCreatedAt(t time.Time) {
+func (m *CompletedJobMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *FailedJobMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *CompletedJobMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -840,10 +844,10 @@ func (m *FailedJobMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the FailedJob entity.
-// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the CompletedJob entity.
+// If the CompletedJob object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FailedJobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *CompletedJobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -858,19 +862,19 @@ func (m *FailedJobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *FailedJobMutation) ResetCreatedAt() {
+func (m *CompletedJobMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// Where appends a list predicates to the FailedJobMutation builder.
-func (m *FailedJobMutation) Where(ps ...predicate.FailedJob) {
+// Where appends a list predicates to the CompletedJobMutation builder.
+func (m *CompletedJobMutation) Where(ps ...predicate.CompletedJob) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the FailedJobMutation builder. Using this method,
+// WhereP appends storage-level predicates to the CompletedJobMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *FailedJobMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.FailedJob, len(ps))
+func (m *CompletedJobMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.CompletedJob, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -878,36 +882,36 @@ func (m *FailedJobMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *FailedJobMutation) Op() Op {
+func (m *CompletedJobMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *FailedJobMutation) SetOp(op Op) {
+func (m *CompletedJobMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (FailedJob).
-func (m *FailedJobMutation) Type() string {
+// Type returns the node type of this mutation (CompletedJob).
+func (m *CompletedJobMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *FailedJobMutation) Fields() []string {
+func (m *CompletedJobMutation) Fields() []string {
 	fields := make([]string, 0, 4)
 	if m.name != nil {
-		fields = append(fields, failedjob.FieldName)
+		fields = append(fields, completedjob.FieldName)
 	}
-	if m.payload != nil {
-		fields = append(fields, failedjob.FieldPayload)
+	if m.dedup_key != nil {
+		fields = append(fields, completedjob.FieldDedupKey)
 	}
-	if m.reason != nil {
-		fields = append(fields, failedjob.FieldReason)
+	if m.expires_at != nil {
+		fields = append(fields, completedjob.FieldExpiresAt)
 	}
 	if m.created_at != nil {
-		fields = append(fields, failedjob.FieldCreatedAt)
+		fields = append(fields, completedjob.FieldCreatedAt)
 	}
 	return fields
 }
@@ -915,15 +919,15 @@ func (m *FailedJobMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *FailedJobMutation) Field(name string) (ent.Value, bool) {
+func (m *CompletedJobMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case failedjob.FieldName:
+	case completedjob.FieldName:
 		return m.Name()
-	case failedjob.FieldPayload:
-		return m.Payload()
-	case failedjob.FieldReason:
-		return m.Reason()
-	case failedjob.FieldCreatedAt:
+	case completedjob.FieldDedupKey:
+		return m.DedupKey()
+	case completedjob.FieldExpiresAt:
+		return m.ExpiresAt()
+	case completedjob.FieldCreatedAt:
 		return m.CreatedAt()
 	}
 	return nil, false
@@ -932,47 +936,47 @@ func (m *FailedJobMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *FailedJobMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *CompletedJobMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case failedjob.FieldName:
+	case completedjob.FieldName:
 		return m.OldName(ctx)
-	case failedjob.FieldPayload:
-		return m.OldPayload(ctx)
-	case failedjob.FieldReason:
-		return m.OldReason(ctx)
-	case failedjob.FieldCreatedAt:
+	case completedjob.FieldDedupKey:
+		return m.OldDedupKey(ctx)
+	case completedjob.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case completedjob.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown FailedJob field %s", name)
+	return nil, fmt.Errorf("unknown CompletedJob field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *FailedJobMutation) SetField(name string, value ent.Value) error {
+func (m *CompletedJobMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case failedjob.FieldName:
+	case completedjob.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetName(v)
 		return nil
-	case failedjob.FieldPayload:
+	case completedjob.FieldDedupKey:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPayload(v)
+		m.SetDedupKey(v)
 		return nil
-	case failedjob.FieldReason:
-		v, ok := value.(string)
+	case completedjob.FieldExpiresAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetReason(v)
+		m.SetExpiresAt(v)
 		return nil
-	case failedjob.FieldCreatedAt:
+	case completedjob.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -980,148 +984,145 @@ func (m *FailedJobMutation) SetField(name string, value ent.Value) error {
 		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown FailedJob field %s", name)
+	return fmt.Errorf("unknown CompletedJob field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *FailedJobMutation) AddedFields() []string {
+func (m *CompletedJobMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *FailedJobMutation) AddedField(name string) (ent.Value, bool) {
+func (m *CompletedJobMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *FailedJobMutation) AddField(name string, value ent.Value) error {
+func (m *CompletedJobMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown FailedJob numeric field %s", name)
+	return fmt.Errorf("unknown CompletedJob numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *FailedJobMutation) ClearedFields() []string {
+func (m *CompletedJobMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *FailedJobMutation) FieldCleared(name string) bool {
+func (m *CompletedJobMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *FailedJobMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown FailedJob nullable field %s", name)
+func (m *CompletedJobMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown CompletedJob nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *FailedJobMutation) ResetField(name string) error {
+func (m *CompletedJobMutation) ResetField(name string) error {
 	switch name {
-	case failedjob.FieldName:
+	case completedjob.FieldName:
 		m.ResetName()
 		return nil
-	case failedjob.FieldPayload:
-		m.ResetPayload()
+	case completedjob.FieldDedupKey:
+		m.ResetDedupKey()
 		return nil
-	case failedjob.FieldReason:
-		m.ResetReason()
+	case completedjob.FieldExpiresAt:
+		m.ResetExpiresAt()
 		return nil
-	case failedjob.FieldCreatedAt:
+	case completedjob.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown FailedJob field %s", name)
+	return fmt.Errorf("unknown CompletedJob field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *FailedJobMutation) AddedEdges() []string {
+func (m *CompletedJobMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *FailedJobMutation) AddedIDs(name string) []ent.Value {
+func (m *CompletedJobMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *FailedJobMutation) RemovedEdges() []string {
+func (m *CompletedJobMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *FailedJobMutation) RemovedIDs(name string) []ent.Value {
+func (m *CompletedJobMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *FailedJobMutation) ClearedEdges() []string {
+func (m *CompletedJobMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *FailedJobMutation) EdgeCleared(name string) bool {
+func (m *CompletedJobMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *FailedJobMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown FailedJob unique edge %s", name)
+func (m *CompletedJobMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown CompletedJob unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *FailedJobMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown FailedJob edge %s", name)
+func (m *CompletedJobMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown CompletedJob edge %s", name)
 }
 
-// JobMutation represents an operation that mutates the Job nodes in the graph.
-type JobMutation struct {
+// FailedJobMutation represents an operation that mutates the FailedJob nodes in the graph.
+type FailedJobMutation struct {
 	config
-	op             Op
-	typ            string
-	id             *types.JobID
-	name           *string
-	payload        *string
-	attempts       *int
-	addattempts    *int
-	available_at   *time.Time
-	reserved_until *time.Time
-	created_at     *time.Time
-	clearedFields  map[string]struct{}
-	done           bool
-	oldValue       func(context.Context) (*Job, error)
-	predicates     []predicate.Job
+	op            Op
+	typ           string
+	id            *types.FailedJobID
+	name          *string
+	payload       *string
+	reason        *string
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*FailedJob, error)
+	predicates    []predicate.FailedJob
 }
 
-var _ ent.Mutation = (*JobMutation)(nil)
+var _ ent.Mutation = (*FailedJobMutation)(nil)
 
-// jobOption allows management of the mutation configuration using functional options.
-type jobOption func(*JobMutation)
+// failedjobOption allows management of the mutation configuration using functional options.
+type failedjobOption func(*FailedJobMutation)
 
-// newJobMutation creates new mutation for the Job entity.
-func newJobMutation(c config, op Op, opts ...jobOption) *JobMutation {
-	m := &JobMutation{
+// newFailedJobMutation creates new mutation for the FailedJob entity.
+func newFailedJobMutation(c config, op Op, opts ...failedjobOption) *FailedJobMutation {
+	m := &FailedJobMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeJob,
+		typ:           TypeFailedJob,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1130,20 +1131,20 @@ func newJobMutation(c config, op Op, opts ...jobOption) *JobMutation {
 	return m
 }
 
-// withJobID sets the ID field of the mutation.
-func withJobID(id types.JobID) jobOption {
-	return func(m *JobMutation) {
+// withFailedJobID sets the ID field of the mutation.
+func withFailedJobID(id types.FailedJobID) failedjobOption {
+	return func(m *FailedJobMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Job
+			value *FailedJob
 		)
-		m.oldValue = func(ctx context.Context) (*Job, error) {
+		m.oldValue = func(ctx context.Context) (*FailedJob, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Job.Get(ctx, id)
+					value, err = m.Client().FailedJob.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1152,10 +1153,10 @@ func withJobID(id types.JobID) jobOption {
 	}
 }
 
-// withJob sets the old Job of the mutation.
-func withJob(node *Job) jobOption {
-	return func(m *JobMutation) {
-		m.oldValue = func(context.Context) (*Job, error) {
+// withFailedJob sets the old FailedJob of the mutation.
+func withFailedJob(node *FailedJob) failedjobOption {
+	return func(m *FailedJobMutation) {
+		m.oldValue = func(context.Context) (*FailedJob, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1164,7 +1165,7 @@ func withJob(node *Job) jobOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m JobMutation) Client() *Client {
+func (m FailedJobMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1172,7 +1173,7 @@ func (m JobMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m JobMutation) Tx() (*Tx, error) {
+func (m FailedJobMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("store: mutation is not running in a transaction")
 	}
@@ -1182,14 +1183,14 @@ func (m JobMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Job entities.
-func (m *JobMutation) SetID(id types.JobID) {
+// operation is only accepted on creation of FailedJob entities.
+func (m *FailedJobMutation) SetID(id types.FailedJobID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *JobMutation) ID() (id types.JobID, exists bool) {
+func (m *FailedJobMutation) ID() (id types.FailedJobID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1200,28 +1201,28 @@ func (m *JobMutation) ID() (id types.JobID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *JobMutation) IDs(ctx context.Context) ([]types.JobID, error) {
+func (m *FailedJobMutation) IDs(ctx context.Context) ([]types.FailedJobID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []types.JobID{id}, nil
+			return []types.FailedJobID{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Job.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().FailedJob.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
 // SetName sets the "name" field.
-func (m *JobMutation) SetName(s string) {
+func (m *FailedJobMutation) SetName(s string) {
 	m.name = &s
 }
 
 // Name returns the value of the "name" field in the mutation.
-func (m *JobMutation) Name() (r string, exists bool) {
+func (m *FailedJobMutation) Name() (r string, exists bool) {
 	v := m.name
 	if v == nil {
 		return
@@ -1229,10 +1230,10 @@ func (m *JobMutation) Name() (r string, exists bool) {
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Job entity.
-// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the FailedJob entity.
+// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *JobMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *FailedJobMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
@@ -1247,17 +1248,17 @@ func (m *JobMutation) OldName(ctx context.Context) (v string, err error) {
 }
 
 // ResetName resets all changes to the "name" field.
-func (m *JobMutation) ResetName() {
+func (m *FailedJobMutation) ResetName() {
 	m.name = nil
 }
 
 // SetPayload sets the "payload" field.
-func (m *JobMutation) SetPayload(s string) {
+func (m *FailedJobMutation) SetPayload(s string) {
 	m.payload = &s
 }
 
 // Payload returns the value of the "payload" field in the mutation.
-func (m *JobMutation) Payload() (r string, exists bool) {
+func (m *FailedJobMutation) Payload() (r string, exists bool) {
 	v := m.payload
 	if v == nil {
 		return
@@ -1265,10 +1266,10 @@ func (m *JobMutation) Payload() (r string, exists bool) {
 	return *v, true
 }
 
-// OldPayload returns the old "payload" field's value of the Job entity.
-// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// OldPayload returns the old "payload" field's value of the FailedJob entity.
+// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *JobMutation) OldPayload(ctx context.Context) (v string, err error) {
+func (m *FailedJobMutation) OldPayload(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
 	}
@@ -1283,145 +1284,1224 @@ func (m *JobMutation) OldPayload(ctx context.Context) (v string, err error) {
 }
 
 // ResetPayload resets all changes to the "payload" field.
-func (m *JobMutation) ResetPayload() {
+func (m *FailedJobMutation) ResetPayload() {
 	m.payload = nil
 }
 
-// SetAttempts sets the "attempts" field.
-func (m *JobMutation) SetAttempts(i int) {
-	m.attempts = &i
-	m.addattempts = nil
+// SetReason sets the "reason" field.
+func (m *FailedJobMutation) SetReason(s string) {
+	m.reason = &s
 }
 
-// Attempts returns the value of the "attempts" field in the mutation.
-func (m *JobMutation) Attempts() (r int, exists bool) {
-	v := m.attempts
+// Reason returns the value of the "reason" field in the mutation.
+func (m *FailedJobMutation) Reason() (r string, exists bool) {
+	v := m.reason
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReason returns the old "reason" field's value of the FailedJob entity.
+// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FailedJobMutation) OldReason(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReason is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReason requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReason: %w", err)
+	}
+	return oldValue.Reason, nil
+}
+
+// ResetReason resets all changes to the "reason" field.
+func (m *FailedJobMutation) ResetReason() {
+	m.reason = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *FailedJobMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *FailedJobMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the FailedJob entity.
+// If the FailedJob object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *FailedJobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *FailedJobMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the FailedJobMutation builder.
+func (m *FailedJobMutation) Where(ps ...predicate.FailedJob) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the FailedJobMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *FailedJobMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.FailedJob, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *FailedJobMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *FailedJobMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (FailedJob).
+func (m *FailedJobMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *FailedJobMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.name != nil {
+		fields = append(fields, failedjob.FieldName)
+	}
+	if m.payload != nil {
+		fields = append(fields, failedjob.FieldPayload)
+	}
+	if m.reason != nil {
+		fields = append(fields, failedjob.FieldReason)
+	}
+	if m.created_at != nil {
+		fields = append(fields, failedjob.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *FailedJobMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case failedjob.FieldName:
+		return m.Name()
+	case failedjob.FieldPayload:
+		return m.Payload()
+	case failedjob.FieldReason:
+		return m.Reason()
+	case failedjob.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *FailedJobMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case failedjob.FieldName:
+		return m.OldName(ctx)
+	case failedjob.FieldPayload:
+		return m.OldPayload(ctx)
+	case failedjob.FieldReason:
+		return m.OldReason(ctx)
+	case failedjob.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown FailedJob field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *FailedJobMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case failedjob.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case failedjob.FieldPayload:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPayload(v)
+		return nil
+	case failedjob.FieldReason:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReason(v)
+		return nil
+	case failedjob.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown FailedJob field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *FailedJobMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *FailedJobMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *FailedJobMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown FailedJob numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *FailedJobMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *FailedJobMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *FailedJobMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown FailedJob nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *FailedJobMutation) ResetField(name string) error {
+	switch name {
+	case failedjob.FieldName:
+		m.ResetName()
+		return nil
+	case failedjob.FieldPayload:
+		m.ResetPayload()
+		return nil
+	case failedjob.FieldReason:
+		m.ResetReason()
+		return nil
+	case failedjob.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown FailedJob field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *FailedJobMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *FailedJobMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *FailedJobMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *FailedJobMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *FailedJobMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *FailedJobMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *FailedJobMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown FailedJob unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *FailedJobMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown FailedJob edge %s", name)
+}
+
+// JobMutation represents an operation that mutates the Job nodes in the graph.
+type JobMutation struct {
+	config
+	op             Op
+	typ            string
+	id             *types.JobID
+	name           *string
+	payload        *string
+	dedup_key      *string
+	attempts       *int
+	addattempts    *int
+	available_at   *time.Time
+	reserved_until *time.Time
+	created_at     *time.Time
+	clearedFields  map[string]struct{}
+	done           bool
+	oldValue       func(context.Context) (*Job, error)
+	predicates     []predicate.Job
+}
+
+var _ ent.Mutation = (*JobMutation)(nil)
+
+// jobOption allows management of the mutation configuration using functional options.
+type jobOption func(*JobMutation)
+
+// newJobMutation creates new mutation for the Job entity.
+func newJobMutation(c config, op Op, opts ...jobOption) *JobMutation {
+	m := &JobMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeJob,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withJobID sets the ID field of the mutation.
+func withJobID(id types.JobID) jobOption {
+	return func(m *JobMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Job
+		)
+		m.oldValue = func(ctx context.Context) (*Job, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Job.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withJob sets the old Job of the mutation.
+func withJob(node *Job) jobOption {
+	return func(m *JobMutation) {
+		m.oldValue = func(context.Context) (*Job, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m JobMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m JobMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("store: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Job entities.
+func (m *JobMutation) SetID(id types.JobID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *JobMutation) ID() (id types.JobID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *JobMutation) IDs(ctx context.Context) ([]types.JobID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []types.JobID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Job.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetName sets the "name" field.
+func (m *JobMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *JobMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *JobMutation) ResetName() {
+	m.name = nil
+}
+
+// SetPayload sets the "payload" field.
+func (m *JobMutation) SetPayload(s string) {
+	m.payload = &s
+}
+
+// Payload returns the value of the "payload" field in the mutation.
+func (m *JobMutation) Payload() (r string, exists bool) {
+	v := m.payload
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPayload returns the old "payload" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldPayload(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPayload requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+	}
+	return oldValue.Payload, nil
+}
+
+// ResetPayload resets all changes to the "payload" field.
+func (m *JobMutation) ResetPayload() {
+	m.payload = nil
+}
+
+// SetDedupKey sets the "dedup_key" field.
+func (m *JobMutation) SetDedupKey(s string) {
+	m.dedup_key = &s
+}
+
+// DedupKey returns the value of the "dedup_key" field in the mutation.
+func (m *JobMutation) DedupKey() (r string, exists bool) {
+	v := m.dedup_key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDedupKey returns the old "dedup_key" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldDedupKey(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDedupKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDedupKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDedupKey: %w", err)
+	}
+	return oldValue.DedupKey, nil
+}
+
+// ClearDedupKey clears the value of the "dedup_key" field.
+func (m *JobMutation) ClearDedupKey() {
+	m.dedup_key = nil
+	m.clearedFields[job.FieldDedupKey] = struct{}{}
+}
+
+// DedupKeyCleared returns if the "dedup_key" field was cleared in this mutation.
+func (m *JobMutation) DedupKeyCleared() bool {
+	_, ok := m.clearedFields[job.FieldDedupKey]
+	return ok
+}
+
+// ResetDedupKey resets all changes to the "dedup_key" field.
+func (m *JobMutation) ResetDedupKey() {
+	m.dedup_key = nil
+	delete(m.clearedFields, job.FieldDedupKey)
+}
+
+// SetAttempts sets the "attempts" field.
+func (m *JobMutation) SetAttempts(i int) {
+	m.attempts = &i
+	m.addattempts = nil
+}
+
+// Attempts returns the value of the "attempts" field in the mutation.
+func (m *JobMutation) Attempts() (r int, exists bool) {
+	v := m.attempts
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAttempts returns the old "attempts" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldAttempts(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAttempts is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAttempts requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAttempts: %w", err)
+	}
+	return oldValue.Attempts, nil
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (m *JobMutation) AddAttempts(i int) {
+	if m.addattempts != nil {
+		*m.addattempts += i
+	} else {
+		m.addattempts = &i
+	}
+}
+
+// AddedAttempts returns the value that was added to the "attempts" field in this mutation.
+func (m *JobMutation) AddedAttempts() (r int, exists bool) {
+	v := m.addattempts
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAttempts resets all changes to the "attempts" field.
+func (m *JobMutation) ResetAttempts() {
+	m.attempts = nil
+	m.addattempts = nil
+}
+
+// SetAvailableAt sets the "available_at" field.
+func (m *JobMutation) SetAvailableAt(t time.Time) {
+	m.available_at = &t
+}
+
+// AvailableAt returns the value of the "available_at" field in the mutation.
+func (m *JobMutation) AvailableAt() (r time.Time, exists bool) {
+	v := m.available_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvailableAt returns the old "available_at" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldAvailableAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvailableAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvailableAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvailableAt: %w", err)
+	}
+	return oldValue.AvailableAt, nil
+}
+
+// ResetAvailableAt resets all changes to the "available_at" field.
+func (m *JobMutation) ResetAvailableAt() {
+	m.available_at = nil
+}
+
+// SetReservedUntil sets the "reserved_until" field.
+func (m *JobMutation) SetReservedUntil(t time.Time) {
+	m.reserved_until = &t
+}
+
+// ReservedUntil returns the value of the "reserved_until" field in the mutation.
+func (m *JobMutation) ReservedUntil() (r time.Time, exists bool) {
+	v := m.reserved_until
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReservedUntil returns the old "reserved_until" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldReservedUntil(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReservedUntil is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReservedUntil requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReservedUntil: %w", err)
+	}
+	return oldValue.ReservedUntil, nil
+}
+
+// ResetReservedUntil resets all changes to the "reserved_until" field.
+func (m *JobMutation) ResetReservedUntil() {
+	m.reserved_until = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *JobMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *JobMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
-	return *v, true
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Job entity.
+// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *JobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *JobMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the JobMutation builder.
+func (m *JobMutation) Where(ps ...predicate.Job) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the JobMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *JobMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Job, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *JobMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *JobMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Job).
+func (m *JobMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *JobMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.name != nil {
+		fields = append(fields, job.FieldName)
+	}
+	if m.payload != nil {
+		fields = append(fields, job.FieldPayload)
+	}
+	if m.dedup_key != nil {
+		fields = append(fields, job.FieldDedupKey)
+	}
+	if m.attempts != nil {
+		fields = append(fields, job.FieldAttempts)
+	}
+	if m.available_at != nil {
+		fields = append(fields, job.FieldAvailableAt)
+	}
+	if m.reserved_until != nil {
+		fields = append(fields, job.FieldReservedUntil)
+	}
+	if m.created_at != nil {
+		fields = append(fields, job.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *JobMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case job.FieldName:
+		return m.Name()
+	case job.FieldPayload:
+		return m.Payload()
+	case job.FieldDedupKey:
+		return m.DedupKey()
+	case job.FieldAttempts:
+		return m.Attempts()
+	case job.FieldAvailableAt:
+		return m.AvailableAt()
+	case job.FieldReservedUntil:
+		return m.ReservedUntil()
+	case job.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *JobMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case job.FieldName:
+		return m.OldName(ctx)
+	case job.FieldPayload:
+		return m.OldPayload(ctx)
+	case job.FieldDedupKey:
+		return m.OldDedupKey(ctx)
+	case job.FieldAttempts:
+		return m.OldAttempts(ctx)
+	case job.FieldAvailableAt:
+		return m.OldAvailableAt(ctx)
+	case job.FieldReservedUntil:
+		return m.OldReservedUntil(ctx)
+	case job.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Job field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *JobMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case job.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case job.FieldPayload:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPayload(v)
+		return nil
+	case job.FieldDedupKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDedupKey(v)
+		return nil
+	case job.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttempts(v)
+		return nil
+	case job.FieldAvailableAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvailableAt(v)
+		return nil
+	case job.FieldReservedUntil:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReservedUntil(v)
+		return nil
+	case job.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Job field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *JobMutation) AddedFields() []string {
+	var fields []string
+	if m.addattempts != nil {
+		fields = append(fields, job.FieldAttempts)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *JobMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case job.FieldAttempts:
+		return m.AddedAttempts()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *JobMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case job.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAttempts(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Job numeric field %s", name)
 }
 
-// OldAttempts returns the old "attempts" field's value of the Job entity.
-// If the Job object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *JobMutation) OldAttempts(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAttempts is only allowed on UpdateOne operations")
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *JobMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(job.FieldDedupKey) {
+		fields = append(fields, job.FieldDedupKey)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAttempts requires an ID field in the mutation")
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *JobMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *JobMutation) ClearField(name string) error {
+	switch name {
+	case job.FieldDedupKey:
+		m.ClearDedupKey()
+		return nil
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAttempts: %w", err)
+	return fmt.Errorf("unknown Job nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *JobMutation) ResetField(name string) error {
+	switch name {
+	case job.FieldName:
+		m.ResetName()
+		return nil
+	case job.FieldPayload:
+		m.ResetPayload()
+		return nil
+	case job.FieldDedupKey:
+		m.ResetDedupKey()
+		return nil
+	case job.FieldAttempts:
+		m.ResetAttempts()
+		return nil
+	case job.FieldAvailableAt:
+		m.ResetAvailableAt()
+		return nil
+	case job.FieldReservedUntil:
+		m.ResetReservedUntil()
+		return nil
+	case job.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
 	}
-	return oldValue.Attempts, nil
+	return fmt.Errorf("unknown Job field %s", name)
 }
 
-// AddAttempts adds i to the "attempts" field.
-func (m *JobMutation) AddAttempts(i int) {
-	if m.addattempts != nil {
-		*m.addattempts += i
-	} else {
-		m.addattempts = &i
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *JobMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *JobMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *JobMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *JobMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *JobMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *JobMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *JobMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Job unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *JobMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Job edge %s", name)
+}
+
+// JobScheduleMutation represents an operation that mutates the JobSchedule nodes in the graph.
+type JobScheduleMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *types.JobScheduleID
+	name          *string
+	next_run_at   *time.Time
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*JobSchedule, error)
+	predicates    []predicate.JobSchedule
+}
+
+var _ ent.Mutation = (*JobScheduleMutation)(nil)
+
+// jobscheduleOption allows management of the mutation configuration using functional options.
+type jobscheduleOption func(*JobScheduleMutation)
+
+// newJobScheduleMutation creates new mutation for the JobSchedule entity.
+func newJobScheduleMutation(c config, op Op, opts ...jobscheduleOption) *JobScheduleMutation {
+	m := &JobScheduleMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeJobSchedule,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
-// AddedAttempts returns the value that was added to the "attempts" field in this mutation.
-func (m *JobMutation) AddedAttempts() (r int, exists bool) {
-	v := m.addattempts
-	if v == nil {
+// withJobScheduleID sets the ID field of the mutation.
+func withJobScheduleID(id types.JobScheduleID) jobscheduleOption {
+	return func(m *JobScheduleMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *JobSchedule
+		)
+		m.oldValue = func(ctx context.Context) (*JobSchedule, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().JobSchedule.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withJobSchedule sets the old JobSchedule of the mutation.
+func withJobSchedule(node *JobSchedule) jobscheduleOption {
+	return func(m *JobScheduleMutation) {
+		m.oldValue = func(context.Context) (*JobSchedule, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m JobScheduleMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m JobScheduleMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("store: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of JobSchedule entities.
+func (m *JobScheduleMutation) SetID(id types.JobScheduleID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *JobScheduleMutation) ID() (id types.JobScheduleID, exists bool) {
+	if m.id == nil {
 		return
 	}
-	return *v, true
+	return *m.id, true
 }
 
-// ResetAttempts resets all changes to the "attempts" field.
-func (m *JobMutation) ResetAttempts() {
-	m.attempts = nil
-	m.addattempts = nil
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *JobScheduleMutation) IDs(ctx context.Context) ([]types.JobScheduleID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []types.JobScheduleID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().JobSchedule.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetAvailableAt sets the "available_at" field.
-func (m *JobMutation) SetAvailableAt(t time.Time) {
-	m.available_at = &t
+// SetName sets the "name" field.
+func (m *JobScheduleMutation) SetName(s string) {
+	m.name = &s
 }
 
-// AvailableAt returns the value of the "available_at" field in the mutation.
-func (m *JobMutation) AvailableAt() (r time.Time, exists bool) {
-	v := m.available_at
+// Name returns the value of the "name" field in the mutation.
+func (m *JobScheduleMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvailableAt returns the old "available_at" field's value of the Job entity.
-// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the JobSchedule entity.
+// If the JobSchedule object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *JobMutation) OldAvailableAt(ctx context.Context) (v time.Time, err error) {
+func (m *JobScheduleMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvailableAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvailableAt requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvailableAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.AvailableAt, nil
+	return oldValue.Name, nil
 }
 
-// ResetAvailableAt resets all changes to the "available_at" field.
-func (m *JobMutation) ResetAvailableAt() {
-	m.available_at = nil
+// ResetName resets all changes to the "name" field.
+func (m *JobScheduleMutation) ResetName() {
+	m.name = nil
 }
 
-// SetReservedUntil sets the "reserved_until" field.
-func (m *JobMutation) SetReservedUntil(t time.Time) {
-	m.reserved_until = &t
+// SetNextRunAt sets the "next_run_at" field.
+func (m *JobScheduleMutation) SetNextRunAt(t time.Time) {
+	m.next_run_at = &t
 }
 
-// ReservedUntil returns the value of the "reserved_until" field in the mutation.
-func (m *JobMutation) ReservedUntil() (r time.Time, exists bool) {
-	v := m.reserved_until
+// NextRunAt returns the value of the "next_run_at" field in the mutation.
+func (m *JobScheduleMutation) NextRunAt() (r time.Time, exists bool) {
+	v := m.next_run_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldReservedUntil returns the old "reserved_until" field's value of the Job entity.
-// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// OldNextRunAt returns the old "next_run_at" field's value of the JobSchedule entity.
+// If the JobSchedule object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *JobMutation) OldReservedUntil(ctx context.Context) (v time.Time, err error) {
+func (m *JobScheduleMutation) OldNextRunAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldReservedUntil is only allowed on UpdateOne operations")
+		return v, errors.New("OldNextRunAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldReservedUntil requires an ID field in the mutation")
+		return v, errors.New("OldNextRunAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldReservedUntil: %w", err)
+		return v, fmt.Errorf("querying old value for OldNextRunAt: %w", err)
 	}
-	return oldValue.ReservedUntil, nil
+	return oldValue.NextRunAt, nil
 }
 
-// ResetReservedUntil resets all changes to the "reserved_until" field.
-func (m *JobMutation) ResetReservedUntil() {
-	m.reserved_until = nil
+// ResetNextRunAt resets all changes to the "next_run_at" field.
+func (m *JobScheduleMutation) ResetNextRunAt() {
+	m.next_run_at = nil
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *JobMutation) SetCreatedAt(t time.Time) {
+func (m *JobScheduleMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *JobMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *JobScheduleMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -1429,10 +2509,10 @@ func (m *JobMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Job entity.
-// If the Job object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the JobSchedule entity.
+// If the JobSchedule object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *JobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *JobScheduleMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -1447,19 +2527,19 @@ func (m *JobMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error)
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *JobMutation) ResetCreatedAt() {
+func (m *JobScheduleMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// Where appends a list predicates to the JobMutation builder.
-func (m *JobMutation) Where(ps ...predicate.Job) {
+// Where appends a list predicates to the JobScheduleMutation builder.
+func (m *JobScheduleMutation) Where(ps ...predicate.JobSchedule) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the JobMutation builder. Using this method,
+// WhereP appends storage-level predicates to the JobScheduleMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *JobMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Job, len(ps))
+func (m *JobScheduleMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.JobSchedule, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1467,42 +2547,33 @@ func (m *JobMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *JobMutation) Op() Op {
+func (m *JobScheduleMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *JobMutation) SetOp(op Op) {
+func (m *JobScheduleMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Job).
-func (m *JobMutation) Type() string {
+// Type returns the node type of this mutation (JobSchedule).
+func (m *JobScheduleMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *JobMutation) Fields() []string {
-	fields := make([]string, 0, 6)
+func (m *JobScheduleMutation) Fields() []string {
+	fields := make([]string, 0, 3)
 	if m.name != nil {
-		fields = append(fields, job.FieldName)
-	}
-	if m.payload != nil {
-		fields = append(fields, job.FieldPayload)
-	}
-	if m.attempts != nil {
-		fields = append(fields, job.FieldAttempts)
+		fields = append(fields, jobschedule.FieldName)
 	}
-	if m.available_at != nil {
-		fields = append(fields, job.FieldAvailableAt)
-	}
-	if m.reserved_until != nil {
-		fields = append(fields, job.FieldReservedUntil)
+	if m.next_run_at != nil {
+		fields = append(fields, jobschedule.FieldNextRunAt)
 	}
 	if m.created_at != nil {
-		fields = append(fields, job.FieldCreatedAt)
+		fields = append(fields, jobschedule.FieldCreatedAt)
 	}
 	return fields
 }
@@ -1510,19 +2581,13 @@ func (m *JobMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *JobMutation) Field(name string) (ent.Value, bool) {
+func (m *JobScheduleMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case job.FieldName:
+	case jobschedule.FieldName:
 		return m.Name()
-	case job.FieldPayload:
-		return m.Payload()
-	case job.FieldAttempts:
-		return m.Attempts()
-	case job.FieldAvailableAt:
-		return m.AvailableAt()
-	case job.FieldReservedUntil:
-		return m.ReservedUntil()
-	case job.FieldCreatedAt:
+	case jobschedule.FieldNextRunAt:
+		return m.NextRunAt()
+	case jobschedule.FieldCreatedAt:
 		return m.CreatedAt()
 	}
 	return nil, false
@@ -1531,65 +2596,38 @@ func (m *JobMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *JobMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *JobScheduleMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case job.FieldName:
+	case jobschedule.FieldName:
 		return m.OldName(ctx)
-	case job.FieldPayload:
-		return m.OldPayload(ctx)
-	case job.FieldAttempts:
-		return m.OldAttempts(ctx)
-	case job.FieldAvailableAt:
-		return m.OldAvailableAt(ctx)
-	case job.FieldReservedUntil:
-		return m.OldReservedUntil(ctx)
-	case job.FieldCreatedAt:
+	case jobschedule.FieldNextRunAt:
+		return m.OldNextRunAt(ctx)
+	case jobschedule.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Job field %s", name)
+	return nil, fmt.Errorf("unknown JobSchedule field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *JobMutation) SetField(name string, value ent.Value) error {
+func (m *JobScheduleMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case job.FieldName:
+	case jobschedule.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetName(v)
 		return nil
-	case job.FieldPayload:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPayload(v)
-		return nil
-	case job.FieldAttempts:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAttempts(v)
-		return nil
-	case job.FieldAvailableAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAvailableAt(v)
-		return nil
-	case job.FieldReservedUntil:
+	case jobschedule.FieldNextRunAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetReservedUntil(v)
+		m.SetNextRunAt(v)
 		return nil
-	case job.FieldCreatedAt:
+	case jobschedule.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -1597,137 +2635,113 @@ func (m *JobMutation) SetField(name string, value ent.Value) error {
 		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Job field %s", name)
+	return fmt.Errorf("unknown JobSchedule field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *JobMutation) AddedFields() []string {
-	var fields []string
-	if m.addattempts != nil {
-		fields = append(fields, job.FieldAttempts)
-	}
-	return fields
+func (m *JobScheduleMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *JobMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case job.FieldAttempts:
-		return m.AddedAttempts()
-	}
+func (m *JobScheduleMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *JobMutation) AddField(name string, value ent.Value) error {
+func (m *JobScheduleMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case job.FieldAttempts:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddAttempts(v)
-		return nil
 	}
-	return fmt.Errorf("unknown Job numeric field %s", name)
+	return fmt.Errorf("unknown JobSchedule numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *JobMutation) ClearedFields() []string {
+func (m *JobScheduleMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *JobMutation) FieldCleared(name string) bool {
+func (m *JobScheduleMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *JobMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Job nullable field %s", name)
+func (m *JobScheduleMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown JobSchedule nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *JobMutation) ResetField(name string) error {
+func (m *JobScheduleMutation) ResetField(name string) error {
 	switch name {
-	case job.FieldName:
+	case jobschedule.FieldName:
 		m.ResetName()
 		return nil
-	case job.FieldPayload:
-		m.ResetPayload()
-		return nil
-	case job.FieldAttempts:
-		m.ResetAttempts()
-		return nil
-	case job.FieldAvailableAt:
-		m.ResetAvailableAt()
-		return nil
-	case job.FieldReservedUntil:
-		m.ResetReservedUntil()
+	case jobschedule.FieldNextRunAt:
+		m.ResetNextRunAt()
 		return nil
-	case job.FieldCreatedAt:
+	case jobschedule.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Job field %s", name)
+	return fmt.Errorf("unknown JobSchedule field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *JobMutation) AddedEdges() []string {
+func (m *JobScheduleMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *JobMutation) AddedIDs(name string) []ent.Value {
+func (m *JobScheduleMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *JobMutation) RemovedEdges() []string {
+func (m *JobScheduleMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *JobMutation) RemovedIDs(name string) []ent.Value {
+func (m *JobScheduleMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *JobMutation) ClearedEdges() []string {
+func (m *JobScheduleMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *JobMutation) EdgeCleared(name string) bool {
+func (m *JobScheduleMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *JobMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Job unique edge %s", name)
+func (m *JobScheduleMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown JobSchedule unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *JobMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Job edge %s", name)
+func (m *JobScheduleMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown JobSchedule edge %s", name)
 }
 
 // MessageMutation represents an operation that mutates the Message nodes in the graph.
@@ -1741,7 +2755,11 @@ type MessageMutation struct {
 	is_visible_for_client  *bool
 	is_visible_for_manager *bool
 	body                   *string
+	sealed_body            *[]byte
 	checked_at             *time.Time
+	verdict_status         *message.VerdictStatus
+	verdict_source         *string
+	verdict_at             *time.Time
 	is_blocked             *bool
 	is_service             *bool
 	created_at             *time.Time
@@ -2124,6 +3142,55 @@ func (m *MessageMutation) ResetBody() {
 	m.body = nil
 }
 
+// SetSealedBody sets the "sealed_body" field.
+func (m *MessageMutation) SetSealedBody(b []byte) {
+	m.sealed_body = &b
+}
+
+// SealedBody returns the value of the "sealed_body" field in the mutation.
+func (m *MessageMutation) SealedBody() (r []byte, exists bool) {
+	v := m.sealed_body
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSealedBody returns the old "sealed_body" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldSealedBody(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSealedBody is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSealedBody requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSealedBody: %w", err)
+	}
+	return oldValue.SealedBody, nil
+}
+
+// ClearSealedBody clears the value of the "sealed_body" field.
+func (m *MessageMutation) ClearSealedBody() {
+	m.sealed_body = nil
+	m.clearedFields[message.FieldSealedBody] = struct{}{}
+}
+
+// SealedBodyCleared returns if the "sealed_body" field was cleared in this mutation.
+func (m *MessageMutation) SealedBodyCleared() bool {
+	_, ok := m.clearedFields[message.FieldSealedBody]
+	return ok
+}
+
+// ResetSealedBody resets all changes to the "sealed_body" field.
+func (m *MessageMutation) ResetSealedBody() {
+	m.sealed_body = nil
+	delete(m.clearedFields, message.FieldSealedBody)
+}
+
 // SetCheckedAt sets the "checked_at" field.
 func (m *MessageMutation) SetCheckedAt(t time.Time) {
 	m.checked_at = &t
@@ -2173,6 +3240,153 @@ func (m *MessageMutation) ResetCheckedAt() {
 	delete(m.clearedFields, message.FieldCheckedAt)
 }
 
+// SetVerdictStatus sets the "verdict_status" field.
+func (m *MessageMutation) SetVerdictStatus(ms message.VerdictStatus) {
+	m.verdict_status = &ms
+}
+
+// VerdictStatus returns the value of the "verdict_status" field in the mutation.
+func (m *MessageMutation) VerdictStatus() (r message.VerdictStatus, exists bool) {
+	v := m.verdict_status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVerdictStatus returns the old "verdict_status" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldVerdictStatus(ctx context.Context) (v message.VerdictStatus, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVerdictStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVerdictStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVerdictStatus: %w", err)
+	}
+	return oldValue.VerdictStatus, nil
+}
+
+// ClearVerdictStatus clears the value of the "verdict_status" field.
+func (m *MessageMutation) ClearVerdictStatus() {
+	m.verdict_status = nil
+	m.clearedFields[message.FieldVerdictStatus] = struct{}{}
+}
+
+// VerdictStatusCleared returns if the "verdict_status" field was cleared in this mutation.
+func (m *MessageMutation) VerdictStatusCleared() bool {
+	_, ok := m.clearedFields[message.FieldVerdictStatus]
+	return ok
+}
+
+// ResetVerdictStatus resets all changes to the "verdict_status" field.
+func (m *MessageMutation) ResetVerdictStatus() {
+	m.verdict_status = nil
+	delete(m.clearedFields, message.FieldVerdictStatus)
+}
+
+// SetVerdictSource sets the "verdict_source" field.
+func (m *MessageMutation) SetVerdictSource(s string) {
+	m.verdict_source = &s
+}
+
+// VerdictSource returns the value of the "verdict_source" field in the mutation.
+func (m *MessageMutation) VerdictSource() (r string, exists bool) {
+	v := m.verdict_source
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVerdictSource returns the old "verdict_source" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldVerdictSource(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVerdictSource is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVerdictSource requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVerdictSource: %w", err)
+	}
+	return oldValue.VerdictSource, nil
+}
+
+// ClearVerdictSource clears the value of the "verdict_source" field.
+func (m *MessageMutation) ClearVerdictSource() {
+	m.verdict_source = nil
+	m.clearedFields[message.FieldVerdictSource] = struct{}{}
+}
+
+// VerdictSourceCleared returns if the "verdict_source" field was cleared in this mutation.
+func (m *MessageMutation) VerdictSourceCleared() bool {
+	_, ok := m.clearedFields[message.FieldVerdictSource]
+	return ok
+}
+
+// ResetVerdictSource resets all changes to the "verdict_source" field.
+func (m *MessageMutation) ResetVerdictSource() {
+	m.verdict_source = nil
+	delete(m.clearedFields, message.FieldVerdictSource)
+}
+
+// SetVerdictAt sets the "verdict_at" field.
+func (m *MessageMutation) SetVerdictAt(t time.Time) {
+	m.verdict_at = &t
+}
+
+// VerdictAt returns the value of the "verdict_at" field in the mutation.
+func (m *MessageMutation) VerdictAt() (r time.Time, exists bool) {
+	v := m.verdict_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVerdictAt returns the old "verdict_at" field's value of the Message entity.
+// If the Message object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MessageMutation) OldVerdictAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVerdictAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVerdictAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVerdictAt: %w", err)
+	}
+	return oldValue.VerdictAt, nil
+}
+
+// ClearVerdictAt clears the value of the "verdict_at" field.
+func (m *MessageMutation) ClearVerdictAt() {
+	m.verdict_at = nil
+	m.clearedFields[message.FieldVerdictAt] = struct{}{}
+}
+
+// VerdictAtCleared returns if the "verdict_at" field was cleared in this mutation.
+func (m *MessageMutation) VerdictAtCleared() bool {
+	_, ok := m.clearedFields[message.FieldVerdictAt]
+	return ok
+}
+
+// ResetVerdictAt resets all changes to the "verdict_at" field.
+func (m *MessageMutation) ResetVerdictAt() {
+	m.verdict_at = nil
+	delete(m.clearedFields, message.FieldVerdictAt)
+}
+
 // SetIsBlocked sets the "is_blocked" field.
 func (m *MessageMutation) SetIsBlocked(b bool) {
 	m.is_blocked = &b
@@ -2367,7 +3581,7 @@ func (m *MessageMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *MessageMutation) Fields() []string {
-	fields := make([]string, 0, 11)
+	fields := make([]string, 0, 15)
 	if m.author_id != nil {
 		fields = append(fields, message.FieldAuthorID)
 	}
@@ -2389,9 +3603,21 @@ func (m *MessageMutation) Fields() []string {
 	if m.body != nil {
 		fields = append(fields, message.FieldBody)
 	}
+	if m.sealed_body != nil {
+		fields = append(fields, message.FieldSealedBody)
+	}
 	if m.checked_at != nil {
 		fields = append(fields, message.FieldCheckedAt)
 	}
+	if m.verdict_status != nil {
+		fields = append(fields, message.FieldVerdictStatus)
+	}
+	if m.verdict_source != nil {
+		fields = append(fields, message.FieldVerdictSource)
+	}
+	if m.verdict_at != nil {
+		fields = append(fields, message.FieldVerdictAt)
+	}
 	if m.is_blocked != nil {
 		fields = append(fields, message.FieldIsBlocked)
 	}
@@ -2423,8 +3649,16 @@ func (m *MessageMutation) Field(name string) (ent.Value, bool) {
 		return m.IsVisibleForManager()
 	case message.FieldBody:
 		return m.Body()
+	case message.FieldSealedBody:
+		return m.SealedBody()
 	case message.FieldCheckedAt:
 		return m.CheckedAt()
+	case message.FieldVerdictStatus:
+		return m.VerdictStatus()
+	case message.FieldVerdictSource:
+		return m.VerdictSource()
+	case message.FieldVerdictAt:
+		return m.VerdictAt()
 	case message.FieldIsBlocked:
 		return m.IsBlocked()
 	case message.FieldIsService:
@@ -2454,8 +3688,16 @@ func (m *MessageMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldIsVisibleForManager(ctx)
 	case message.FieldBody:
 		return m.OldBody(ctx)
+	case message.FieldSealedBody:
+		return m.OldSealedBody(ctx)
 	case message.FieldCheckedAt:
 		return m.OldCheckedAt(ctx)
+	case message.FieldVerdictStatus:
+		return m.OldVerdictStatus(ctx)
+	case message.FieldVerdictSource:
+		return m.OldVerdictSource(ctx)
+	case message.FieldVerdictAt:
+		return m.OldVerdictAt(ctx)
 	case message.FieldIsBlocked:
 		return m.OldIsBlocked(ctx)
 	case message.FieldIsService:
@@ -2520,6 +3762,13 @@ func (m *MessageMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetBody(v)
 		return nil
+	case message.FieldSealedBody:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSealedBody(v)
+		return nil
 	case message.FieldCheckedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -2527,6 +3776,27 @@ func (m *MessageMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCheckedAt(v)
 		return nil
+	case message.FieldVerdictStatus:
+		v, ok := value.(message.VerdictStatus)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVerdictStatus(v)
+		return nil
+	case message.FieldVerdictSource:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVerdictSource(v)
+		return nil
+	case message.FieldVerdictAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVerdictAt(v)
+		return nil
 	case message.FieldIsBlocked:
 		v, ok := value.(bool)
 		if !ok {
@@ -2581,9 +3851,21 @@ func (m *MessageMutation) ClearedFields() []string {
 	if m.FieldCleared(message.FieldAuthorID) {
 		fields = append(fields, message.FieldAuthorID)
 	}
+	if m.FieldCleared(message.FieldSealedBody) {
+		fields = append(fields, message.FieldSealedBody)
+	}
 	if m.FieldCleared(message.FieldCheckedAt) {
 		fields = append(fields, message.FieldCheckedAt)
 	}
+	if m.FieldCleared(message.FieldVerdictStatus) {
+		fields = append(fields, message.FieldVerdictStatus)
+	}
+	if m.FieldCleared(message.FieldVerdictSource) {
+		fields = append(fields, message.FieldVerdictSource)
+	}
+	if m.FieldCleared(message.FieldVerdictAt) {
+		fields = append(fields, message.FieldVerdictAt)
+	}
 	return fields
 }
 
@@ -2601,9 +3883,21 @@ func (m *MessageMutation) ClearField(name string) error {
 	case message.FieldAuthorID:
 		m.ClearAuthorID()
 		return nil
+	case message.FieldSealedBody:
+		m.ClearSealedBody()
+		return nil
 	case message.FieldCheckedAt:
 		m.ClearCheckedAt()
 		return nil
+	case message.FieldVerdictStatus:
+		m.ClearVerdictStatus()
+		return nil
+	case message.FieldVerdictSource:
+		m.ClearVerdictSource()
+		return nil
+	case message.FieldVerdictAt:
+		m.ClearVerdictAt()
+		return nil
 	}
 	return fmt.Errorf("unknown Message nullable field %s", name)
 }
@@ -2633,9 +3927,21 @@ func (m *MessageMutation) ResetField(name string) error {
 	case message.FieldBody:
 		m.ResetBody()
 		return nil
+	case message.FieldSealedBody:
+		m.ResetSealedBody()
+		return nil
 	case message.FieldCheckedAt:
 		m.ResetCheckedAt()
 		return nil
+	case message.FieldVerdictStatus:
+		m.ResetVerdictStatus()
+		return nil
+	case message.FieldVerdictSource:
+		m.ResetVerdictSource()
+		return nil
+	case message.FieldVerdictAt:
+		m.ResetVerdictAt()
+		return nil
 	case message.FieldIsBlocked:
 		m.ResetIsBlocked()
 		return nil
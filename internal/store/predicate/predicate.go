@@ -9,12 +9,18 @@ import (
 // Chat is the predicate function for chat builders.
 type Chat func(*sql.Selector)
 
+// CompletedJob is the predicate function for completedjob builders.
+type CompletedJob func(*sql.Selector)
+
 // FailedJob is the predicate function for failedjob builders.
 type FailedJob func(*sql.Selector)
 
 // Job is the predicate function for job builders.
 type Job func(*sql.Selector)
 
+// JobSchedule is the predicate function for jobschedule builders.
+type JobSchedule func(*sql.Selector)
+
 // Message is the predicate function for message builders.
 type Message func(*sql.Selector)
 
@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/gerladeno/chat-service/internal/store/chat"
+	"github.com/gerladeno/chat-service/internal/store/completedjob"
 	"github.com/gerladeno/chat-service/internal/store/failedjob"
 	"github.com/gerladeno/chat-service/internal/store/job"
+	"github.com/gerladeno/chat-service/internal/store/jobschedule"
 	"github.com/gerladeno/chat-service/internal/store/message"
 	"github.com/gerladeno/chat-service/internal/store/problem"
 	"github.com/gerladeno/chat-service/internal/store/schema"
@@ -28,6 +30,16 @@ func init() {
 	chatDescID := chatFields[0].Descriptor()
 	// chat.DefaultID holds the default value on creation for the id field.
 	chat.DefaultID = chatDescID.Default.(func() types.ChatID)
+	completedjobFields := schema.CompletedJob{}.Fields()
+	_ = completedjobFields
+	// completedjobDescCreatedAt is the schema descriptor for created_at field.
+	completedjobDescCreatedAt := completedjobFields[4].Descriptor()
+	// completedjob.DefaultCreatedAt holds the default value on creation for the created_at field.
+	completedjob.DefaultCreatedAt = completedjobDescCreatedAt.Default.(func() time.Time)
+	// completedjobDescID is the schema descriptor for id field.
+	completedjobDescID := completedjobFields[0].Descriptor()
+	// completedjob.DefaultID holds the default value on creation for the id field.
+	completedjob.DefaultID = completedjobDescID.Default.(func() types.CompletedJobID)
 	failedjobFields := schema.FailedJob{}.Fields()
 	_ = failedjobFields
 	// failedjobDescCreatedAt is the schema descriptor for created_at field.
@@ -41,23 +53,33 @@ func init() {
 	jobFields := schema.Job{}.Fields()
 	_ = jobFields
 	// jobDescAttempts is the schema descriptor for attempts field.
-	jobDescAttempts := jobFields[3].Descriptor()
+	jobDescAttempts := jobFields[4].Descriptor()
 	// job.DefaultAttempts holds the default value on creation for the attempts field.
 	job.DefaultAttempts = jobDescAttempts.Default.(int)
 	// job.AttemptsValidator is a validator for the "attempts" field. It is called by the builders before save.
 	job.AttemptsValidator = jobDescAttempts.Validators[0].(func(int) error)
 	// jobDescReservedUntil is the schema descriptor for reserved_until field.
-	jobDescReservedUntil := jobFields[5].Descriptor()
+	jobDescReservedUntil := jobFields[6].Descriptor()
 	// job.DefaultReservedUntil holds the default value on creation for the reserved_until field.
 	job.DefaultReservedUntil = jobDescReservedUntil.Default.(time.Time)
 	// jobDescCreatedAt is the schema descriptor for created_at field.
-	jobDescCreatedAt := jobFields[6].Descriptor()
+	jobDescCreatedAt := jobFields[7].Descriptor()
 	// job.DefaultCreatedAt holds the default value on creation for the created_at field.
 	job.DefaultCreatedAt = jobDescCreatedAt.Default.(func() time.Time)
 	// jobDescID is the schema descriptor for id field.
 	jobDescID := jobFields[0].Descriptor()
 	// job.DefaultID holds the default value on creation for the id field.
 	job.DefaultID = jobDescID.Default.(func() types.JobID)
+	jobscheduleFields := schema.JobSchedule{}.Fields()
+	_ = jobscheduleFields
+	// jobscheduleDescCreatedAt is the schema descriptor for created_at field.
+	jobscheduleDescCreatedAt := jobscheduleFields[3].Descriptor()
+	// jobschedule.DefaultCreatedAt holds the default value on creation for the created_at field.
+	jobschedule.DefaultCreatedAt = jobscheduleDescCreatedAt.Default.(func() time.Time)
+	// jobscheduleDescID is the schema descriptor for id field.
+	jobscheduleDescID := jobscheduleFields[0].Descriptor()
+	// jobschedule.DefaultID holds the default value on creation for the id field.
+	jobschedule.DefaultID = jobscheduleDescID.Default.(func() types.JobScheduleID)
 	messageFields := schema.Message{}.Fields()
 	_ = messageFields
 	// messageDescIsVisibleForClient is the schema descriptor for is_visible_for_client field.
@@ -87,15 +109,15 @@ func init() {
 		}
 	}()
 	// messageDescIsBlocked is the schema descriptor for is_blocked field.
-	messageDescIsBlocked := messageFields[9].Descriptor()
+	messageDescIsBlocked := messageFields[13].Descriptor()
 	// message.DefaultIsBlocked holds the default value on creation for the is_blocked field.
 	message.DefaultIsBlocked = messageDescIsBlocked.Default.(bool)
 	// messageDescIsService is the schema descriptor for is_service field.
-	messageDescIsService := messageFields[10].Descriptor()
+	messageDescIsService := messageFields[14].Descriptor()
 	// message.DefaultIsService holds the default value on creation for the is_service field.
 	message.DefaultIsService = messageDescIsService.Default.(bool)
 	// messageDescCreatedAt is the schema descriptor for created_at field.
-	messageDescCreatedAt := messageFields[11].Descriptor()
+	messageDescCreatedAt := messageFields[15].Descriptor()
 	// message.DefaultCreatedAt holds the default value on creation for the created_at field.
 	message.DefaultCreatedAt = messageDescCreatedAt.Default.(func() time.Time)
 	// messageDescID is the schema descriptor for id field.
@@ -3,6 +3,7 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/gerladeno/chat-service/internal/types"
 	"time"
 )
@@ -19,6 +20,7 @@ func (Job) Fields() []ent.Field {
 		field.UUID("id", types.JobID{}).Default(types.NewJobID).Unique().Immutable(),
 		field.Text("name").Immutable(),
 		field.Text("payload").Immutable(),
+		field.Text("dedup_key").Optional().Unique().Immutable(),
 		field.Int("attempts").Max(jobMaxAttempts).Default(0),
 		field.Time("available_at").Immutable(),
 		field.Time("reserved_until").Default(time.Now()),
@@ -44,3 +46,40 @@ func (FailedJob) Fields() []ent.Field {
 		newCreatedAtField(),
 	}
 }
+
+// CompletedJob remembers the dedup key of a successfully handled job until expires_at,
+// so that the same job is not put into the queue again.
+type CompletedJob struct {
+	ent.Schema
+}
+
+func (CompletedJob) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", types.CompletedJobID{}).Default(types.NewCompletedJobID).Unique().Immutable(),
+		field.Text("name").Immutable(),
+		field.Text("dedup_key").Unique().Immutable(),
+		field.Time("expires_at"),
+		newCreatedAtField(),
+	}
+}
+
+func (CompletedJob) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("expires_at"),
+	}
+}
+
+// JobSchedule keeps the next occurrence of the recurring job, so that the replicas agree on it
+// regardless of when each of them was started.
+type JobSchedule struct {
+	ent.Schema
+}
+
+func (JobSchedule) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", types.JobScheduleID{}).Default(types.NewJobScheduleID).Unique().Immutable(),
+		field.Text("name").Unique().Immutable(),
+		field.Time("next_run_at"),
+		newCreatedAtField(),
+	}
+}
@@ -25,8 +25,13 @@ func (Message) Fields() []ent.Field {
 		field.UUID("problem_id", types.ProblemID{}),
 		field.Bool("is_visible_for_client").Default(false),
 		field.Bool("is_visible_for_manager").Default(false),
-		field.Text("body").NotEmpty().MaxLen(messageBodyMaxLength).Immutable(),
+		// The body is mutable to be redacted, the original is sealed for auditors then.
+		field.Text("body").NotEmpty().MaxLen(messageBodyMaxLength),
+		field.Bytes("sealed_body").Optional().Sensitive(),
 		field.Time("checked_at").Optional(),
+		field.Enum("verdict_status").Values("ok", "suspicious").Optional(),
+		field.Text("verdict_source").Optional(),
+		field.Time("verdict_at").Optional(),
 		field.Bool("is_blocked").Default(false),
 		field.Bool("is_service").Default(false).Immutable(),
 		newCreatedAtField(),
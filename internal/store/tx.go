@@ -14,10 +14,14 @@ type Tx struct {
 	config
 	// Chat is the client for interacting with the Chat builders.
 	Chat *ChatClient
+	// CompletedJob is the client for interacting with the CompletedJob builders.
+	CompletedJob *CompletedJobClient
 	// FailedJob is the client for interacting with the FailedJob builders.
 	FailedJob *FailedJobClient
 	// Job is the client for interacting with the Job builders.
 	Job *JobClient
+	// JobSchedule is the client for interacting with the JobSchedule builders.
+	JobSchedule *JobScheduleClient
 	// Message is the client for interacting with the Message builders.
 	Message *MessageClient
 	// Problem is the client for interacting with the Problem builders.
@@ -154,8 +158,10 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.Chat = NewChatClient(tx.config)
+	tx.CompletedJob = NewCompletedJobClient(tx.config)
 	tx.FailedJob = NewFailedJobClient(tx.config)
 	tx.Job = NewJobClient(tx.config)
+	tx.JobSchedule = NewJobScheduleClient(tx.config)
 	tx.Message = NewMessageClient(tx.config)
 	tx.Problem = NewProblemClient(tx.config)
 }
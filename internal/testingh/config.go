@@ -23,6 +23,8 @@ type config struct {
 
 	KafkaAddress string `envconfig:"KAFKA_ADDRESS" default:"localhost:9092" validate:"required,hostname_port"`
 
+	NATSURL string `envconfig:"NATS_URL" default:"nats://localhost:4222" validate:"required,url"`
+
 	KeycloakBasePath     string `envconfig:"KEYCLOAK_BASE_PATH" default:"http://localhost:3010" validate:"required,url"`
 	KeycloakRealm        string `envconfig:"KEYCLOAK_REALM" default:"Testing" validate:"required"`
 	KeycloakClientID     string `envconfig:"KEYCLOAK_CLIENT_ID" validate:"required"`
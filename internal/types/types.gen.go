@@ -102,6 +102,76 @@ func (id ChatID) IsZero() bool {
 	return id.String() == "" || id == ChatIDNil
 }
 
+type CompletedJobID uuid.UUID
+
+var CompletedJobIDNil = CompletedJobID(uuid.Nil)
+
+func NewCompletedJobID() CompletedJobID {
+	return CompletedJobID(uuid.New())
+}
+
+func (id CompletedJobID) String() string {
+	return uuid.UUID(id).String()
+}
+
+func (id CompletedJobID) MarshalText() ([]byte, error) {
+	return []byte(uuid.UUID(id).String()), nil
+}
+
+func (id *CompletedJobID) UnmarshalText(text []byte) error {
+	if id == nil {
+		return ErrEntityIsNil
+	}
+	val, err := uuid.ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*id = CompletedJobID(val)
+	return nil
+}
+
+func (id CompletedJobID) Value() (driver.Value, error) {
+	return uuid.UUID(id).Value()
+}
+
+func (id *CompletedJobID) Scan(src any) error {
+	if id == nil {
+		return ErrEntityIsNil
+	}
+	val := uuid.Nil
+	if err := val.Scan(src); err != nil {
+		return err
+	}
+	*id = CompletedJobID(val)
+	return nil
+}
+
+func (id CompletedJobID) Validate() error {
+	if id.IsZero() {
+		return ErrZeroID
+	}
+	_, err := uuid.Parse(id.String())
+	return err
+}
+
+func (id CompletedJobID) Matches(x any) bool {
+	switch x.(type) {
+	case CompletedJobID:
+		if id == x.(CompletedJobID) {
+			return true
+		}
+	case *CompletedJobID:
+		if x.(*CompletedJobID) != nil && id == *x.(*CompletedJobID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (id CompletedJobID) IsZero() bool {
+	return id.String() == "" || id == CompletedJobIDNil
+}
+
 type EventID uuid.UUID
 
 var EventIDNil = EventID(uuid.Nil)
@@ -312,6 +382,76 @@ func (id JobID) IsZero() bool {
 	return id.String() == "" || id == JobIDNil
 }
 
+type JobScheduleID uuid.UUID
+
+var JobScheduleIDNil = JobScheduleID(uuid.Nil)
+
+func NewJobScheduleID() JobScheduleID {
+	return JobScheduleID(uuid.New())
+}
+
+func (id JobScheduleID) String() string {
+	return uuid.UUID(id).String()
+}
+
+func (id JobScheduleID) MarshalText() ([]byte, error) {
+	return []byte(uuid.UUID(id).String()), nil
+}
+
+func (id *JobScheduleID) UnmarshalText(text []byte) error {
+	if id == nil {
+		return ErrEntityIsNil
+	}
+	val, err := uuid.ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*id = JobScheduleID(val)
+	return nil
+}
+
+func (id JobScheduleID) Value() (driver.Value, error) {
+	return uuid.UUID(id).Value()
+}
+
+func (id *JobScheduleID) Scan(src any) error {
+	if id == nil {
+		return ErrEntityIsNil
+	}
+	val := uuid.Nil
+	if err := val.Scan(src); err != nil {
+		return err
+	}
+	*id = JobScheduleID(val)
+	return nil
+}
+
+func (id JobScheduleID) Validate() error {
+	if id.IsZero() {
+		return ErrZeroID
+	}
+	_, err := uuid.Parse(id.String())
+	return err
+}
+
+func (id JobScheduleID) Matches(x any) bool {
+	switch x.(type) {
+	case JobScheduleID:
+		if id == x.(JobScheduleID) {
+			return true
+		}
+	case *JobScheduleID:
+		if x.(*JobScheduleID) != nil && id == *x.(*JobScheduleID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (id JobScheduleID) IsZero() bool {
+	return id.String() == "" || id == JobScheduleIDNil
+}
+
 type MessageID uuid.UUID
 
 var MessageIDNil = MessageID(uuid.Nil)
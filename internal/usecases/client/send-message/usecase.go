@@ -7,6 +7,7 @@ import (
 	"time"
 
 	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	localverdictjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/local-verdict"
 	sendclientmessagejob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/send-client-message"
 	"github.com/gerladeno/chat-service/internal/types"
 )
@@ -40,7 +41,7 @@ type problemsRepository interface {
 }
 
 type outboxService interface {
-	Put(ctx context.Context, name, payload string, availableAt time.Time) (types.JobID, error)
+	PutUnique(ctx context.Context, name, payload, dedupKey string, availableAt time.Time) (types.JobID, error)
 }
 
 type transactor interface {
@@ -54,6 +55,9 @@ type Options struct {
 	outboxService outboxService      `option:"mandatory" validate:"required"`
 	problemRepo   problemsRepository `option:"mandatory" validate:"required"`
 	tx            transactor         `option:"mandatory" validate:"required"`
+
+	// afcFallbackSLA is the time given to AFC to check the message before the local check, zero disables it.
+	afcFallbackSLA time.Duration `validate:"min=0"`
 }
 
 type UseCase struct {
@@ -95,10 +99,20 @@ func (u UseCase) Handle(ctx context.Context, req Request) (Response, error) {
 			return fmt.Errorf("creating new message: %v", err)
 		}
 
-		if _, err = u.outboxService.Put(ctx, sendclientmessagejob.Name, msg.ID.String(), time.Now()); err != nil {
+		if _, err = u.outboxService.PutUnique(ctx,
+			sendclientmessagejob.Name, msg.ID.String(), sendclientmessagejob.DedupKey(msg.ID), time.Now(),
+		); err != nil {
 			return fmt.Errorf("creating a job for message publishing: %v", err)
 		}
 
+		if u.afcFallbackSLA > 0 {
+			if _, err = u.outboxService.PutUnique(ctx,
+				localverdictjob.Name, msg.ID.String(), localverdictjob.DedupKey(msg.ID), time.Now().Add(u.afcFallbackSLA),
+			); err != nil {
+				return fmt.Errorf("creating a job for local message check: %v", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return Response{}, err
@@ -3,6 +3,7 @@ package sendmessage
 
 import (
 	fmt461e464ebed9 "fmt"
+	"time"
 
 	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
 	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
@@ -34,6 +35,12 @@ func NewOptions(
 	return o
 }
 
+func WithAfcFallbackSLA(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.afcFallbackSLA = opt
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("chatRepo", _validate_Options_chatRepo(o)))
@@ -41,6 +48,7 @@ func (o *Options) Validate() error {
 	errs.Add(errors461e464ebed9.NewValidationError("outboxService", _validate_Options_outboxService(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("problemRepo", _validate_Options_problemRepo(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("tx", _validate_Options_tx(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("afcFallbackSLA", _validate_Options_afcFallbackSLA(o)))
 	return errs.AsError()
 }
 
@@ -78,3 +86,10 @@ func _validate_Options_tx(o *Options) error {
 	}
 	return nil
 }
+
+func _validate_Options_afcFallbackSLA(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.afcFallbackSLA, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `afcFallbackSLA` did not pass the test: %w", err)
+	}
+	return nil
+}
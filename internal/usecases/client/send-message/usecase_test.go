@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	messagesrepo "github.com/gerladeno/chat-service/internal/repositories/messages"
+	localverdictjob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/local-verdict"
 	sendclientmessagejob "github.com/gerladeno/chat-service/internal/services/outbox/jobs/send-client-message"
 	"github.com/gerladeno/chat-service/internal/testingh"
 	"github.com/gerladeno/chat-service/internal/types"
@@ -235,7 +236,7 @@ func (s *UseCaseSuite) TestPutJobError() {
 	s.problemRepo.EXPECT().CreateIfNotExists(gomock.Any(), chatID).Return(problemID, nil)
 	s.msgRepo.EXPECT().CreateClientVisible(gomock.Any(), reqID, problemID, chatID, clientID, msgBody).
 		Return(&messagesrepo.Message{ID: types.NewMessageID()}, nil)
-	s.outBoxSvc.EXPECT().Put(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any()).
+	s.outBoxSvc.EXPECT().PutUnique(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(types.JobIDNil, errors.New("unexpected"))
 
 	req := sendmessage.Request{
@@ -269,7 +270,7 @@ func (s *UseCaseSuite) TestTransactionError() {
 	s.problemRepo.EXPECT().CreateIfNotExists(gomock.Any(), chatID).Return(problemID, nil)
 	s.msgRepo.EXPECT().CreateClientVisible(gomock.Any(), reqID, problemID, chatID, clientID, msgBody).
 		Return(&messagesrepo.Message{ID: types.NewMessageID()}, nil)
-	s.outBoxSvc.EXPECT().Put(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any()).
+	s.outBoxSvc.EXPECT().PutUnique(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(types.NewJobID(), nil)
 
 	req := sendmessage.Request{
@@ -317,7 +318,7 @@ func (s *UseCaseSuite) TestNewMsgCreatedSuccessfully() {
 			IsBlocked:           false,
 			IsService:           false,
 		}, nil)
-	s.outBoxSvc.EXPECT().Put(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any()).
+	s.outBoxSvc.EXPECT().PutUnique(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(types.NewJobID(), nil)
 
 	req := sendmessage.Request{
@@ -335,3 +336,46 @@ func (s *UseCaseSuite) TestNewMsgCreatedSuccessfully() {
 	s.Require().Equal(messageID, resp.MessageID)
 	s.Require().True(createdAt.Equal(resp.CreatedAt))
 }
+
+func (s *UseCaseSuite) TestLocalVerdictJobScheduled() {
+	// Arrange.
+	const sla = time.Minute
+	uCase, err := sendmessage.New(sendmessage.NewOptions(s.chatRepo, s.msgRepo, s.outBoxSvc, s.problemRepo, s.txtor,
+		sendmessage.WithAfcFallbackSLA(sla)))
+	s.Require().NoError(err)
+
+	reqID := types.NewRequestID()
+	clientID := types.NewUserID()
+	chatID := types.NewChatID()
+	problemID := types.NewProblemID()
+	const msgBody = "Hello!"
+	messageID := types.NewMessageID()
+
+	s.txtor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+	s.msgRepo.EXPECT().GetMessageByRequestID(gomock.Any(), reqID).Return(nil, messagesrepo.ErrMsgNotFound)
+	s.chatRepo.EXPECT().CreateIfNotExists(gomock.Any(), clientID).Return(chatID, nil)
+	s.problemRepo.EXPECT().CreateIfNotExists(gomock.Any(), chatID).Return(problemID, nil)
+	s.msgRepo.EXPECT().CreateClientVisible(gomock.Any(), reqID, problemID, chatID, clientID, msgBody).
+		Return(&messagesrepo.Message{ID: messageID, ChatID: chatID, AuthorID: clientID, Body: msgBody}, nil)
+	s.outBoxSvc.EXPECT().PutUnique(gomock.Any(), sendclientmessagejob.Name, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(types.NewJobID(), nil)
+	s.outBoxSvc.EXPECT().PutUnique(gomock.Any(), localverdictjob.Name, messageID.String(),
+		localverdictjob.DedupKey(messageID), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, availableAt time.Time) (types.JobID, error) {
+			s.WithinDuration(time.Now().Add(sla), availableAt, time.Second)
+			return types.NewJobID(), nil
+		})
+
+	// Action.
+	_, err = uCase.Handle(s.Ctx, sendmessage.Request{
+		ID:          reqID,
+		ClientID:    clientID,
+		MessageBody: msgBody,
+	})
+
+	// Assert.
+	s.Require().NoError(err)
+}
@@ -1,13 +1,28 @@
 package websocketstream
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gerladeno/chat-service/internal/eventspb"
 	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
 )
 
+// The subprotocols of the binary formats are the base one with the suffix, e.g. chat-service-protocol.msgpack.
+const (
+	SubprotocolSuffixMsgPack  = ".msgpack"
+	SubprotocolSuffixProtobuf = ".protobuf"
+)
+
+var ErrNoProtoSchema = errors.New("event has no protobuf schema")
+
 // EventAdapter converts the event from the stream to the appropriate object.
 type EventAdapter interface {
 	Adapt(event eventstream.Event) (any, error)
@@ -16,6 +31,26 @@ type EventAdapter interface {
 // EventWriter write adapted event it to the socket.
 type EventWriter interface {
 	Write(event any, out io.Writer) error
+	// MessageType is the type of the frames the events are written in.
+	MessageType() int
+}
+
+// Subprotocols returns the subprotocols derived from the base one in the order of preference.
+func Subprotocols(secWsProtocol string) []string {
+	return []string{
+		secWsProtocol + SubprotocolSuffixMsgPack,
+		secWsProtocol + SubprotocolSuffixProtobuf,
+		secWsProtocol,
+	}
+}
+
+// EventWriters returns the writers by the subprotocols derived from the base one.
+func EventWriters(secWsProtocol string) map[string]EventWriter {
+	return map[string]EventWriter{
+		secWsProtocol + SubprotocolSuffixMsgPack:  MsgPackEventWriter{},
+		secWsProtocol + SubprotocolSuffixProtobuf: ProtobufEventWriter{},
+		secWsProtocol: JSONEventWriter{},
+	}
 }
 
 type JSONEventWriter struct{}
@@ -26,3 +61,90 @@ func (JSONEventWriter) Write(event any, out io.Writer) error {
 	}
 	return nil
 }
+
+func (JSONEventWriter) MessageType() int {
+	return gorillaws.TextMessage
+}
+
+// MsgPackEventWriter writes the JSON document of the event in MessagePack.
+type MsgPackEventWriter struct{}
+
+func (MsgPackEventWriter) Write(event any, out io.Writer) error {
+	doc, err := jsonDocument(event)
+	if err != nil {
+		return err
+	}
+	enc := msgpack.NewEncoder(out)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode event to msgpack: %v", err)
+	}
+	return nil
+}
+
+func (MsgPackEventWriter) MessageType() int {
+	return gorillaws.BinaryMessage
+}
+
+// ProtoEvent is the adapted event having the protobuf schema, see api/events.proto.
+type ProtoEvent interface {
+	ProtoEvent() *eventspb.Event
+}
+
+// ProtobufEventWriter writes the events as eventspb.Event, they must implement ProtoEvent.
+type ProtobufEventWriter struct{}
+
+func (ProtobufEventWriter) Write(event any, out io.Writer) error {
+	pe, ok := event.(ProtoEvent)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrNoProtoSchema, event)
+	}
+	data, err := proto.Marshal(pe.ProtoEvent())
+	if err != nil {
+		return fmt.Errorf("encode event to protobuf: %v", err)
+	}
+	if _, err := out.Write(data); err != nil {
+		return fmt.Errorf("write protobuf event: %v", err)
+	}
+	return nil
+}
+
+func (ProtobufEventWriter) MessageType() int {
+	return gorillaws.BinaryMessage
+}
+
+// jsonDocument returns the event as it is seen in JSON: the field names, ids and times are the same in every format.
+func jsonDocument(event any) (any, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("encode event to json: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode event json: %v", err)
+	}
+	return normalizeNumbers(doc), nil
+}
+
+// normalizeNumbers keeps the integers integer instead of float64.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, vv := range v {
+			v[k] = normalizeNumbers(vv)
+		}
+	case []any:
+		for i, vv := range v {
+			v[i] = normalizeNumbers(vv)
+		}
+	}
+	return v
+}
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"testing"
 
+	gorillaws "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/gerladeno/chat-service/internal/eventspb"
+	"github.com/gerladeno/chat-service/internal/types"
 	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
 )
 
@@ -16,4 +21,80 @@ func TestJSONEventWriter_Smoke(t *testing.T) {
 	err := wr.Write(struct{ Name string }{Name: "John"}, out)
 	require.NoError(t, err)
 	assert.JSONEq(t, `{"Name":"John"}`, out.String())
+	assert.Equal(t, gorillaws.TextMessage, wr.MessageType())
+}
+
+func TestMsgPackEventWriter(t *testing.T) {
+	wr := websocketstream.MsgPackEventWriter{}
+	assert.Equal(t, gorillaws.BinaryMessage, wr.MessageType())
+
+	out := bytes.NewBuffer(nil)
+	err := wr.Write(testEvent(), out)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, msgpack.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, map[string]any{
+		"id":   "b611c338-1e11-11ed-b5ce-461e464ebed9",
+		"name": "John",
+		"age":  int8(42),
+		"tags": []any{"a", "b"},
+	}, got)
+}
+
+func TestProtobufEventWriter(t *testing.T) {
+	wr := websocketstream.ProtobufEventWriter{}
+	assert.Equal(t, gorillaws.BinaryMessage, wr.MessageType())
+
+	out := bytes.NewBuffer(nil)
+	err := wr.Write(testProtoEvent{MessageID: "b611c338-1e11-11ed-b5ce-461e464ebed9"}, out)
+	require.NoError(t, err)
+
+	var got eventspb.Event
+	require.NoError(t, proto.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "b611c338-1e11-11ed-b5ce-461e464ebed9", got.GetMessageSentEvent().GetMessageId())
+}
+
+func TestProtobufEventWriter_NoSchema(t *testing.T) {
+	err := websocketstream.ProtobufEventWriter{}.Write(testEvent(), bytes.NewBuffer(nil))
+	assert.ErrorIs(t, err, websocketstream.ErrNoProtoSchema)
+}
+
+func TestEventWriters(t *testing.T) {
+	const base = "chat-service-protocol"
+
+	writers := websocketstream.EventWriters(base)
+	for _, p := range websocketstream.Subprotocols(base) {
+		assert.Contains(t, writers, p)
+	}
+	assert.IsType(t, websocketstream.JSONEventWriter{}, writers[base])
+	assert.IsType(t, websocketstream.MsgPackEventWriter{}, writers[base+websocketstream.SubprotocolSuffixMsgPack])
+	assert.IsType(t, websocketstream.ProtobufEventWriter{}, writers[base+websocketstream.SubprotocolSuffixProtobuf])
+}
+
+type event struct {
+	ID   types.MessageID `json:"id"`
+	Name string          `json:"name"`
+	Age  int             `json:"age"`
+	Tags []string        `json:"tags"`
+	Skip string          `json:"skip,omitempty"`
+}
+
+func testEvent() event {
+	return event{
+		ID:   types.MustParse[types.MessageID]("b611c338-1e11-11ed-b5ce-461e464ebed9"),
+		Name: "John",
+		Age:  42,
+		Tags: []string{"a", "b"},
+	}
+}
+
+type testProtoEvent struct {
+	MessageID string
+}
+
+func (e testProtoEvent) ProtoEvent() *eventspb.Event {
+	return &eventspb.Event{Event: &eventspb.Event_MessageSentEvent{
+		MessageSentEvent: &eventspb.MessageSentEvent{MessageId: e.MessageID},
+	}}
 }
@@ -1,9 +1,10 @@
 package websocketstream
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -15,10 +16,7 @@ import (
 	"github.com/gerladeno/chat-service/internal/types"
 )
 
-const (
-	writeTimeout = time.Second
-	pingPeriod   = 250 * time.Millisecond
-)
+var errPongWaitLessThanPing = errors.New("pong wait must exceed ping period")
 
 type eventStream interface {
 	Subscribe(ctx context.Context, userID types.UserID) (<-chan eventstream.Event, error)
@@ -26,7 +24,14 @@ type eventStream interface {
 
 //go:generate options-gen -out-filename=handler_options.gen.go -from-struct=Options
 type Options struct {
-	pingPeriod time.Duration `default:"3s" validate:"omitempty,min=100ms,max=30s"`
+	pingPeriod time.Duration `default:"3s" validate:"min=10ms,max=30s"`
+	// pongWait is how long the connection lives without the pong or another frame from the client.
+	pongWait     time.Duration `default:"10s" validate:"min=10ms,max=5m"`
+	writeTimeout time.Duration `default:"1s" validate:"min=10ms,max=1m"`
+	// readLimit is the max size of the client frame, the bigger one closes the connection with 1009.
+	readLimit int64 `default:"16384" validate:"min=512"`
+	// compressionThreshold is the min size of the message compressed if permessage-deflate is negotiated.
+	compressionThreshold int `default:"512" validate:"min=0"`
 
 	logger       *zap.Logger     `option:"mandatory" validate:"required"`
 	eventStream  eventStream     `option:"mandatory" validate:"required"`
@@ -34,6 +39,13 @@ type Options struct {
 	eventWriter  EventWriter     `option:"mandatory" validate:"required"`
 	upgrader     Upgrader        `option:"mandatory" validate:"required"`
 	shutdownCh   <-chan struct{} `option:"mandatory" validate:"required"`
+
+	// eventWriters are chosen by the negotiated subprotocol, eventWriter is used if none matches.
+	eventWriters map[string]EventWriter
+	// registry limits the connections, the one with the default limits is used if not set.
+	registry *Registry
+	// tokenVerifier enables the in-band re-auth prolonging the session, see authFrame.
+	tokenVerifier TokenVerifier
 }
 
 type HTTPHandler struct {
@@ -44,94 +56,155 @@ func NewHTTPHandler(opts Options) (*HTTPHandler, error) {
 	if err := opts.Validate(); err != nil {
 		return nil, fmt.Errorf("validating ws http handler options: %v", err)
 	}
+	if opts.pongWait <= opts.pingPeriod {
+		return nil, errPongWaitLessThanPing
+	}
+	if opts.registry == nil {
+		r, err := NewRegistry(NewRegistryOptions())
+		if err != nil {
+			return nil, fmt.Errorf("create ws registry: %v", err)
+		}
+		opts.registry = r
+	}
 	return &HTTPHandler{
 		Options: opts,
 	}, nil
 }
 
+// Serve upgrades the connection and streams the user events into it.
+// The connection over the registry limits is closed with the policy violation code,
+// the one outlived its token is closed with CloseSessionExpired.
 func (h *HTTPHandler) Serve(eCtx echo.Context) error {
-	ctx := eCtx.Request().Context()
 	userID := middlewares.MustUserID(eCtx)
 	ws, err := h.upgrader.Upgrade(eCtx.Response().Writer, eCtx.Request(), eCtx.Response().Header())
 	if err != nil {
 		return fmt.Errorf("upgrade connection to ws: %v", err)
 	}
+	ws.SetReadLimit(h.readLimit)
 	closer := newWsCloser(h.logger, ws)
-	eventsCh, err := h.eventStream.Subscribe(context.Background(), userID)
+
+	conn, err := h.registry.register(userID, eCtx.RealIP(), ws.Subprotocol(), closer.Close)
+	if err != nil {
+		h.logger.Warn("ws connection rejected", zap.Stringer("user_id", userID), zap.Error(err))
+		go closer.Close(websocket.ClosePolicyViolation)
+		return nil
+	}
+
+	// The request context is done once the connection is hijacked, so the connection has its own.
+	ctx, cancel := context.WithCancel(context.Background())
+	eventsCh, err := h.eventStream.Subscribe(ctx, userID)
 	if err != nil {
+		cancel()
+		h.registry.unregister(userID, conn)
+		go closer.Close(websocket.CloseInternalServerErr)
 		return fmt.Errorf("subscribe on event stream: %v", err)
 	}
+	expiresAt, _ := middlewares.TokenExpiresAt(eCtx)
+	sess := newSession(userID, expiresAt, func() { closer.Close(CloseSessionExpired) })
 	go func() {
-		if err = h.readLoop(ctx, ws); err != nil {
+		// The connection without pongs is not closed by the client.
+		defer closer.Close(websocket.CloseGoingAway)
+		defer h.registry.unregister(userID, conn)
+		defer cancel()
+		defer sess.stop()
+		if err := h.readLoop(ctx, ws, sess, closer); err != nil {
 			h.logger.Warn("ws readLoop", zap.Error(err))
 		}
 	}()
 	go func() {
-		if err = h.writeLoop(ctx, ws, eventsCh); err != nil {
+		if err := h.writeLoop(ctx, ws, h.writerFor(ws.Subprotocol()), conn, eventsCh); err != nil {
 			h.logger.Warn("ws writeLoop", zap.Error(err))
 		}
 	}()
 	go func() {
-		<-h.shutdownCh
-		closer.Close(websocket.CloseNormalClosure)
+		select {
+		case <-h.shutdownCh:
+			closer.Close(websocket.CloseNormalClosure)
+		case <-ctx.Done():
+		}
 	}()
 	return nil
 }
 
-// readLoop listen PONGs.
-func (h *HTTPHandler) readLoop(_ context.Context, ws Websocket) error {
-	var err error
+func (h *HTTPHandler) writerFor(subprotocol string) EventWriter {
+	if w, ok := h.eventWriters[subprotocol]; ok {
+		return w
+	}
+	return h.eventWriter
+}
+
+// readLoop listen PONGs and re-auth frames.
+func (h *HTTPHandler) readLoop(ctx context.Context, ws Websocket, sess *session, closer *wsCloser) error {
+	_ = ws.SetReadDeadline(time.Now().Add(h.pongWait))
+	ws.SetPongHandler(func(string) error {
+		_ = ws.SetReadDeadline(time.Now().Add(h.pongWait))
+		return nil
+	})
+
 	for {
-		_, _, err = ws.NextReader()
+		_, r, err := ws.NextReader()
 		if err != nil {
 			return fmt.Errorf("get next reader: %v", err)
 		}
-		_ = ws.SetReadDeadline(time.Now().Add(pingPeriod))
-		ws.SetPongHandler(func(string) error {
-			_ = ws.SetReadDeadline(time.Now().Add(pingPeriod))
-			return nil
-		})
+		_ = ws.SetReadDeadline(time.Now().Add(h.pongWait))
+
+		if h.tokenVerifier == nil {
+			continue
+		}
+		if err := sess.reauth(ctx, h.tokenVerifier, r); err != nil {
+			h.logger.Warn("ws reauth", zap.Stringer("user_id", sess.userID), zap.Error(err))
+			if errors.Is(err, errAnotherUser) {
+				go closer.Close(websocket.ClosePolicyViolation)
+			}
+		}
 	}
 }
 
 // writeLoop listen events and writes them into Websocket.
-func (h *HTTPHandler) writeLoop(_ context.Context, ws Websocket, events <-chan eventstream.Event) error {
-	var err error
-	var adapted any
-	var event eventstream.Event
-	var w io.WriteCloser
-	t := time.NewTicker(pingPeriod)
-	defer t.Stop()
-	for {
-		select {
-		case <-t.C:
-			_ = ws.SetWriteDeadline(time.Now().Add(writeTimeout))
-			if err = ws.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return fmt.Errorf("ping error: %v", err)
-			}
-		case event = <-events:
-			adapted, err = h.eventAdapter.Adapt(event)
-			if err != nil {
-				return fmt.Errorf("adapt event: %v", err)
-			}
-			_ = ws.SetWriteDeadline(time.Now().Add(writeTimeout))
-			if err = func() error {
-				if w, err = ws.NextWriter(websocket.TextMessage); err != nil {
-					return fmt.Errorf("get next writer: %v", err)
-				}
-				defer func() {
-					if err = w.Close(); err != nil {
-						h.logger.Warn("ws close error", zap.Error(err))
-					}
-				}()
-				err = JSONEventWriter{}.Write(adapted, w)
-				if err != nil {
-					return fmt.Errorf("write encoded message to the connection: %v", err)
-				}
-				return nil
-			}(); err != nil {
-				return err
-			}
-		}
+func (h *HTTPHandler) writeLoop(
+	ctx context.Context,
+	ws Websocket,
+	eventWriter EventWriter,
+	conn *conn,
+	events <-chan eventstream.Event,
+) error {
+	return pumpEvents(ctx, events, h.eventAdapter, h.pingPeriod, &wsSink{
+		ws:                   ws,
+		writer:               eventWriter,
+		conn:                 conn,
+		writeTimeout:         h.writeTimeout,
+		compressionThreshold: h.compressionThreshold,
+	})
+}
+
+type wsSink struct {
+	ws                   Websocket
+	writer               EventWriter
+	conn                 *conn
+	writeTimeout         time.Duration
+	compressionThreshold int
+}
+
+func (s *wsSink) heartbeat() error {
+	_ = s.ws.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	if err := s.ws.WriteMessage(websocket.PingMessage, nil); err != nil {
+		return fmt.Errorf("ping error: %v", err)
 	}
+	return nil
+}
+
+// writeEvent encodes the whole message first to compress only the big ones.
+func (s *wsSink) writeEvent(_ types.EventID, adapted any) error {
+	var buf bytes.Buffer
+	if err := s.writer.Write(adapted, &buf); err != nil {
+		return fmt.Errorf("encode message: %v", err)
+	}
+
+	_ = s.ws.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	s.ws.EnableWriteCompression(buf.Len() >= s.compressionThreshold)
+	if err := s.ws.WriteMessage(s.writer.MessageType(), buf.Bytes()); err != nil {
+		return fmt.Errorf("write message to the connection: %v", err)
+	}
+	s.conn.bytesSent.Add(int64(buf.Len()))
+	return nil
 }
@@ -25,6 +25,10 @@ func NewOptions(
 
 	// Setting defaults from field tag (if present)
 	o.pingPeriod, _ = time.ParseDuration("3s")
+	o.pongWait, _ = time.ParseDuration("10s")
+	o.writeTimeout, _ = time.ParseDuration("1s")
+	o.readLimit = 16384
+	o.compressionThreshold = 512
 
 	o.logger = logger
 	o.eventStream = eventStream
@@ -45,9 +49,55 @@ func WithPingPeriod(opt time.Duration) OptOptionsSetter {
 	}
 }
 
+func WithPongWait(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.pongWait = opt
+	}
+}
+
+func WithWriteTimeout(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.writeTimeout = opt
+	}
+}
+
+func WithReadLimit(opt int64) OptOptionsSetter {
+	return func(o *Options) {
+		o.readLimit = opt
+	}
+}
+
+func WithCompressionThreshold(opt int) OptOptionsSetter {
+	return func(o *Options) {
+		o.compressionThreshold = opt
+	}
+}
+
+func WithEventWriters(opt map[string]EventWriter) OptOptionsSetter {
+	return func(o *Options) {
+		o.eventWriters = opt
+	}
+}
+
+func WithRegistry(opt *Registry) OptOptionsSetter {
+	return func(o *Options) {
+		o.registry = opt
+	}
+}
+
+func WithTokenVerifier(opt TokenVerifier) OptOptionsSetter {
+	return func(o *Options) {
+		o.tokenVerifier = opt
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("pingPeriod", _validate_Options_pingPeriod(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("pongWait", _validate_Options_pongWait(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("writeTimeout", _validate_Options_writeTimeout(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("readLimit", _validate_Options_readLimit(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("compressionThreshold", _validate_Options_compressionThreshold(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("logger", _validate_Options_logger(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("eventStream", _validate_Options_eventStream(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("eventAdapter", _validate_Options_eventAdapter(o)))
@@ -58,12 +108,40 @@ func (o *Options) Validate() error {
 }
 
 func _validate_Options_pingPeriod(o *Options) error {
-	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.pingPeriod, "omitempty,min=100ms,max=30s"); err != nil {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.pingPeriod, "min=10ms,max=30s"); err != nil {
 		return fmt461e464ebed9.Errorf("field `pingPeriod` did not pass the test: %w", err)
 	}
 	return nil
 }
 
+func _validate_Options_pongWait(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.pongWait, "min=10ms,max=5m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `pongWait` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_writeTimeout(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.writeTimeout, "min=10ms,max=1m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `writeTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_readLimit(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.readLimit, "min=512"); err != nil {
+		return fmt461e464ebed9.Errorf("field `readLimit` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_compressionThreshold(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.compressionThreshold, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `compressionThreshold` did not pass the test: %w", err)
+	}
+	return nil
+}
+
 func _validate_Options_logger(o *Options) error {
 	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.logger, "required"); err != nil {
 		return fmt461e464ebed9.Errorf("field `logger` did not pass the test: %w", err)
@@ -2,6 +2,7 @@ package websocketstream_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,8 +15,11 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/gerladeno/chat-service/internal/eventspb"
 	"github.com/gerladeno/chat-service/internal/logger"
 	"github.com/gerladeno/chat-service/internal/middlewares"
 	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
@@ -57,7 +61,7 @@ func TestHTTPHandler(t *testing.T) {
 		eventStreamMock{uid: uid, ch: eventsCh},
 		eventAdapter{},
 		websocketstream.JSONEventWriter{},
-		websocketstream.NewUpgrader([]string{origin}, secWsProtocol),
+		newUpgrader(t, origin, secWsProtocol),
 		shutdownCh,
 		websocketstream.WithPingPeriod(pingInterval),
 	))
@@ -143,6 +147,17 @@ func TestHTTPHandler(t *testing.T) {
 	})
 }
 
+func newUpgrader(t *testing.T, origin string, secWsProtocols ...string) websocketstream.Upgrader {
+	t.Helper()
+
+	u, err := websocketstream.NewUpgrader(websocketstream.NewUpgraderOptions(
+		[]string{origin},
+		websocketstream.WithSecWsProtocols(secWsProtocols),
+	))
+	require.NoError(t, err)
+	return u
+}
+
 type eventStreamMock struct {
 	ch  chan eventstream.Event
 	uid types.UserID
@@ -158,5 +173,111 @@ func (e eventStreamMock) Subscribe(_ context.Context, userID types.UserID) (<-ch
 type eventAdapter struct{}
 
 func (eventAdapter) Adapt(event eventstream.Event) (any, error) {
+	if v, ok := event.(*eventstream.MessageSentEvent); ok {
+		return messageSentEvent{v}, nil
+	}
 	return event, nil
 }
+
+// messageSentEvent is the stream event in JSON and MessagePack.
+type messageSentEvent struct{ *eventstream.MessageSentEvent }
+
+func (e messageSentEvent) ProtoEvent() *eventspb.Event {
+	return &eventspb.Event{Event: &eventspb.Event_MessageSentEvent{
+		MessageSentEvent: &eventspb.MessageSentEvent{EventId: e.EventID.String()},
+	}}
+}
+
+func TestHTTPHandler_Subprotocols(t *testing.T) {
+	const (
+		origin        = "http://localhost"
+		secWsProtocol = "chat-service-protocol.test"
+	)
+
+	cases := []struct {
+		name        string
+		offered     []string
+		negotiated  string
+		messageType int
+		decode      func(t *testing.T, data []byte) map[string]any
+	}{
+		{
+			name:        "json",
+			offered:     []string{secWsProtocol},
+			negotiated:  secWsProtocol,
+			messageType: gorillaws.TextMessage,
+			decode: func(t *testing.T, data []byte) map[string]any {
+				var v map[string]any
+				require.NoError(t, json.Unmarshal(data, &v))
+				return v
+			},
+		},
+		{
+			name:        "msgpack is preferred",
+			offered:     []string{secWsProtocol, secWsProtocol + websocketstream.SubprotocolSuffixMsgPack},
+			negotiated:  secWsProtocol + websocketstream.SubprotocolSuffixMsgPack,
+			messageType: gorillaws.BinaryMessage,
+			decode: func(t *testing.T, data []byte) map[string]any {
+				var v map[string]any
+				require.NoError(t, msgpack.Unmarshal(data, &v))
+				return v
+			},
+		},
+		{
+			name:        "protobuf",
+			offered:     []string{secWsProtocol + websocketstream.SubprotocolSuffixProtobuf},
+			negotiated:  secWsProtocol + websocketstream.SubprotocolSuffixProtobuf,
+			messageType: gorillaws.BinaryMessage,
+			decode: func(t *testing.T, data []byte) map[string]any {
+				var v eventspb.Event
+				require.NoError(t, proto.Unmarshal(data, &v))
+				return map[string]any{"EventID": v.GetMessageSentEvent().GetEventId()}
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := types.NewUserID()
+			eventsCh := make(chan eventstream.Event, 1)
+			shutdownCh := make(chan struct{})
+			defer close(shutdownCh)
+
+			h, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+				zap.L(),
+				eventStreamMock{uid: uid, ch: eventsCh},
+				eventAdapter{},
+				websocketstream.JSONEventWriter{},
+				newUpgrader(t, origin, websocketstream.Subprotocols(secWsProtocol)...),
+				shutdownCh,
+				websocketstream.WithEventWriters(websocketstream.EventWriters(secWsProtocol)),
+			))
+			require.NoError(t, err)
+
+			e := echo.New()
+			e.GET("/ws", middlewares.AuthWith(uid)(h.Serve))
+			s := httptest.NewServer(e)
+			defer s.Close()
+
+			dialer := gorillaws.Dialer{Subprotocols: tt.offered}
+			header := http.Header{}
+			header.Add(echo.HeaderOrigin, origin)
+			c, resp, err := dialer.Dial("ws://"+s.Listener.Addr().String()+"/ws", header)
+			require.NoError(t, err)
+			defer func() {
+				_ = c.Close()
+				_ = resp.Body.Close()
+			}()
+			assert.Equal(t, tt.negotiated, c.Subprotocol())
+
+			eventID := types.NewEventID()
+			eventsCh <- &eventstream.MessageSentEvent{CoreEventFields: eventstream.CoreEventFields{EventID: eventID}}
+
+			messageType, data, err := c.ReadMessage()
+			require.NoError(t, err)
+			assert.Equal(t, tt.messageType, messageType)
+			assert.Equal(t, eventID.String(), tt.decode(t, data)["EventID"])
+		})
+	}
+}
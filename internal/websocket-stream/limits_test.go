@@ -0,0 +1,224 @@
+package websocketstream_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+const (
+	limitsOrigin   = "http://localhost"
+	limitsProtocol = "chat-service-protocol.test"
+)
+
+func TestHTTPHandler_PingPongKeepAlive(t *testing.T) {
+	const (
+		pingPeriod = 50 * time.Millisecond
+		pongWait   = 150 * time.Millisecond
+	)
+
+	// Arrange.
+	eventsCh := make(chan eventstream.Event)
+	registry, err := websocketstream.NewRegistry(websocketstream.NewRegistryOptions())
+	require.NoError(t, err)
+
+	s := newLimitsServer(t, eventsCh, eventAdapter{},
+		websocketstream.WithPingPeriod(pingPeriod),
+		websocketstream.WithPongWait(pongWait),
+		websocketstream.WithRegistry(registry),
+	)
+
+	alive, _ := dialLimitsServer(t, s, gorillaws.Dialer{})
+	var pings atomic.Int32
+	alive.SetPingHandler(func(data string) error {
+		pings.Add(1)
+		return alive.WriteControl(gorillaws.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	received := make(chan string, 1)
+	go func() {
+		for {
+			_, data, err := alive.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- string(data)
+		}
+	}()
+
+	// The silent client reads nothing and so answers no pings.
+	_, _ = dialLimitsServer(t, s, gorillaws.Dialer{})
+	require.Len(t, registry.Connections(), 2)
+
+	// Action & assert.
+	require.Eventually(t, func() bool {
+		return len(registry.Connections()) == 1
+	}, 10*pongWait, pingPeriod, "silent client is disconnected")
+
+	time.Sleep(3 * pongWait)
+	assert.Len(t, registry.Connections(), 1, "answering client is alive")
+	assert.GreaterOrEqual(t, pings.Load(), int32(3*pongWait/pingPeriod))
+
+	event := eventstream.NewMessageSentEvent(types.NewEventID(), types.NewRequestID(), types.NewMessageID())
+	eventsCh <- event
+	select {
+	case data := <-received:
+		assert.Contains(t, data, event.ID().String())
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+func TestHTTPHandler_Compression(t *testing.T) {
+	payload := strings.Repeat("compress me ", 1000)
+
+	cases := []struct {
+		name       string
+		threshold  int
+		compressed bool
+	}{
+		{name: "big message is compressed", threshold: len(payload) / 2, compressed: true},
+		{name: "small message is not compressed", threshold: len(payload) * 2, compressed: false},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange.
+			eventsCh := make(chan eventstream.Event)
+			s := newLimitsServer(t, eventsCh, constAdapter(payload), websocketstream.WithCompressionThreshold(tt.threshold))
+
+			var read atomic.Int64
+			c, resp := dialLimitsServer(t, s, gorillaws.Dialer{
+				EnableCompression: true,
+				NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+					return countingConn{Conn: conn, read: &read}, err
+				},
+			})
+			assert.Contains(t, resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+			handshakeSize := read.Load()
+
+			// Action.
+			eventsCh <- eventstream.NewMessageSentEvent(types.NewEventID(), types.NewRequestID(), types.NewMessageID())
+			_, data, err := c.ReadMessage()
+
+			// Assert.
+			require.NoError(t, err)
+			assert.Contains(t, string(data), payload)
+			if wire := read.Load() - handshakeSize; tt.compressed {
+				assert.Less(t, wire, int64(len(payload)/10))
+			} else {
+				assert.Greater(t, wire, int64(len(payload)))
+			}
+		})
+	}
+}
+
+func TestHTTPHandler_ReadLimit(t *testing.T) {
+	const readLimit = 512
+
+	s := newLimitsServer(t, make(chan eventstream.Event), eventAdapter{}, websocketstream.WithReadLimit(readLimit))
+	c, _ := dialLimitsServer(t, s, gorillaws.Dialer{})
+
+	require.NoError(t, c.WriteMessage(gorillaws.TextMessage, make([]byte, 2*readLimit)))
+
+	_ = c.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, _, err := c.ReadMessage()
+	assert.True(t, gorillaws.IsCloseError(err, gorillaws.CloseMessageTooBig), err)
+}
+
+func TestNewHTTPHandler_PongWaitLessThanPing(t *testing.T) {
+	_, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+		zap.L(),
+		anyUserEventStream(nil),
+		eventAdapter{},
+		websocketstream.JSONEventWriter{},
+		newUpgrader(t, limitsOrigin),
+		make(chan struct{}),
+		websocketstream.WithPingPeriod(time.Second),
+		websocketstream.WithPongWait(time.Second),
+	))
+	require.Error(t, err)
+}
+
+func newLimitsServer(
+	t *testing.T,
+	eventsCh chan eventstream.Event,
+	adapter websocketstream.EventAdapter,
+	opts ...websocketstream.OptOptionsSetter,
+) *httptest.Server {
+	t.Helper()
+
+	shutdownCh := make(chan struct{})
+	t.Cleanup(func() { close(shutdownCh) })
+
+	upgrader, err := websocketstream.NewUpgrader(websocketstream.NewUpgraderOptions(
+		[]string{limitsOrigin},
+		websocketstream.WithSecWsProtocols([]string{limitsProtocol}),
+		websocketstream.WithCompression(true),
+	))
+	require.NoError(t, err)
+
+	h, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+		zap.L(),
+		anyUserEventStream(eventsCh),
+		adapter,
+		websocketstream.JSONEventWriter{},
+		upgrader,
+		shutdownCh,
+		opts...,
+	))
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.GET("/ws", func(c echo.Context) error {
+		return middlewares.AuthWith(types.NewUserID())(h.Serve)(c)
+	})
+	s := httptest.NewServer(e)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func dialLimitsServer(t *testing.T, s *httptest.Server, dialer gorillaws.Dialer) (*gorillaws.Conn, *http.Response) {
+	t.Helper()
+
+	dialer.Subprotocols = []string{limitsProtocol}
+	c, resp, err := dialer.Dial("ws://"+s.Listener.Addr().String()+"/ws", http.Header{echo.HeaderOrigin: {limitsOrigin}})
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	t.Cleanup(func() { _ = c.Close() })
+	return c, resp
+}
+
+type constAdapter string
+
+func (a constAdapter) Adapt(eventstream.Event) (any, error) {
+	return string(a), nil
+}
+
+type countingConn struct {
+	net.Conn
+	read *atomic.Int64
+}
+
+func (c countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.read.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,220 @@
+package websocketstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	servererrors "github.com/gerladeno/chat-service/internal/errors"
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+var errIdleLessThanPoll = errors.New("idle timeout must exceed poll timeout")
+
+//go:generate options-gen -out-filename=poll_options.gen.go -from-struct=PollOptions
+type PollOptions struct {
+	pollTimeout time.Duration `default:"25s" validate:"omitempty,min=1s,max=1m"`
+	backlogSize int           `default:"100" validate:"omitempty,min=1,max=10000"`
+	// idleTimeout is how long the user events are kept after the last poll.
+	idleTimeout time.Duration `default:"1m" validate:"omitempty,min=1s,max=10m"`
+
+	logger       *zap.Logger     `option:"mandatory" validate:"required"`
+	eventStream  eventStream     `option:"mandatory" validate:"required"`
+	eventAdapter EventAdapter    `option:"mandatory" validate:"required"`
+	shutdownCh   <-chan struct{} `option:"mandatory" validate:"required"`
+}
+
+// PollHandler returns the events following the since cursor, waiting for them up to the poll timeout.
+// The user is subscribed on the event stream on the first poll and the events are kept
+// in the backlog between the polls until the user stops polling.
+type PollHandler struct {
+	PollOptions
+
+	mu       sync.Mutex
+	backlogs map[types.UserID]*backlog
+}
+
+func NewPollHandler(opts PollOptions) (*PollHandler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating poll http handler options: %v", err)
+	}
+	if opts.idleTimeout <= opts.pollTimeout {
+		return nil, errIdleLessThanPoll
+	}
+	return &PollHandler{
+		PollOptions: opts,
+		backlogs:    make(map[types.UserID]*backlog),
+	}, nil
+}
+
+type pollResponse struct {
+	Events []any `json:"events"`
+	// Cursor is the since parameter for the next poll.
+	Cursor *types.EventID `json:"cursor"`
+}
+
+func (h *PollHandler) Serve(eCtx echo.Context) error {
+	var since types.EventID
+	if s := eCtx.QueryParam("since"); s != "" {
+		var err error
+		if since, err = types.Parse[types.EventID](s); err != nil {
+			return servererrors.NewServerError(http.StatusBadRequest, "invalid since cursor", err)
+		}
+	}
+
+	b, err := h.backlogFor(middlewares.MustUserID(eCtx))
+	if err != nil {
+		return err
+	}
+	defer h.touch(b)
+
+	timer := time.NewTimer(h.pollTimeout)
+	defer timer.Stop()
+
+	start, cursor := b.position(since)
+	for {
+		events, changed := b.from(start)
+		if len(events) > 0 {
+			return h.respond(eCtx, events)
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return eCtx.JSON(http.StatusOK, pollResponse{Events: []any{}, Cursor: cursor})
+		case <-h.shutdownCh:
+			return eCtx.JSON(http.StatusOK, pollResponse{Events: []any{}, Cursor: cursor})
+		case <-eCtx.Request().Context().Done():
+			return nil
+		}
+	}
+}
+
+func (h *PollHandler) respond(eCtx echo.Context, events []eventstream.Event) error {
+	adapted := make([]any, 0, len(events))
+	for _, e := range events {
+		a, err := h.eventAdapter.Adapt(e)
+		if err != nil {
+			return fmt.Errorf("adapt event: %v", err)
+		}
+		adapted = append(adapted, a)
+	}
+	last := events[len(events)-1].ID()
+	return eCtx.JSON(http.StatusOK, pollResponse{Events: adapted, Cursor: &last})
+}
+
+func (h *PollHandler) backlogFor(userID types.UserID) (*backlog, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if b, ok := h.backlogs[userID]; ok {
+		b.idle.Reset(h.idleTimeout)
+		return b, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventsCh, err := h.eventStream.Subscribe(ctx, userID)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("subscribe on event stream: %v", err)
+	}
+	b := newBacklog(h.backlogSize, cancel)
+	b.idle = time.AfterFunc(h.idleTimeout, func() { h.expire(userID, b) })
+	h.backlogs[userID] = b
+	go b.collect(eventsCh)
+	return b, nil
+}
+
+// touch restarts the idle timeout after the poll.
+func (h *PollHandler) touch(b *backlog) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	b.idle.Reset(h.idleTimeout)
+}
+
+func (h *PollHandler) expire(userID types.UserID, b *backlog) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.backlogs[userID] == b {
+		delete(h.backlogs, userID)
+	}
+	b.cancel()
+}
+
+// backlog keeps the recent events of the user.
+type backlog struct {
+	size   int
+	cancel context.CancelFunc
+	idle   *time.Timer
+
+	mu      sync.Mutex
+	events  []eventstream.Event // Oldest first.
+	offset  int                 // Number of the pushed out events.
+	changed chan struct{}       // Closed and replaced on every event.
+}
+
+func newBacklog(size int, cancel context.CancelFunc) *backlog {
+	return &backlog{
+		size:    size,
+		cancel:  cancel,
+		changed: make(chan struct{}),
+	}
+}
+
+func (b *backlog) collect(eventsCh <-chan eventstream.Event) {
+	for e := range eventsCh {
+		b.mu.Lock()
+		b.events = append(b.events, e)
+		if n := len(b.events) - b.size; n > 0 {
+			b.events = append([]eventstream.Event(nil), b.events[n:]...)
+			b.offset += n
+		}
+		close(b.changed)
+		b.changed = make(chan struct{})
+		b.mu.Unlock()
+	}
+}
+
+// position returns the position of the event following the cursor and the cursor to return if there are none.
+// No cursor means the events from now on, the unknown one, e.g. pushed out, means all the kept events.
+func (b *backlog) position(since types.EventID) (int, *types.EventID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if since.IsZero() {
+		if len(b.events) == 0 {
+			return b.offset, nil
+		}
+		last := b.events[len(b.events)-1].ID()
+		return b.offset + len(b.events), &last
+	}
+	for i := len(b.events) - 1; i >= 0; i-- {
+		if b.events[i].ID() == since {
+			return b.offset + i + 1, &since
+		}
+	}
+	return b.offset, &since
+}
+
+// from returns the events starting with the position and the channel closed on the next event.
+func (b *backlog) from(pos int) ([]eventstream.Event, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := pos - b.offset
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(b.events) {
+		return nil, b.changed
+	}
+	return append([]eventstream.Event(nil), b.events[i:]...), b.changed
+}
@@ -0,0 +1,117 @@
+// Code generated by options-gen. DO NOT EDIT.
+package websocketstream
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type OptPollOptionsSetter func(o *PollOptions)
+
+func NewPollOptions(
+	logger *zap.Logger,
+	eventStream eventStream,
+	eventAdapter EventAdapter,
+	shutdownCh <-chan struct{},
+	options ...OptPollOptionsSetter,
+) PollOptions {
+	o := PollOptions{}
+
+	// Setting defaults from field tag (if present)
+	o.pollTimeout, _ = time.ParseDuration("25s")
+	o.backlogSize = 100
+	o.idleTimeout, _ = time.ParseDuration("1m")
+
+	o.logger = logger
+	o.eventStream = eventStream
+	o.eventAdapter = eventAdapter
+	o.shutdownCh = shutdownCh
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithPollTimeout(opt time.Duration) OptPollOptionsSetter {
+	return func(o *PollOptions) {
+		o.pollTimeout = opt
+	}
+}
+
+func WithBacklogSize(opt int) OptPollOptionsSetter {
+	return func(o *PollOptions) {
+		o.backlogSize = opt
+	}
+}
+
+func WithIdleTimeout(opt time.Duration) OptPollOptionsSetter {
+	return func(o *PollOptions) {
+		o.idleTimeout = opt
+	}
+}
+
+func (o *PollOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("pollTimeout", _validate_PollOptions_pollTimeout(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("backlogSize", _validate_PollOptions_backlogSize(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("idleTimeout", _validate_PollOptions_idleTimeout(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("logger", _validate_PollOptions_logger(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("eventStream", _validate_PollOptions_eventStream(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("eventAdapter", _validate_PollOptions_eventAdapter(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("shutdownCh", _validate_PollOptions_shutdownCh(o)))
+	return errs.AsError()
+}
+
+func _validate_PollOptions_pollTimeout(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.pollTimeout, "omitempty,min=1s,max=1m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `pollTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_PollOptions_backlogSize(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.backlogSize, "omitempty,min=1,max=10000"); err != nil {
+		return fmt461e464ebed9.Errorf("field `backlogSize` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_PollOptions_idleTimeout(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.idleTimeout, "omitempty,min=1s,max=10m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `idleTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_PollOptions_logger(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.logger, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `logger` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_PollOptions_eventStream(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.eventStream, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `eventStream` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_PollOptions_eventAdapter(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.eventAdapter, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `eventAdapter` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_PollOptions_shutdownCh(o *PollOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.shutdownCh, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `shutdownCh` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package websocketstream_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	servererrors "github.com/gerladeno/chat-service/internal/errors"
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+func TestPollHandler(t *testing.T) {
+	const pollTimeout = time.Second
+
+	uid := types.NewUserID()
+	eventsCh := make(chan eventstream.Event)
+
+	h, err := websocketstream.NewPollHandler(websocketstream.NewPollOptions(
+		zap.L(),
+		eventStreamMock{uid: uid, ch: eventsCh},
+		eventAdapter{},
+		make(chan struct{}),
+		websocketstream.WithPollTimeout(pollTimeout),
+		websocketstream.WithBacklogSize(2),
+	))
+	require.NoError(t, err)
+
+	newEvent := func() eventstream.Event {
+		return eventstream.NewMessageSentEvent(types.NewEventID(), types.NewRequestID(), types.NewMessageID())
+	}
+	events := []eventstream.Event{newEvent(), newEvent(), newEvent(), newEvent()}
+
+	t.Run("waits for the next event", func(t *testing.T) {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			eventsCh <- events[0]
+		}()
+
+		resp := poll(t, h, uid, "")
+		require.Len(t, resp.Events, 1)
+		assert.Equal(t, events[0].ID(), resp.Events[0].EventID)
+		assert.Equal(t, events[0].ID(), *resp.Cursor)
+	})
+
+	t.Run("returns the events kept between polls", func(t *testing.T) {
+		eventsCh <- events[1]
+		eventsCh <- events[2]
+		time.Sleep(50 * time.Millisecond) // Let the backlog collect.
+
+		resp := poll(t, h, uid, events[0].ID().String())
+		require.Len(t, resp.Events, 2)
+		assert.Equal(t, events[1].ID(), resp.Events[0].EventID)
+		assert.Equal(t, events[2].ID(), resp.Events[1].EventID)
+		assert.Equal(t, events[2].ID(), *resp.Cursor)
+	})
+
+	t.Run("nothing new", func(t *testing.T) {
+		start := time.Now()
+		resp := poll(t, h, uid, events[2].ID().String())
+		assert.Empty(t, resp.Events)
+		assert.Equal(t, events[2].ID(), *resp.Cursor)
+		assert.GreaterOrEqual(t, time.Since(start), pollTimeout)
+	})
+
+	t.Run("pushed out cursor returns all kept events", func(t *testing.T) {
+		eventsCh <- events[3]
+		time.Sleep(50 * time.Millisecond)
+
+		resp := poll(t, h, uid, events[0].ID().String())
+		require.Len(t, resp.Events, 2)
+		assert.Equal(t, events[2].ID(), resp.Events[0].EventID)
+		assert.Equal(t, events[3].ID(), resp.Events[1].EventID)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/events/poll?since=42", nil)
+		err := middlewares.AuthWith(uid)(h.Serve)(echo.New().NewContext(req, httptest.NewRecorder()))
+		assert.Equal(t, http.StatusBadRequest, servererrors.GetServerErrorCode(err))
+	})
+}
+
+func TestNewPollHandler_IdleLessThanPoll(t *testing.T) {
+	_, err := websocketstream.NewPollHandler(websocketstream.NewPollOptions(
+		zap.L(),
+		eventStreamMock{},
+		eventAdapter{},
+		make(chan struct{}),
+		websocketstream.WithPollTimeout(time.Minute),
+		websocketstream.WithIdleTimeout(time.Minute),
+	))
+	require.Error(t, err)
+}
+
+type pollResponse struct {
+	Events []eventstream.MessageSentEvent `json:"events"`
+	Cursor *types.EventID                 `json:"cursor"`
+}
+
+func poll(t *testing.T, h *websocketstream.PollHandler, uid types.UserID, since string) pollResponse {
+	t.Helper()
+
+	target := "/events/poll"
+	if since != "" {
+		target += "?since=" + since
+	}
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, middlewares.AuthWith(uid)(h.Serve)(echo.New().NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp pollResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
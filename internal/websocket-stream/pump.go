@@ -0,0 +1,54 @@
+package websocketstream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// eventSink is the transport the events are delivered to the user through.
+type eventSink interface {
+	writeEvent(id types.EventID, adapted any) error
+	// heartbeat keeps the connection alive and reveals the dead one.
+	heartbeat() error
+}
+
+// pumpEvents adapts the events from the stream and writes them to the sink sending the heartbeat every period.
+// It returns nil when the stream is closed or the context is done.
+func pumpEvents(
+	ctx context.Context,
+	events <-chan eventstream.Event,
+	adapter EventAdapter,
+	heartbeatPeriod time.Duration,
+	sink eventSink,
+) error {
+	t := time.NewTicker(heartbeatPeriod)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-t.C:
+			if err := sink.heartbeat(); err != nil {
+				return fmt.Errorf("heartbeat: %v", err)
+			}
+
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			adapted, err := adapter.Adapt(event)
+			if err != nil {
+				return fmt.Errorf("adapt event: %v", err)
+			}
+			if err := sink.writeEvent(event.ID(), adapted); err != nil {
+				return err
+			}
+		}
+	}
+}
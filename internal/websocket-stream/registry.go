@@ -0,0 +1,140 @@
+package websocketstream
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+var (
+	ErrUserConnsLimit = errors.New("user connections limit exceeded")
+	ErrConnsLimit     = errors.New("connections limit exceeded")
+)
+
+//go:generate options-gen -out-filename=registry_options.gen.go -from-struct=RegistryOptions
+type RegistryOptions struct {
+	maxConnsPerUser int `default:"5" validate:"min=1,max=1000"`
+	maxConns        int `default:"10000" validate:"min=1"`
+}
+
+// Registry keeps the open websocket connections and limits their number.
+type Registry struct {
+	RegistryOptions
+
+	mu    sync.Mutex
+	conns map[types.UserID]map[*conn]struct{}
+	total int
+}
+
+func NewRegistry(opts RegistryOptions) (*Registry, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating ws registry options: %v", err)
+	}
+	return &Registry{
+		RegistryOptions: opts,
+		conns:           make(map[types.UserID]map[*conn]struct{}),
+	}, nil
+}
+
+// ConnInfo describes the open connection.
+type ConnInfo struct {
+	UserID      types.UserID  `json:"userId"`
+	RemoteAddr  string        `json:"remoteAddr"`
+	Subprotocol string        `json:"subprotocol"`
+	ConnectedAt time.Time     `json:"connectedAt"`
+	Age         time.Duration `json:"age"`
+	BytesSent   int64         `json:"bytesSent"`
+}
+
+// Connections returns the open connections, the oldest first.
+func (r *Registry) Connections() []ConnInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	result := make([]ConnInfo, 0, r.total)
+	for userID, conns := range r.conns {
+		for c := range conns {
+			result = append(result, ConnInfo{
+				UserID:      userID,
+				RemoteAddr:  c.remoteAddr,
+				Subprotocol: c.subprotocol,
+				ConnectedAt: c.connectedAt,
+				Age:         now.Sub(c.connectedAt),
+				BytesSent:   c.bytesSent.Load(),
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ConnectedAt.Before(result[j].ConnectedAt) })
+	return result
+}
+
+// Disconnect closes the user connections with the policy violation code, e.g. when the session is revoked.
+// It returns the number of the closed connections.
+func (r *Registry) Disconnect(userID types.UserID) int {
+	r.mu.Lock()
+	conns := make([]*conn, 0, len(r.conns[userID]))
+	for c := range r.conns[userID] {
+		conns = append(conns, c)
+	}
+	r.mu.Unlock()
+
+	for _, c := range conns {
+		go c.close(gorillaws.ClosePolicyViolation)
+	}
+	return len(conns)
+}
+
+type conn struct {
+	remoteAddr  string
+	subprotocol string
+	connectedAt time.Time
+	bytesSent   atomic.Int64
+	close       func(code int)
+}
+
+func (r *Registry) register(userID types.UserID, remoteAddr, subprotocol string, closeFn func(code int)) (*conn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.total >= r.maxConns {
+		return nil, ErrConnsLimit
+	}
+	if len(r.conns[userID]) >= r.maxConnsPerUser {
+		return nil, ErrUserConnsLimit
+	}
+
+	c := &conn{
+		remoteAddr:  remoteAddr,
+		subprotocol: subprotocol,
+		connectedAt: time.Now(),
+		close:       closeFn,
+	}
+	if r.conns[userID] == nil {
+		r.conns[userID] = make(map[*conn]struct{})
+	}
+	r.conns[userID][c] = struct{}{}
+	r.total++
+	return c, nil
+}
+
+func (r *Registry) unregister(userID types.UserID, c *conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.conns[userID][c]; !ok {
+		return
+	}
+	delete(r.conns[userID], c)
+	if len(r.conns[userID]) == 0 {
+		delete(r.conns, userID)
+	}
+	r.total--
+}
@@ -0,0 +1,59 @@
+// Code generated by options-gen. DO NOT EDIT.
+package websocketstream
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptRegistryOptionsSetter func(o *RegistryOptions)
+
+func NewRegistryOptions(
+	options ...OptRegistryOptionsSetter,
+) RegistryOptions {
+	o := RegistryOptions{}
+
+	// Setting defaults from field tag (if present)
+	o.maxConnsPerUser = 5
+	o.maxConns = 10000
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithMaxConnsPerUser(opt int) OptRegistryOptionsSetter {
+	return func(o *RegistryOptions) {
+		o.maxConnsPerUser = opt
+	}
+}
+
+func WithMaxConns(opt int) OptRegistryOptionsSetter {
+	return func(o *RegistryOptions) {
+		o.maxConns = opt
+	}
+}
+
+func (o *RegistryOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("maxConnsPerUser", _validate_RegistryOptions_maxConnsPerUser(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxConns", _validate_RegistryOptions_maxConns(o)))
+	return errs.AsError()
+}
+
+func _validate_RegistryOptions_maxConnsPerUser(o *RegistryOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxConnsPerUser, "min=1,max=1000"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxConnsPerUser` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_RegistryOptions_maxConns(o *RegistryOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxConns, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxConns` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package websocketstream_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+func TestRegistry(t *testing.T) {
+	const (
+		origin        = "http://localhost"
+		secWsProtocol = "chat-service-protocol.test"
+	)
+
+	// Arrange.
+	uid := types.NewUserID()
+	eventsCh := make(chan eventstream.Event)
+	shutdownCh := make(chan struct{})
+	defer close(shutdownCh)
+
+	registry, err := websocketstream.NewRegistry(websocketstream.NewRegistryOptions(
+		websocketstream.WithMaxConnsPerUser(1),
+		websocketstream.WithMaxConns(2),
+	))
+	require.NoError(t, err)
+
+	h, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+		zap.L(),
+		anyUserEventStream(eventsCh),
+		eventAdapter{},
+		websocketstream.JSONEventWriter{},
+		newUpgrader(t, origin, secWsProtocol),
+		shutdownCh,
+		websocketstream.WithRegistry(registry),
+	))
+	require.NoError(t, err)
+
+	users := make(chan types.UserID, 1)
+	e := echo.New()
+	e.GET("/ws", func(c echo.Context) error {
+		return middlewares.AuthWith(<-users)(h.Serve)(c)
+	})
+	s := httptest.NewServer(e)
+	defer s.Close()
+
+	dial := func(userID types.UserID) *gorillaws.Conn {
+		t.Helper()
+
+		users <- userID
+		dialer := gorillaws.Dialer{Subprotocols: []string{secWsProtocol}}
+		c, resp, err := dialer.Dial("ws://"+s.Listener.Addr().String()+"/ws", http.Header{echo.HeaderOrigin: {origin}})
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		t.Cleanup(func() { _ = c.Close() })
+		return c
+	}
+	requireClosedWith := func(c *gorillaws.Conn, code int) {
+		t.Helper()
+
+		_ = c.SetReadDeadline(time.Now().Add(3 * time.Second))
+		_, _, err := c.ReadMessage()
+		require.Error(t, err)
+		assert.True(t, gorillaws.IsCloseError(err, code), err)
+	}
+
+	// Action & assert.
+	first := dial(uid)
+	eventsCh <- eventstream.NewMessageSentEvent(types.NewEventID(), types.NewRequestID(), types.NewMessageID())
+	_, _, err = first.ReadMessage()
+	require.NoError(t, err)
+
+	conns := registry.Connections()
+	require.Len(t, conns, 1)
+	assert.Equal(t, uid, conns[0].UserID)
+	assert.Equal(t, secWsProtocol, conns[0].Subprotocol)
+	assert.Positive(t, conns[0].BytesSent)
+	assert.Positive(t, conns[0].Age)
+
+	t.Run("user limit", func(t *testing.T) {
+		requireClosedWith(dial(uid), gorillaws.ClosePolicyViolation)
+		assert.Len(t, registry.Connections(), 1)
+	})
+
+	t.Run("global limit", func(t *testing.T) {
+		_ = dial(types.NewUserID())
+		requireClosedWith(dial(types.NewUserID()), gorillaws.ClosePolicyViolation)
+		assert.Len(t, registry.Connections(), 2)
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		assert.Equal(t, 1, registry.Disconnect(uid))
+		requireClosedWith(first, gorillaws.ClosePolicyViolation)
+		require.Eventually(t, func() bool {
+			return len(registry.Connections()) == 1
+		}, 3*time.Second, 50*time.Millisecond)
+		assert.Zero(t, registry.Disconnect(uid))
+	})
+}
+
+type anyUserEventStream chan eventstream.Event
+
+func (s anyUserEventStream) Subscribe(context.Context, types.UserID) (<-chan eventstream.Event, error) {
+	return s, nil
+}
@@ -0,0 +1,94 @@
+package websocketstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+// CloseSessionExpired is the close code of the connection whose token has expired.
+// The client is expected to get a fresh token and reconnect.
+const CloseSessionExpired = 4001
+
+const (
+	authFrameType = "auth"
+	reauthTimeout = 5 * time.Second
+)
+
+var errAnotherUser = errors.New("token of another user")
+
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (types.UserID, time.Time, error)
+}
+
+// authFrame is sent by the client to prolong the session with a fresh token:
+// {"type": "auth", "token": "<access token>"}.
+type authFrame struct {
+	Type  string `json:"type"`
+	Token string `json:"token"`
+}
+
+// session closes the connection once the token expires.
+type session struct {
+	userID types.UserID
+
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// newSession starts the expiry timer, the zero expiresAt means the session never expires.
+func newSession(userID types.UserID, expiresAt time.Time, expire func()) *session {
+	s := &session{userID: userID}
+	if !expiresAt.IsZero() {
+		s.timer = time.AfterFunc(time.Until(expiresAt), expire)
+	}
+	return s
+}
+
+func (s *session) prolong(expiresAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer == nil {
+		return
+	}
+	if expiresAt.IsZero() {
+		s.timer.Stop()
+		return
+	}
+	s.timer.Reset(time.Until(expiresAt))
+}
+
+func (s *session) stop() {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+}
+
+// reauth verifies the token from the client frame and prolongs the session with it.
+// Frames other than auth ones are ignored.
+func (s *session) reauth(ctx context.Context, verifier TokenVerifier, r io.Reader) error {
+	var frame authFrame
+	if err := json.NewDecoder(r).Decode(&frame); err != nil || frame.Type != authFrameType {
+		return nil //nolint:nilerr // Not an auth frame.
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reauthTimeout)
+	defer cancel()
+
+	userID, expiresAt, err := verifier.VerifyToken(ctx, frame.Token)
+	if err != nil {
+		return fmt.Errorf("verify token: %v", err)
+	}
+	if userID != s.userID {
+		return errAnotherUser
+	}
+	s.prolong(expiresAt)
+	return nil
+}
@@ -0,0 +1,123 @@
+package websocketstream_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+func TestSessionExpiry(t *testing.T) {
+	const (
+		origin        = "http://localhost"
+		secWsProtocol = "chat-service-protocol.test"
+		tokenTTL      = 500 * time.Millisecond
+	)
+
+	uid := types.NewUserID()
+	verifier := tokenVerifierMock{
+		"fresh":   {uid: uid, ttl: 2 * tokenTTL},
+		"another": {uid: types.NewUserID(), ttl: tokenTTL},
+	}
+
+	cases := []struct {
+		name      string
+		frame     any
+		wantCode  int
+		minLasted time.Duration
+	}{
+		{
+			name:      "expired",
+			wantCode:  websocketstream.CloseSessionExpired,
+			minLasted: tokenTTL,
+		},
+		{
+			name:      "prolonged",
+			frame:     map[string]string{"type": "auth", "token": "fresh"},
+			wantCode:  websocketstream.CloseSessionExpired,
+			minLasted: 2 * tokenTTL,
+		},
+		{
+			name:      "invalid token keeps session",
+			frame:     map[string]string{"type": "auth", "token": "invalid"},
+			wantCode:  websocketstream.CloseSessionExpired,
+			minLasted: tokenTTL,
+		},
+		{
+			name:     "another user",
+			frame:    map[string]string{"type": "auth", "token": "another"},
+			wantCode: gorillaws.ClosePolicyViolation,
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			shutdownCh := make(chan struct{})
+			defer close(shutdownCh)
+
+			h, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+				zap.L(),
+				anyUserEventStream(make(chan eventstream.Event)),
+				eventAdapter{},
+				websocketstream.JSONEventWriter{},
+				newUpgrader(t, origin, secWsProtocol),
+				shutdownCh,
+				websocketstream.WithTokenVerifier(verifier),
+			))
+			require.NoError(t, err)
+
+			start := time.Now()
+			e := echo.New()
+			e.GET("/ws", middlewares.AuthWithExpiry(uid, start.Add(tokenTTL))(h.Serve))
+			s := httptest.NewServer(e)
+			defer s.Close()
+
+			dialer := gorillaws.Dialer{Subprotocols: []string{secWsProtocol}}
+			c, resp, err := dialer.Dial("ws://"+s.Listener.Addr().String()+"/ws", http.Header{echo.HeaderOrigin: {origin}})
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			defer c.Close()
+
+			if tt.frame != nil {
+				require.NoError(t, c.WriteJSON(tt.frame))
+			}
+
+			// Reading answers the pings until the close frame.
+			_ = c.SetReadDeadline(time.Now().Add(5 * tokenTTL))
+			for err == nil {
+				_, _, err = c.ReadMessage()
+			}
+			var closeErr *gorillaws.CloseError
+			require.True(t, errors.As(err, &closeErr), err)
+			assert.Equal(t, tt.wantCode, closeErr.Code)
+			assert.GreaterOrEqual(t, time.Since(start), tt.minLasted)
+		})
+	}
+}
+
+type tokenVerifierMock map[string]struct {
+	uid types.UserID
+	ttl time.Duration
+}
+
+func (m tokenVerifierMock) VerifyToken(_ context.Context, token string) (types.UserID, time.Time, error) {
+	t, ok := m[token]
+	if !ok {
+		return types.UserIDNil, time.Time{}, errors.New("invalid token")
+	}
+	return t.uid, time.Now().Add(t.ttl), nil
+}
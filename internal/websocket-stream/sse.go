@@ -0,0 +1,92 @@
+package websocketstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	"github.com/gerladeno/chat-service/internal/types"
+)
+
+//go:generate options-gen -out-filename=sse_options.gen.go -from-struct=SSEOptions
+type SSEOptions struct {
+	heartbeatPeriod time.Duration `default:"15s" validate:"omitempty,min=100ms,max=1m"`
+
+	logger       *zap.Logger     `option:"mandatory" validate:"required"`
+	eventStream  eventStream     `option:"mandatory" validate:"required"`
+	eventAdapter EventAdapter    `option:"mandatory" validate:"required"`
+	shutdownCh   <-chan struct{} `option:"mandatory" validate:"required"`
+}
+
+// SSEHandler streams the events as Server-Sent Events for the networks the websockets are broken in.
+type SSEHandler struct {
+	SSEOptions
+}
+
+func NewSSEHandler(opts SSEOptions) (*SSEHandler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating sse http handler options: %v", err)
+	}
+	return &SSEHandler{SSEOptions: opts}, nil
+}
+
+func (h *SSEHandler) Serve(eCtx echo.Context) error {
+	ctx, cancel := context.WithCancel(eCtx.Request().Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-h.shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	eventsCh, err := h.eventStream.Subscribe(ctx, middlewares.MustUserID(eCtx))
+	if err != nil {
+		return fmt.Errorf("subscribe on event stream: %v", err)
+	}
+
+	resp := eCtx.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.Header().Set("X-Accel-Buffering", "no") // Disables the proxy buffering.
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	// The response is already started, so the error is only logged.
+	if err := pumpEvents(ctx, eventsCh, h.eventAdapter, h.heartbeatPeriod, &sseSink{resp: resp}); err != nil {
+		h.logger.Warn("sse stream", zap.Error(err))
+	}
+	return nil
+}
+
+type sseSink struct {
+	resp *echo.Response
+}
+
+func (s *sseSink) heartbeat() error {
+	if _, err := fmt.Fprint(s.resp, ": heartbeat\n\n"); err != nil {
+		return err
+	}
+	s.resp.Flush()
+	return nil
+}
+
+func (s *sseSink) writeEvent(id types.EventID, adapted any) error {
+	data, err := json.Marshal(adapted)
+	if err != nil {
+		return fmt.Errorf("encode event to json: %v", err)
+	}
+	if _, err := fmt.Fprintf(s.resp, "id: %s\ndata: %s\n\n", id, data); err != nil {
+		return fmt.Errorf("write event: %v", err)
+	}
+	s.resp.Flush()
+	return nil
+}
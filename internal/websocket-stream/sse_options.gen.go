@@ -0,0 +1,87 @@
+// Code generated by options-gen. DO NOT EDIT.
+package websocketstream
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type OptSSEOptionsSetter func(o *SSEOptions)
+
+func NewSSEOptions(
+	logger *zap.Logger,
+	eventStream eventStream,
+	eventAdapter EventAdapter,
+	shutdownCh <-chan struct{},
+	options ...OptSSEOptionsSetter,
+) SSEOptions {
+	o := SSEOptions{}
+
+	// Setting defaults from field tag (if present)
+	o.heartbeatPeriod, _ = time.ParseDuration("15s")
+
+	o.logger = logger
+	o.eventStream = eventStream
+	o.eventAdapter = eventAdapter
+	o.shutdownCh = shutdownCh
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithHeartbeatPeriod(opt time.Duration) OptSSEOptionsSetter {
+	return func(o *SSEOptions) {
+		o.heartbeatPeriod = opt
+	}
+}
+
+func (o *SSEOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("heartbeatPeriod", _validate_SSEOptions_heartbeatPeriod(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("logger", _validate_SSEOptions_logger(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("eventStream", _validate_SSEOptions_eventStream(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("eventAdapter", _validate_SSEOptions_eventAdapter(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("shutdownCh", _validate_SSEOptions_shutdownCh(o)))
+	return errs.AsError()
+}
+
+func _validate_SSEOptions_heartbeatPeriod(o *SSEOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.heartbeatPeriod, "omitempty,min=100ms,max=1m"); err != nil {
+		return fmt461e464ebed9.Errorf("field `heartbeatPeriod` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_SSEOptions_logger(o *SSEOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.logger, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `logger` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_SSEOptions_eventStream(o *SSEOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.eventStream, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `eventStream` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_SSEOptions_eventAdapter(o *SSEOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.eventAdapter, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `eventAdapter` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_SSEOptions_shutdownCh(o *SSEOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.shutdownCh, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `shutdownCh` did not pass the test: %w", err)
+	}
+	return nil
+}
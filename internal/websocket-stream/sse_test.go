@@ -0,0 +1,73 @@
+package websocketstream_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gerladeno/chat-service/internal/middlewares"
+	eventstream "github.com/gerladeno/chat-service/internal/services/event-stream"
+	"github.com/gerladeno/chat-service/internal/types"
+	websocketstream "github.com/gerladeno/chat-service/internal/websocket-stream"
+)
+
+func TestSSEHandler(t *testing.T) {
+	// Arrange.
+	uid := types.NewUserID()
+	eventsCh := make(chan eventstream.Event)
+	shutdownCh := make(chan struct{})
+
+	h, err := websocketstream.NewSSEHandler(websocketstream.NewSSEOptions(
+		zap.L(),
+		eventStreamMock{uid: uid, ch: eventsCh},
+		eventAdapter{},
+		shutdownCh,
+		websocketstream.WithHeartbeatPeriod(100*time.Millisecond),
+	))
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.GET("/events/sse", middlewares.AuthWith(uid)(h.Serve))
+	s := httptest.NewServer(e)
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/events/sse")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+	// Action.
+	event := eventstream.NewMessageSentEvent(types.NewEventID(), types.NewRequestID(), types.NewMessageID())
+	go func() {
+		eventsCh <- event
+		time.Sleep(250 * time.Millisecond)
+		close(shutdownCh)
+	}()
+
+	// Assert.
+	var lines []string
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	require.NoError(t, sc.Err(), "stream is closed on shutdown")
+
+	require.GreaterOrEqual(t, len(lines), 3)
+	assert.Equal(t, "id: "+event.ID().String(), lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "data: "))
+	var got eventstream.MessageSentEvent
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &got))
+	assert.Equal(t, event.ID(), got.EventID)
+	assert.Equal(t, "", lines[2])
+	assert.Contains(t, lines, ": heartbeat")
+}
@@ -1,6 +1,7 @@
 package websocketstream
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -10,18 +11,21 @@ import (
 	"github.com/gerladeno/chat-service/pkg/utils"
 )
 
-const MessageSizeLimit = 14 * 1024 // bytes
+const handshakeTimeout = time.Second
 
 type Websocket interface {
 	SetWriteDeadline(t time.Time) error
-	NextWriter(messageType int) (io.WriteCloser, error)
+	EnableWriteCompression(enable bool)
 	WriteMessage(messageType int, data []byte) error
 	WriteControl(messageType int, data []byte, deadline time.Time) error
 
 	SetPongHandler(h func(appData string) error)
 	SetReadDeadline(t time.Time) error
+	SetReadLimit(limit int64)
 	NextReader() (messageType int, r io.Reader, err error)
 
+	Subprotocol() string
+
 	Close() error
 }
 
@@ -33,18 +37,34 @@ type upgraderImpl struct {
 	upgrader *gorillaws.Upgrader
 }
 
-func NewUpgrader(allowOrigins []string, secWsProtocol string) Upgrader {
+//go:generate options-gen -out-filename=upgrader_options.gen.go -from-struct=UpgraderOptions
+type UpgraderOptions struct {
+	allowOrigins []string `option:"mandatory" validate:"required"`
+	// secWsProtocols are accepted in the order of preference.
+	secWsProtocols  []string
+	readBufferSize  int `default:"4096" validate:"min=0,max=1048576"`
+	writeBufferSize int `default:"14336" validate:"min=0,max=1048576"`
+	// compression negotiates permessage-deflate, see Options.compressionThreshold.
+	compression bool
+}
+
+func NewUpgrader(opts UpgraderOptions) (Upgrader, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validating ws upgrader options: %v", err)
+	}
 	upgrader := &gorillaws.Upgrader{
-		WriteBufferSize:  MessageSizeLimit,
-		HandshakeTimeout: writeTimeout,
-		Subprotocols:     []string{secWsProtocol},
+		ReadBufferSize:    opts.readBufferSize,
+		WriteBufferSize:   opts.writeBufferSize,
+		HandshakeTimeout:  handshakeTimeout,
+		Subprotocols:      opts.secWsProtocols,
+		EnableCompression: opts.compression,
 		CheckOrigin: func(r *http.Request) bool {
-			return utils.SlicesCollide[string](r.Header["Origin"], allowOrigins...)
+			return utils.SlicesCollide[string](r.Header["Origin"], opts.allowOrigins...)
 		},
 	}
 	return &upgraderImpl{
 		upgrader: upgrader,
-	}
+	}, nil
 }
 
 func (u *upgraderImpl) Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (Websocket, error) {
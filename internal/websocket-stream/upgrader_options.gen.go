@@ -0,0 +1,82 @@
+// Code generated by options-gen. DO NOT EDIT.
+package websocketstream
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptUpgraderOptionsSetter func(o *UpgraderOptions)
+
+func NewUpgraderOptions(
+	allowOrigins []string,
+	options ...OptUpgraderOptionsSetter,
+) UpgraderOptions {
+	o := UpgraderOptions{}
+
+	// Setting defaults from field tag (if present)
+	o.readBufferSize = 4096
+	o.writeBufferSize = 14336
+
+	o.allowOrigins = allowOrigins
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithSecWsProtocols(opt []string) OptUpgraderOptionsSetter {
+	return func(o *UpgraderOptions) {
+		o.secWsProtocols = opt
+	}
+}
+
+func WithReadBufferSize(opt int) OptUpgraderOptionsSetter {
+	return func(o *UpgraderOptions) {
+		o.readBufferSize = opt
+	}
+}
+
+func WithWriteBufferSize(opt int) OptUpgraderOptionsSetter {
+	return func(o *UpgraderOptions) {
+		o.writeBufferSize = opt
+	}
+}
+
+func WithCompression(opt bool) OptUpgraderOptionsSetter {
+	return func(o *UpgraderOptions) {
+		o.compression = opt
+	}
+}
+
+func (o *UpgraderOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("allowOrigins", _validate_UpgraderOptions_allowOrigins(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("readBufferSize", _validate_UpgraderOptions_readBufferSize(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("writeBufferSize", _validate_UpgraderOptions_writeBufferSize(o)))
+	return errs.AsError()
+}
+
+func _validate_UpgraderOptions_allowOrigins(o *UpgraderOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.allowOrigins, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `allowOrigins` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_UpgraderOptions_readBufferSize(o *UpgraderOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.readBufferSize, "min=0,max=1048576"); err != nil {
+		return fmt461e464ebed9.Errorf("field `readBufferSize` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_UpgraderOptions_writeBufferSize(o *UpgraderOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.writeBufferSize, "min=0,max=1048576"); err != nil {
+		return fmt461e464ebed9.Errorf("field `writeBufferSize` did not pass the test: %w", err)
+	}
+	return nil
+}